@@ -220,6 +220,8 @@ type RunCommand struct {
 
 	StalledWorkerTimeout time.Duration `long:"stalled-worker-timeout" default:"0s" description:"Period after which stalled (unresponsive, non-ephemeral) workers will be automatically pruned along with their cache state. 0s (the default) means stalled workers are never automatically pruned and must be removed manually with 'fly prune-worker'."`
 
+	ATCName string `long:"atc-name" description:"Name telling this ATC apart from the other ATCs of the cluster, recorded on the workers whose state it changes. Defaults to the hostname."`
+
 	BuildTrackerInterval time.Duration `long:"build-tracker-interval" default:"10s" description:"Interval on which to run build tracking."`
 
 	TelemetryOptIn bool `long:"telemetry-opt-in" hidden:"true" description:"Enable anonymous concourse version reporting."`
@@ -1368,7 +1370,18 @@ func (cmd *RunCommand) gcComponents(
 	gcConn db.DbConn,
 	lockFactory lock.LockFactory,
 ) ([]RunnableComponent, error) {
-	dbWorkerLifecycle := db.NewWorkerLifecycle(gcConn)
+	atcID, err := cmd.atcID()
+	if err != nil {
+		return nil, err
+	}
+
+	dbWorkerLifecycle := db.NewWorkerLifecycleWithOptions(gcConn, db.WorkerLifecycleOptions{
+		ATCID: atcID,
+		Emitter: metric.WorkerLifecycleEmitter{
+			Logger:  logger.Session("worker-lifecycle"),
+			Monitor: metric.Metrics,
+		},
+	})
 	dbResourceCacheLifecycle := db.NewResourceCacheLifecycle(gcConn)
 	dbTaskCacheLifecycle := db.NewTaskCacheLifecycle(gcConn)
 	dbContainerRepository := db.NewContainerRepository(gcConn)
@@ -1758,6 +1771,21 @@ func (cmd *RunCommand) debugBindAddr() string {
 	return fmt.Sprintf("%s:%d", cmd.DebugBindIP, cmd.DebugBindPort)
 }
 
+// atcID returns the name the worker lifecycle records on the workers this ATC
+// changes, which is the hostname unless a name is configured.
+func (cmd *RunCommand) atcID() (string, error) {
+	if cmd.ATCName != "" {
+		return cmd.ATCName, nil
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "", fmt.Errorf("failed to get hostname to identify the ATC, set --atc-name instead: %w", err)
+	}
+
+	return hostname, nil
+}
+
 func (cmd *RunCommand) configureMetrics(logger lager.Logger) error {
 	host := cmd.Metrics.HostName
 	if host == "" {
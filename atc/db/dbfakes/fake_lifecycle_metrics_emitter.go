@@ -0,0 +1,79 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeLifecycleMetricsEmitter struct {
+	LifecycleQueryCompletedStub        func(string, time.Duration, int)
+	lifecycleQueryCompletedMutex       sync.RWMutex
+	lifecycleQueryCompletedArgsForCall []struct {
+		arg1 string
+		arg2 time.Duration
+		arg3 int
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeLifecycleMetricsEmitter) LifecycleQueryCompleted(arg1 string, arg2 time.Duration, arg3 int) {
+	fake.lifecycleQueryCompletedMutex.Lock()
+	fake.lifecycleQueryCompletedArgsForCall = append(fake.lifecycleQueryCompletedArgsForCall, struct {
+		arg1 string
+		arg2 time.Duration
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.LifecycleQueryCompletedStub
+	fake.recordInvocation("LifecycleQueryCompleted", []interface{}{arg1, arg2, arg3})
+	fake.lifecycleQueryCompletedMutex.Unlock()
+	if stub != nil {
+		fake.LifecycleQueryCompletedStub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *FakeLifecycleMetricsEmitter) LifecycleQueryCompletedCallCount() int {
+	fake.lifecycleQueryCompletedMutex.RLock()
+	defer fake.lifecycleQueryCompletedMutex.RUnlock()
+	return len(fake.lifecycleQueryCompletedArgsForCall)
+}
+
+func (fake *FakeLifecycleMetricsEmitter) LifecycleQueryCompletedCalls(stub func(string, time.Duration, int)) {
+	fake.lifecycleQueryCompletedMutex.Lock()
+	defer fake.lifecycleQueryCompletedMutex.Unlock()
+	fake.LifecycleQueryCompletedStub = stub
+}
+
+func (fake *FakeLifecycleMetricsEmitter) LifecycleQueryCompletedArgsForCall(i int) (string, time.Duration, int) {
+	fake.lifecycleQueryCompletedMutex.RLock()
+	defer fake.lifecycleQueryCompletedMutex.RUnlock()
+	argsForCall := fake.lifecycleQueryCompletedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeLifecycleMetricsEmitter) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeLifecycleMetricsEmitter) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.LifecycleMetricsEmitter = new(FakeLifecycleMetricsEmitter)
@@ -0,0 +1,80 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeLifecycleObserver struct {
+	WorkerStateChangedStub        func(string, db.WorkerState, db.WorkerState, string)
+	workerStateChangedMutex       sync.RWMutex
+	workerStateChangedArgsForCall []struct {
+		arg1 string
+		arg2 db.WorkerState
+		arg3 db.WorkerState
+		arg4 string
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeLifecycleObserver) WorkerStateChanged(arg1 string, arg2 db.WorkerState, arg3 db.WorkerState, arg4 string) {
+	fake.workerStateChangedMutex.Lock()
+	fake.workerStateChangedArgsForCall = append(fake.workerStateChangedArgsForCall, struct {
+		arg1 string
+		arg2 db.WorkerState
+		arg3 db.WorkerState
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.WorkerStateChangedStub
+	fake.recordInvocation("WorkerStateChanged", []interface{}{arg1, arg2, arg3, arg4})
+	fake.workerStateChangedMutex.Unlock()
+	if stub != nil {
+		fake.WorkerStateChangedStub(arg1, arg2, arg3, arg4)
+	}
+}
+
+func (fake *FakeLifecycleObserver) WorkerStateChangedCallCount() int {
+	fake.workerStateChangedMutex.RLock()
+	defer fake.workerStateChangedMutex.RUnlock()
+	return len(fake.workerStateChangedArgsForCall)
+}
+
+func (fake *FakeLifecycleObserver) WorkerStateChangedCalls(stub func(string, db.WorkerState, db.WorkerState, string)) {
+	fake.workerStateChangedMutex.Lock()
+	defer fake.workerStateChangedMutex.Unlock()
+	fake.WorkerStateChangedStub = stub
+}
+
+func (fake *FakeLifecycleObserver) WorkerStateChangedArgsForCall(i int) (string, db.WorkerState, db.WorkerState, string) {
+	fake.workerStateChangedMutex.RLock()
+	defer fake.workerStateChangedMutex.RUnlock()
+	argsForCall := fake.workerStateChangedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeLifecycleObserver) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeLifecycleObserver) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.LifecycleObserver = new(FakeLifecycleObserver)
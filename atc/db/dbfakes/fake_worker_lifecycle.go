@@ -2,6 +2,7 @@
 package dbfakes
 
 import (
+	"context"
 	"sync"
 	"time"
 
@@ -9,9 +10,79 @@ import (
 )
 
 type FakeWorkerLifecycle struct {
-	DeleteFinishedRetiringWorkersStub        func() ([]string, error)
+	ClaimWorkerForLandingStub        func(context.Context, string, string, time.Duration) (bool, error)
+	claimWorkerForLandingMutex       sync.RWMutex
+	claimWorkerForLandingArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 time.Duration
+	}
+	claimWorkerForLandingReturns struct {
+		result1 bool
+		result2 error
+	}
+	claimWorkerForLandingReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	CleanWorkerResourceCachesStub        func(context.Context, string) (int, error)
+	cleanWorkerResourceCachesMutex       sync.RWMutex
+	cleanWorkerResourceCachesArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	cleanWorkerResourceCachesReturns struct {
+		result1 int
+		result2 error
+	}
+	cleanWorkerResourceCachesReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	CountWorkersByStateStub        func(context.Context) (map[db.WorkerState]int, error)
+	countWorkersByStateMutex       sync.RWMutex
+	countWorkersByStateArgsForCall []struct {
+		arg1 context.Context
+	}
+	countWorkersByStateReturns struct {
+		result1 map[db.WorkerState]int
+		result2 error
+	}
+	countWorkersByStateReturnsOnCall map[int]struct {
+		result1 map[db.WorkerState]int
+		result2 error
+	}
+	CountWorkersByTeamAndStateStub        func(context.Context) (map[string]map[db.WorkerState]int, error)
+	countWorkersByTeamAndStateMutex       sync.RWMutex
+	countWorkersByTeamAndStateArgsForCall []struct {
+		arg1 context.Context
+	}
+	countWorkersByTeamAndStateReturns struct {
+		result1 map[string]map[db.WorkerState]int
+		result2 error
+	}
+	countWorkersByTeamAndStateReturnsOnCall map[int]struct {
+		result1 map[string]map[db.WorkerState]int
+		result2 error
+	}
+	DeleteExpiredStalledWorkersStub        func(context.Context) ([]string, error)
+	deleteExpiredStalledWorkersMutex       sync.RWMutex
+	deleteExpiredStalledWorkersArgsForCall []struct {
+		arg1 context.Context
+	}
+	deleteExpiredStalledWorkersReturns struct {
+		result1 []string
+		result2 error
+	}
+	deleteExpiredStalledWorkersReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	DeleteFinishedRetiringWorkersStub        func(context.Context) ([]string, error)
 	deleteFinishedRetiringWorkersMutex       sync.RWMutex
 	deleteFinishedRetiringWorkersArgsForCall []struct {
+		arg1 context.Context
 	}
 	deleteFinishedRetiringWorkersReturns struct {
 		result1 []string
@@ -21,10 +92,78 @@ type FakeWorkerLifecycle struct {
 		result1 []string
 		result2 error
 	}
-	DeleteStalledWorkersStub        func(time.Duration) ([]string, error)
+	DeleteFinishedRetiringWorkersBatchStub        func(context.Context, int) ([]string, error)
+	deleteFinishedRetiringWorkersBatchMutex       sync.RWMutex
+	deleteFinishedRetiringWorkersBatchArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+	}
+	deleteFinishedRetiringWorkersBatchReturns struct {
+		result1 []string
+		result2 error
+	}
+	deleteFinishedRetiringWorkersBatchReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	DeleteFinishedRetiringWorkersCountStub        func(context.Context) (int, error)
+	deleteFinishedRetiringWorkersCountMutex       sync.RWMutex
+	deleteFinishedRetiringWorkersCountArgsForCall []struct {
+		arg1 context.Context
+	}
+	deleteFinishedRetiringWorkersCountReturns struct {
+		result1 int
+		result2 error
+	}
+	deleteFinishedRetiringWorkersCountReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	DeleteFinishedRetiringWorkersDetailedStub        func(context.Context) ([]db.WorkerAffected, error)
+	deleteFinishedRetiringWorkersDetailedMutex       sync.RWMutex
+	deleteFinishedRetiringWorkersDetailedArgsForCall []struct {
+		arg1 context.Context
+	}
+	deleteFinishedRetiringWorkersDetailedReturns struct {
+		result1 []db.WorkerAffected
+		result2 error
+	}
+	deleteFinishedRetiringWorkersDetailedReturnsOnCall map[int]struct {
+		result1 []db.WorkerAffected
+		result2 error
+	}
+	DeleteFinishedRetiringWorkersSQLStub        func() (string, []any, error)
+	deleteFinishedRetiringWorkersSQLMutex       sync.RWMutex
+	deleteFinishedRetiringWorkersSQLArgsForCall []struct {
+	}
+	deleteFinishedRetiringWorkersSQLReturns struct {
+		result1 string
+		result2 []any
+		result3 error
+	}
+	deleteFinishedRetiringWorkersSQLReturnsOnCall map[int]struct {
+		result1 string
+		result2 []any
+		result3 error
+	}
+	DeleteFinishedRetiringWorkersWithPreviousStateStub        func(context.Context) ([]db.WorkerTransition, error)
+	deleteFinishedRetiringWorkersWithPreviousStateMutex       sync.RWMutex
+	deleteFinishedRetiringWorkersWithPreviousStateArgsForCall []struct {
+		arg1 context.Context
+	}
+	deleteFinishedRetiringWorkersWithPreviousStateReturns struct {
+		result1 []db.WorkerTransition
+		result2 error
+	}
+	deleteFinishedRetiringWorkersWithPreviousStateReturnsOnCall map[int]struct {
+		result1 []db.WorkerTransition
+		result2 error
+	}
+	DeleteStalledWorkersStub        func(context.Context, time.Duration) ([]string, error)
 	deleteStalledWorkersMutex       sync.RWMutex
 	deleteStalledWorkersArgsForCall []struct {
-		arg1 time.Duration
+		arg1 context.Context
+		arg2 time.Duration
 	}
 	deleteStalledWorkersReturns struct {
 		result1 []string
@@ -34,9 +173,39 @@ type FakeWorkerLifecycle struct {
 		result1 []string
 		result2 error
 	}
-	DeleteUnresponsiveEphemeralWorkersStub        func() ([]string, error)
+	DeleteStalledWorkersCountStub        func(context.Context, time.Duration) (int, error)
+	deleteStalledWorkersCountMutex       sync.RWMutex
+	deleteStalledWorkersCountArgsForCall []struct {
+		arg1 context.Context
+		arg2 time.Duration
+	}
+	deleteStalledWorkersCountReturns struct {
+		result1 int
+		result2 error
+	}
+	deleteStalledWorkersCountReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	DeleteStalledWorkersSQLStub        func(time.Duration) (string, []any, error)
+	deleteStalledWorkersSQLMutex       sync.RWMutex
+	deleteStalledWorkersSQLArgsForCall []struct {
+		arg1 time.Duration
+	}
+	deleteStalledWorkersSQLReturns struct {
+		result1 string
+		result2 []any
+		result3 error
+	}
+	deleteStalledWorkersSQLReturnsOnCall map[int]struct {
+		result1 string
+		result2 []any
+		result3 error
+	}
+	DeleteUnresponsiveEphemeralWorkersStub        func(context.Context) ([]string, error)
 	deleteUnresponsiveEphemeralWorkersMutex       sync.RWMutex
 	deleteUnresponsiveEphemeralWorkersArgsForCall []struct {
+		arg1 context.Context
 	}
 	deleteUnresponsiveEphemeralWorkersReturns struct {
 		result1 []string
@@ -46,9 +215,427 @@ type FakeWorkerLifecycle struct {
 		result1 []string
 		result2 error
 	}
-	GetWorkerStateByNameStub        func() (map[string]db.WorkerState, error)
+	DeleteUnresponsiveEphemeralWorkersCountStub        func(context.Context) (int, error)
+	deleteUnresponsiveEphemeralWorkersCountMutex       sync.RWMutex
+	deleteUnresponsiveEphemeralWorkersCountArgsForCall []struct {
+		arg1 context.Context
+	}
+	deleteUnresponsiveEphemeralWorkersCountReturns struct {
+		result1 int
+		result2 error
+	}
+	deleteUnresponsiveEphemeralWorkersCountReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	DeleteUnresponsiveEphemeralWorkersDetailedStub        func(context.Context) ([]db.DeletedWorker, error)
+	deleteUnresponsiveEphemeralWorkersDetailedMutex       sync.RWMutex
+	deleteUnresponsiveEphemeralWorkersDetailedArgsForCall []struct {
+		arg1 context.Context
+	}
+	deleteUnresponsiveEphemeralWorkersDetailedReturns struct {
+		result1 []db.DeletedWorker
+		result2 error
+	}
+	deleteUnresponsiveEphemeralWorkersDetailedReturnsOnCall map[int]struct {
+		result1 []db.DeletedWorker
+		result2 error
+	}
+	DeleteUnresponsiveEphemeralWorkersExceptStub        func(context.Context, []string) ([]string, error)
+	deleteUnresponsiveEphemeralWorkersExceptMutex       sync.RWMutex
+	deleteUnresponsiveEphemeralWorkersExceptArgsForCall []struct {
+		arg1 context.Context
+		arg2 []string
+	}
+	deleteUnresponsiveEphemeralWorkersExceptReturns struct {
+		result1 []string
+		result2 error
+	}
+	deleteUnresponsiveEphemeralWorkersExceptReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	DeleteUnresponsiveEphemeralWorkersSQLStub        func() (string, []any, error)
+	deleteUnresponsiveEphemeralWorkersSQLMutex       sync.RWMutex
+	deleteUnresponsiveEphemeralWorkersSQLArgsForCall []struct {
+	}
+	deleteUnresponsiveEphemeralWorkersSQLReturns struct {
+		result1 string
+		result2 []any
+		result3 error
+	}
+	deleteUnresponsiveEphemeralWorkersSQLReturnsOnCall map[int]struct {
+		result1 string
+		result2 []any
+		result3 error
+	}
+	DeleteUnresponsiveEphemeralWorkersWithSkewStub        func(context.Context, time.Duration) ([]string, error)
+	deleteUnresponsiveEphemeralWorkersWithSkewMutex       sync.RWMutex
+	deleteUnresponsiveEphemeralWorkersWithSkewArgsForCall []struct {
+		arg1 context.Context
+		arg2 time.Duration
+	}
+	deleteUnresponsiveEphemeralWorkersWithSkewReturns struct {
+		result1 []string
+		result2 error
+	}
+	deleteUnresponsiveEphemeralWorkersWithSkewReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	DeleteWorkersForDeletedTeamsStub        func(context.Context) ([]string, error)
+	deleteWorkersForDeletedTeamsMutex       sync.RWMutex
+	deleteWorkersForDeletedTeamsArgsForCall []struct {
+		arg1 context.Context
+	}
+	deleteWorkersForDeletedTeamsReturns struct {
+		result1 []string
+		result2 error
+	}
+	deleteWorkersForDeletedTeamsReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	DrainWorkerStub        func(context.Context, string) error
+	drainWorkerMutex       sync.RWMutex
+	drainWorkerArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	drainWorkerReturns struct {
+		result1 error
+	}
+	drainWorkerReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EphemeralExpiryHistogramStub        func(context.Context, []time.Duration) (map[time.Duration]int, error)
+	ephemeralExpiryHistogramMutex       sync.RWMutex
+	ephemeralExpiryHistogramArgsForCall []struct {
+		arg1 context.Context
+		arg2 []time.Duration
+	}
+	ephemeralExpiryHistogramReturns struct {
+		result1 map[time.Duration]int
+		result2 error
+	}
+	ephemeralExpiryHistogramReturnsOnCall map[int]struct {
+		result1 map[time.Duration]int
+		result2 error
+	}
+	EstimateLandingDrainTimesStub        func(context.Context) (map[string]time.Duration, error)
+	estimateLandingDrainTimesMutex       sync.RWMutex
+	estimateLandingDrainTimesArgsForCall []struct {
+		arg1 context.Context
+	}
+	estimateLandingDrainTimesReturns struct {
+		result1 map[string]time.Duration
+		result2 error
+	}
+	estimateLandingDrainTimesReturnsOnCall map[int]struct {
+		result1 map[string]time.Duration
+		result2 error
+	}
+	ExpireWorkerStub        func(context.Context, string) error
+	expireWorkerMutex       sync.RWMutex
+	expireWorkerArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	expireWorkerReturns struct {
+		result1 error
+	}
+	expireWorkerReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ExtendEphemeralExpiryStub        func(context.Context, string, time.Duration) (bool, error)
+	extendEphemeralExpiryMutex       sync.RWMutex
+	extendEphemeralExpiryArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 time.Duration
+	}
+	extendEphemeralExpiryReturns struct {
+		result1 bool
+		result2 error
+	}
+	extendEphemeralExpiryReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	FindDuplicateWorkerAddressesStub        func(context.Context) (map[string][]string, error)
+	findDuplicateWorkerAddressesMutex       sync.RWMutex
+	findDuplicateWorkerAddressesArgsForCall []struct {
+		arg1 context.Context
+	}
+	findDuplicateWorkerAddressesReturns struct {
+		result1 map[string][]string
+		result2 error
+	}
+	findDuplicateWorkerAddressesReturnsOnCall map[int]struct {
+		result1 map[string][]string
+		result2 error
+	}
+	FindExpiredWorkersWithActiveBuildsStub        func(context.Context) ([]string, error)
+	findExpiredWorkersWithActiveBuildsMutex       sync.RWMutex
+	findExpiredWorkersWithActiveBuildsArgsForCall []struct {
+		arg1 context.Context
+	}
+	findExpiredWorkersWithActiveBuildsReturns struct {
+		result1 []string
+		result2 error
+	}
+	findExpiredWorkersWithActiveBuildsReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	FindIdleWorkersStub        func(context.Context, time.Duration) ([]string, error)
+	findIdleWorkersMutex       sync.RWMutex
+	findIdleWorkersArgsForCall []struct {
+		arg1 context.Context
+		arg2 time.Duration
+	}
+	findIdleWorkersReturns struct {
+		result1 []string
+		result2 error
+	}
+	findIdleWorkersReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	FindInconsistentWorkersStub        func(context.Context) ([]db.WorkerInconsistency, error)
+	findInconsistentWorkersMutex       sync.RWMutex
+	findInconsistentWorkersArgsForCall []struct {
+		arg1 context.Context
+	}
+	findInconsistentWorkersReturns struct {
+		result1 []db.WorkerInconsistency
+		result2 error
+	}
+	findInconsistentWorkersReturnsOnCall map[int]struct {
+		result1 []db.WorkerInconsistency
+		result2 error
+	}
+	FindNeverLandedWorkersStub        func(context.Context) ([]string, error)
+	findNeverLandedWorkersMutex       sync.RWMutex
+	findNeverLandedWorkersArgsForCall []struct {
+		arg1 context.Context
+	}
+	findNeverLandedWorkersReturns struct {
+		result1 []string
+		result2 error
+	}
+	findNeverLandedWorkersReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	FindScaleDownCandidatesStub        func(context.Context, time.Duration, int) ([]string, error)
+	findScaleDownCandidatesMutex       sync.RWMutex
+	findScaleDownCandidatesArgsForCall []struct {
+		arg1 context.Context
+		arg2 time.Duration
+		arg3 int
+	}
+	findScaleDownCandidatesReturns struct {
+		result1 []string
+		result2 error
+	}
+	findScaleDownCandidatesReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	FindStuckRetiringWorkersStub        func(context.Context, time.Duration) ([]string, error)
+	findStuckRetiringWorkersMutex       sync.RWMutex
+	findStuckRetiringWorkersArgsForCall []struct {
+		arg1 context.Context
+		arg2 time.Duration
+	}
+	findStuckRetiringWorkersReturns struct {
+		result1 []string
+		result2 error
+	}
+	findStuckRetiringWorkersReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	FindUnhealthyBaggageclaimWorkersStub        func(context.Context) ([]string, error)
+	findUnhealthyBaggageclaimWorkersMutex       sync.RWMutex
+	findUnhealthyBaggageclaimWorkersArgsForCall []struct {
+		arg1 context.Context
+	}
+	findUnhealthyBaggageclaimWorkersReturns struct {
+		result1 []string
+		result2 error
+	}
+	findUnhealthyBaggageclaimWorkersReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	FindWorkersBlockedByDeletedJobsStub        func(context.Context) ([]string, error)
+	findWorkersBlockedByDeletedJobsMutex       sync.RWMutex
+	findWorkersBlockedByDeletedJobsArgsForCall []struct {
+		arg1 context.Context
+	}
+	findWorkersBlockedByDeletedJobsReturns struct {
+		result1 []string
+		result2 error
+	}
+	findWorkersBlockedByDeletedJobsReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	FindWorkersMissingHeartbeatsStub        func(context.Context, int) ([]string, error)
+	findWorkersMissingHeartbeatsMutex       sync.RWMutex
+	findWorkersMissingHeartbeatsArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+	}
+	findWorkersMissingHeartbeatsReturns struct {
+		result1 []string
+		result2 error
+	}
+	findWorkersMissingHeartbeatsReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	FindWorkersWithHeadroomStub        func(context.Context, int, int) ([]db.WorkerHeadroom, error)
+	findWorkersWithHeadroomMutex       sync.RWMutex
+	findWorkersWithHeadroomArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+		arg3 int
+	}
+	findWorkersWithHeadroomReturns struct {
+		result1 []db.WorkerHeadroom
+		result2 error
+	}
+	findWorkersWithHeadroomReturnsOnCall map[int]struct {
+		result1 []db.WorkerHeadroom
+		result2 error
+	}
+	FindWorkersWithOutdatedResourceTypesStub        func(context.Context, map[string]string) ([]string, error)
+	findWorkersWithOutdatedResourceTypesMutex       sync.RWMutex
+	findWorkersWithOutdatedResourceTypesArgsForCall []struct {
+		arg1 context.Context
+		arg2 map[string]string
+	}
+	findWorkersWithOutdatedResourceTypesReturns struct {
+		result1 []string
+		result2 error
+	}
+	findWorkersWithOutdatedResourceTypesReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	ForceDeleteRetiringWorkersStub        func(context.Context) ([]string, error)
+	forceDeleteRetiringWorkersMutex       sync.RWMutex
+	forceDeleteRetiringWorkersArgsForCall []struct {
+		arg1 context.Context
+	}
+	forceDeleteRetiringWorkersReturns struct {
+		result1 []string
+		result2 error
+	}
+	forceDeleteRetiringWorkersReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	GetBuildsBlockingWorkerLandingStub        func(context.Context, string) ([]db.BlockingBuild, error)
+	getBuildsBlockingWorkerLandingMutex       sync.RWMutex
+	getBuildsBlockingWorkerLandingArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getBuildsBlockingWorkerLandingReturns struct {
+		result1 []db.BlockingBuild
+		result2 error
+	}
+	getBuildsBlockingWorkerLandingReturnsOnCall map[int]struct {
+		result1 []db.BlockingBuild
+		result2 error
+	}
+	GetChronicallyStalledWorkersStub        func(context.Context, int) ([]string, error)
+	getChronicallyStalledWorkersMutex       sync.RWMutex
+	getChronicallyStalledWorkersArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+	}
+	getChronicallyStalledWorkersReturns struct {
+		result1 []string
+		result2 error
+	}
+	getChronicallyStalledWorkersReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	GetDeletableEphemeralWorkersStub        func(context.Context) ([]string, error)
+	getDeletableEphemeralWorkersMutex       sync.RWMutex
+	getDeletableEphemeralWorkersArgsForCall []struct {
+		arg1 context.Context
+	}
+	getDeletableEphemeralWorkersReturns struct {
+		result1 []string
+		result2 error
+	}
+	getDeletableEphemeralWorkersReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	GetPendingContainerDestroysByWorkerStub        func(context.Context) (map[string]int, error)
+	getPendingContainerDestroysByWorkerMutex       sync.RWMutex
+	getPendingContainerDestroysByWorkerArgsForCall []struct {
+		arg1 context.Context
+	}
+	getPendingContainerDestroysByWorkerReturns struct {
+		result1 map[string]int
+		result2 error
+	}
+	getPendingContainerDestroysByWorkerReturnsOnCall map[int]struct {
+		result1 map[string]int
+		result2 error
+	}
+	GetRunningWorkerAddressesStub        func(context.Context) (map[string]string, error)
+	getRunningWorkerAddressesMutex       sync.RWMutex
+	getRunningWorkerAddressesArgsForCall []struct {
+		arg1 context.Context
+	}
+	getRunningWorkerAddressesReturns struct {
+		result1 map[string]string
+		result2 error
+	}
+	getRunningWorkerAddressesReturnsOnCall map[int]struct {
+		result1 map[string]string
+		result2 error
+	}
+	GetWorkerHeartbeatAgesStub        func(context.Context) (map[string]time.Duration, error)
+	getWorkerHeartbeatAgesMutex       sync.RWMutex
+	getWorkerHeartbeatAgesArgsForCall []struct {
+		arg1 context.Context
+	}
+	getWorkerHeartbeatAgesReturns struct {
+		result1 map[string]time.Duration
+		result2 error
+	}
+	getWorkerHeartbeatAgesReturnsOnCall map[int]struct {
+		result1 map[string]time.Duration
+		result2 error
+	}
+	GetWorkerProcessorsStub        func(context.Context) (map[string]string, error)
+	getWorkerProcessorsMutex       sync.RWMutex
+	getWorkerProcessorsArgsForCall []struct {
+		arg1 context.Context
+	}
+	getWorkerProcessorsReturns struct {
+		result1 map[string]string
+		result2 error
+	}
+	getWorkerProcessorsReturnsOnCall map[int]struct {
+		result1 map[string]string
+		result2 error
+	}
+	GetWorkerStateByNameStub        func(context.Context) (map[string]db.WorkerState, error)
 	getWorkerStateByNameMutex       sync.RWMutex
 	getWorkerStateByNameArgsForCall []struct {
+		arg1 context.Context
 	}
 	getWorkerStateByNameReturns struct {
 		result1 map[string]db.WorkerState
@@ -58,45 +645,6313 @@ type FakeWorkerLifecycle struct {
 		result1 map[string]db.WorkerState
 		result2 error
 	}
-	LandFinishedLandingWorkersStub        func() ([]string, error)
-	landFinishedLandingWorkersMutex       sync.RWMutex
-	landFinishedLandingWorkersArgsForCall []struct {
+	GetWorkerStateByNameForTeamStub        func(context.Context, int) (map[string]db.WorkerState, error)
+	getWorkerStateByNameForTeamMutex       sync.RWMutex
+	getWorkerStateByNameForTeamArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
 	}
-	landFinishedLandingWorkersReturns struct {
-		result1 []string
+	getWorkerStateByNameForTeamReturns struct {
+		result1 map[string]db.WorkerState
 		result2 error
 	}
-	landFinishedLandingWorkersReturnsOnCall map[int]struct {
-		result1 []string
+	getWorkerStateByNameForTeamReturnsOnCall map[int]struct {
+		result1 map[string]db.WorkerState
 		result2 error
 	}
-	StallUnresponsiveWorkersStub        func() ([]string, error)
-	stallUnresponsiveWorkersMutex       sync.RWMutex
-	stallUnresponsiveWorkersArgsForCall []struct {
+	GetWorkerStateHistoryStub        func(context.Context, string) ([]db.StateTransition, error)
+	getWorkerStateHistoryMutex       sync.RWMutex
+	getWorkerStateHistoryArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
 	}
-	stallUnresponsiveWorkersReturns struct {
-		result1 []string
+	getWorkerStateHistoryReturns struct {
+		result1 []db.StateTransition
 		result2 error
 	}
-	stallUnresponsiveWorkersReturnsOnCall map[int]struct {
-		result1 []string
+	getWorkerStateHistoryReturnsOnCall map[int]struct {
+		result1 []db.StateTransition
 		result2 error
 	}
-	invocations      map[string][][]interface{}
-	invocationsMutex sync.RWMutex
+	GetWorkerStatesPagedStub        func(context.Context, int, int) (map[string]db.WorkerState, error)
+	getWorkerStatesPagedMutex       sync.RWMutex
+	getWorkerStatesPagedArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+		arg3 int
+	}
+	getWorkerStatesPagedReturns struct {
+		result1 map[string]db.WorkerState
+		result2 error
+	}
+	getWorkerStatesPagedReturnsOnCall map[int]struct {
+		result1 map[string]db.WorkerState
+		result2 error
+	}
+	GetWorkerStatesWithCapacityStub        func(context.Context) (map[string]db.WorkerCapacity, error)
+	getWorkerStatesWithCapacityMutex       sync.RWMutex
+	getWorkerStatesWithCapacityArgsForCall []struct {
+		arg1 context.Context
+	}
+	getWorkerStatesWithCapacityReturns struct {
+		result1 map[string]db.WorkerCapacity
+		result2 error
+	}
+	getWorkerStatesWithCapacityReturnsOnCall map[int]struct {
+		result1 map[string]db.WorkerCapacity
+		result2 error
+	}
+	GetWorkerStatesWithTagsStub        func(context.Context) (map[string]db.WorkerStateTags, error)
+	getWorkerStatesWithTagsMutex       sync.RWMutex
+	getWorkerStatesWithTagsArgsForCall []struct {
+		arg1 context.Context
+	}
+	getWorkerStatesWithTagsReturns struct {
+		result1 map[string]db.WorkerStateTags
+		result2 error
+	}
+	getWorkerStatesWithTagsReturnsOnCall map[int]struct {
+		result1 map[string]db.WorkerStateTags
+		result2 error
+	}
+	GetWorkerStatesWithTeamStub        func(context.Context) (map[string]db.WorkerStateInfo, error)
+	getWorkerStatesWithTeamMutex       sync.RWMutex
+	getWorkerStatesWithTeamArgsForCall []struct {
+		arg1 context.Context
+	}
+	getWorkerStatesWithTeamReturns struct {
+		result1 map[string]db.WorkerStateInfo
+		result2 error
+	}
+	getWorkerStatesWithTeamReturnsOnCall map[int]struct {
+		result1 map[string]db.WorkerStateInfo
+		result2 error
+	}
+	GetWorkerUptimesStub        func(context.Context) (map[string]time.Duration, error)
+	getWorkerUptimesMutex       sync.RWMutex
+	getWorkerUptimesArgsForCall []struct {
+		arg1 context.Context
+	}
+	getWorkerUptimesReturns struct {
+		result1 map[string]time.Duration
+		result2 error
+	}
+	getWorkerUptimesReturnsOnCall map[int]struct {
+		result1 map[string]time.Duration
+		result2 error
+	}
+	GetWorkersInStateStub        func(context.Context, db.WorkerState) ([]string, error)
+	getWorkersInStateMutex       sync.RWMutex
+	getWorkersInStateArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.WorkerState
+	}
+	getWorkersInStateReturns struct {
+		result1 []string
+		result2 error
+	}
+	getWorkersInStateReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	HeartbeatWorkerStub        func(context.Context, string, time.Duration) (bool, error)
+	heartbeatWorkerMutex       sync.RWMutex
+	heartbeatWorkerArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 time.Duration
+	}
+	heartbeatWorkerReturns struct {
+		result1 bool
+		result2 error
+	}
+	heartbeatWorkerReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	HeartbeatWorkersStub        func(context.Context, []string, time.Duration) ([]string, error)
+	heartbeatWorkersMutex       sync.RWMutex
+	heartbeatWorkersArgsForCall []struct {
+		arg1 context.Context
+		arg2 []string
+		arg3 time.Duration
+	}
+	heartbeatWorkersReturns struct {
+		result1 []string
+		result2 error
+	}
+	heartbeatWorkersReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	LandAllWorkersStub        func(context.Context) ([]string, error)
+	landAllWorkersMutex       sync.RWMutex
+	landAllWorkersArgsForCall []struct {
+		arg1 context.Context
+	}
+	landAllWorkersReturns struct {
+		result1 []string
+		result2 error
+	}
+	landAllWorkersReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	LandFinishedLandingWorkersStub        func(context.Context) ([]string, error)
+	landFinishedLandingWorkersMutex       sync.RWMutex
+	landFinishedLandingWorkersArgsForCall []struct {
+		arg1 context.Context
+	}
+	landFinishedLandingWorkersReturns struct {
+		result1 []string
+		result2 error
+	}
+	landFinishedLandingWorkersReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	LandFinishedLandingWorkersCountStub        func(context.Context) (int, error)
+	landFinishedLandingWorkersCountMutex       sync.RWMutex
+	landFinishedLandingWorkersCountArgsForCall []struct {
+		arg1 context.Context
+	}
+	landFinishedLandingWorkersCountReturns struct {
+		result1 int
+		result2 error
+	}
+	landFinishedLandingWorkersCountReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	LandFinishedLandingWorkersDetailedStub        func(context.Context) ([]db.WorkerAffected, error)
+	landFinishedLandingWorkersDetailedMutex       sync.RWMutex
+	landFinishedLandingWorkersDetailedArgsForCall []struct {
+		arg1 context.Context
+	}
+	landFinishedLandingWorkersDetailedReturns struct {
+		result1 []db.WorkerAffected
+		result2 error
+	}
+	landFinishedLandingWorkersDetailedReturnsOnCall map[int]struct {
+		result1 []db.WorkerAffected
+		result2 error
+	}
+	LandFinishedLandingWorkersForPlatformStub        func(context.Context, string) ([]string, error)
+	landFinishedLandingWorkersForPlatformMutex       sync.RWMutex
+	landFinishedLandingWorkersForPlatformArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	landFinishedLandingWorkersForPlatformReturns struct {
+		result1 []string
+		result2 error
+	}
+	landFinishedLandingWorkersForPlatformReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	LandFinishedLandingWorkersForWorkersStub        func(context.Context, []string) ([]string, error)
+	landFinishedLandingWorkersForWorkersMutex       sync.RWMutex
+	landFinishedLandingWorkersForWorkersArgsForCall []struct {
+		arg1 context.Context
+		arg2 []string
+	}
+	landFinishedLandingWorkersForWorkersReturns struct {
+		result1 []string
+		result2 error
+	}
+	landFinishedLandingWorkersForWorkersReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	LandFinishedLandingWorkersKeepMinimumStub        func(context.Context, int) ([]string, error)
+	landFinishedLandingWorkersKeepMinimumMutex       sync.RWMutex
+	landFinishedLandingWorkersKeepMinimumArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+	}
+	landFinishedLandingWorkersKeepMinimumReturns struct {
+		result1 []string
+		result2 error
+	}
+	landFinishedLandingWorkersKeepMinimumReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	LandFinishedLandingWorkersSQLStub        func() (string, []any, error)
+	landFinishedLandingWorkersSQLMutex       sync.RWMutex
+	landFinishedLandingWorkersSQLArgsForCall []struct {
+	}
+	landFinishedLandingWorkersSQLReturns struct {
+		result1 string
+		result2 []any
+		result3 error
+	}
+	landFinishedLandingWorkersSQLReturnsOnCall map[int]struct {
+		result1 string
+		result2 []any
+		result3 error
+	}
+	LandFinishedLandingWorkersWithDurationStub        func(context.Context) ([]db.LandedWorker, error)
+	landFinishedLandingWorkersWithDurationMutex       sync.RWMutex
+	landFinishedLandingWorkersWithDurationArgsForCall []struct {
+		arg1 context.Context
+	}
+	landFinishedLandingWorkersWithDurationReturns struct {
+		result1 []db.LandedWorker
+		result2 error
+	}
+	landFinishedLandingWorkersWithDurationReturnsOnCall map[int]struct {
+		result1 []db.LandedWorker
+		result2 error
+	}
+	LandUnreachableWorkersStub        func(context.Context, time.Duration) ([]string, error)
+	landUnreachableWorkersMutex       sync.RWMutex
+	landUnreachableWorkersArgsForCall []struct {
+		arg1 context.Context
+		arg2 time.Duration
+	}
+	landUnreachableWorkersReturns struct {
+		result1 []string
+		result2 error
+	}
+	landUnreachableWorkersReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	LandWorkersInterruptingBuildsStub        func(context.Context) ([]string, error)
+	landWorkersInterruptingBuildsMutex       sync.RWMutex
+	landWorkersInterruptingBuildsArgsForCall []struct {
+		arg1 context.Context
+	}
+	landWorkersInterruptingBuildsReturns struct {
+		result1 []string
+		result2 error
+	}
+	landWorkersInterruptingBuildsReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	LandWorkersWithTagStub        func(context.Context, string) ([]string, error)
+	landWorkersWithTagMutex       sync.RWMutex
+	landWorkersWithTagArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	landWorkersWithTagReturns struct {
+		result1 []string
+		result2 error
+	}
+	landWorkersWithTagReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	LifecycleStatsStub        func() db.LifecycleCounters
+	lifecycleStatsMutex       sync.RWMutex
+	lifecycleStatsArgsForCall []struct {
+	}
+	lifecycleStatsReturns struct {
+		result1 db.LifecycleCounters
+	}
+	lifecycleStatsReturnsOnCall map[int]struct {
+		result1 db.LifecycleCounters
+	}
+	MarkBaggageclaimHealthStub        func(context.Context, string, bool) error
+	markBaggageclaimHealthMutex       sync.RWMutex
+	markBaggageclaimHealthArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 bool
+	}
+	markBaggageclaimHealthReturns struct {
+		result1 error
+	}
+	markBaggageclaimHealthReturnsOnCall map[int]struct {
+		result1 error
+	}
+	MarkWorkerForUpgradeStub        func(context.Context, string) error
+	markWorkerForUpgradeMutex       sync.RWMutex
+	markWorkerForUpgradeArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	markWorkerForUpgradeReturns struct {
+		result1 error
+	}
+	markWorkerForUpgradeReturnsOnCall map[int]struct {
+		result1 error
+	}
+	OldestExpiredWorkerAgeStub        func(context.Context) (time.Duration, bool, error)
+	oldestExpiredWorkerAgeMutex       sync.RWMutex
+	oldestExpiredWorkerAgeArgsForCall []struct {
+		arg1 context.Context
+	}
+	oldestExpiredWorkerAgeReturns struct {
+		result1 time.Duration
+		result2 bool
+		result3 error
+	}
+	oldestExpiredWorkerAgeReturnsOnCall map[int]struct {
+		result1 time.Duration
+		result2 bool
+		result3 error
+	}
+	OrphanContainersForWorkerStub        func(context.Context, string) (int, error)
+	orphanContainersForWorkerMutex       sync.RWMutex
+	orphanContainersForWorkerArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	orphanContainersForWorkerReturns struct {
+		result1 int
+		result2 error
+	}
+	orphanContainersForWorkerReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	ProcessFinishedWorkersStub        func(context.Context) ([]string, []string, error)
+	processFinishedWorkersMutex       sync.RWMutex
+	processFinishedWorkersArgsForCall []struct {
+		arg1 context.Context
+	}
+	processFinishedWorkersReturns struct {
+		result1 []string
+		result2 []string
+		result3 error
+	}
+	processFinishedWorkersReturnsOnCall map[int]struct {
+		result1 []string
+		result2 []string
+		result3 error
+	}
+	PruneWorkerStateHistoryStub        func(context.Context, time.Duration) (int, error)
+	pruneWorkerStateHistoryMutex       sync.RWMutex
+	pruneWorkerStateHistoryArgsForCall []struct {
+		arg1 context.Context
+		arg2 time.Duration
+	}
+	pruneWorkerStateHistoryReturns struct {
+		result1 int
+		result2 error
+	}
+	pruneWorkerStateHistoryReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	PurgeDeletedWorkersStub        func(context.Context, time.Duration) ([]string, error)
+	purgeDeletedWorkersMutex       sync.RWMutex
+	purgeDeletedWorkersArgsForCall []struct {
+		arg1 context.Context
+		arg2 time.Duration
+	}
+	purgeDeletedWorkersReturns struct {
+		result1 []string
+		result2 error
+	}
+	purgeDeletedWorkersReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	QuarantineWorkerStub        func(context.Context, string) error
+	quarantineWorkerMutex       sync.RWMutex
+	quarantineWorkerArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	quarantineWorkerReturns struct {
+		result1 error
+	}
+	quarantineWorkerReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ReconcileWorkersStub        func(context.Context, []string) ([]string, error)
+	reconcileWorkersMutex       sync.RWMutex
+	reconcileWorkersArgsForCall []struct {
+		arg1 context.Context
+		arg2 []string
+	}
+	reconcileWorkersReturns struct {
+		result1 []string
+		result2 error
+	}
+	reconcileWorkersReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	RestoreUpgradedWorkersStub        func(context.Context, []string) ([]string, error)
+	restoreUpgradedWorkersMutex       sync.RWMutex
+	restoreUpgradedWorkersArgsForCall []struct {
+		arg1 context.Context
+		arg2 []string
+	}
+	restoreUpgradedWorkersReturns struct {
+		result1 []string
+		result2 error
+	}
+	restoreUpgradedWorkersReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	ResurrectWorkerStub        func(context.Context, string, string, string, time.Duration) error
+	resurrectWorkerMutex       sync.RWMutex
+	resurrectWorkerArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 time.Duration
+	}
+	resurrectWorkerReturns struct {
+		result1 error
+	}
+	resurrectWorkerReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetWorkerStatesStub        func(context.Context, []string, db.WorkerState) ([]string, error)
+	setWorkerStatesMutex       sync.RWMutex
+	setWorkerStatesArgsForCall []struct {
+		arg1 context.Context
+		arg2 []string
+		arg3 db.WorkerState
+	}
+	setWorkerStatesReturns struct {
+		result1 []string
+		result2 error
+	}
+	setWorkerStatesReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	StallUnresponsiveWorkersStub        func(context.Context) ([]string, error)
+	stallUnresponsiveWorkersMutex       sync.RWMutex
+	stallUnresponsiveWorkersArgsForCall []struct {
+		arg1 context.Context
+	}
+	stallUnresponsiveWorkersReturns struct {
+		result1 []string
+		result2 error
+	}
+	stallUnresponsiveWorkersReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	StallUnresponsiveWorkersCountStub        func(context.Context) (int, error)
+	stallUnresponsiveWorkersCountMutex       sync.RWMutex
+	stallUnresponsiveWorkersCountArgsForCall []struct {
+		arg1 context.Context
+	}
+	stallUnresponsiveWorkersCountReturns struct {
+		result1 int
+		result2 error
+	}
+	stallUnresponsiveWorkersCountReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	StallUnresponsiveWorkersDetailedStub        func(context.Context) ([]db.WorkerAffected, error)
+	stallUnresponsiveWorkersDetailedMutex       sync.RWMutex
+	stallUnresponsiveWorkersDetailedArgsForCall []struct {
+		arg1 context.Context
+	}
+	stallUnresponsiveWorkersDetailedReturns struct {
+		result1 []db.WorkerAffected
+		result2 error
+	}
+	stallUnresponsiveWorkersDetailedReturnsOnCall map[int]struct {
+		result1 []db.WorkerAffected
+		result2 error
+	}
+	StallUnresponsiveWorkersOfKindStub        func(context.Context, db.WorkerKind) ([]string, error)
+	stallUnresponsiveWorkersOfKindMutex       sync.RWMutex
+	stallUnresponsiveWorkersOfKindArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.WorkerKind
+	}
+	stallUnresponsiveWorkersOfKindReturns struct {
+		result1 []string
+		result2 error
+	}
+	stallUnresponsiveWorkersOfKindReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	StallUnresponsiveWorkersSQLStub        func(time.Duration) (string, []any, error)
+	stallUnresponsiveWorkersSQLMutex       sync.RWMutex
+	stallUnresponsiveWorkersSQLArgsForCall []struct {
+		arg1 time.Duration
+	}
+	stallUnresponsiveWorkersSQLReturns struct {
+		result1 string
+		result2 []any
+		result3 error
+	}
+	stallUnresponsiveWorkersSQLReturnsOnCall map[int]struct {
+		result1 string
+		result2 []any
+		result3 error
+	}
+	StallUnresponsiveWorkersTxStub        func(context.Context, db.Tx) ([]string, error)
+	stallUnresponsiveWorkersTxMutex       sync.RWMutex
+	stallUnresponsiveWorkersTxArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.Tx
+	}
+	stallUnresponsiveWorkersTxReturns struct {
+		result1 []string
+		result2 error
+	}
+	stallUnresponsiveWorkersTxReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	StallUnresponsiveWorkersWithGraceStub        func(context.Context, time.Duration) ([]string, error)
+	stallUnresponsiveWorkersWithGraceMutex       sync.RWMutex
+	stallUnresponsiveWorkersWithGraceArgsForCall []struct {
+		arg1 context.Context
+		arg2 time.Duration
+	}
+	stallUnresponsiveWorkersWithGraceReturns struct {
+		result1 []string
+		result2 error
+	}
+	stallUnresponsiveWorkersWithGraceReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	StallUnresponsiveWorkersWithPreviousStateStub        func(context.Context) ([]db.WorkerTransition, error)
+	stallUnresponsiveWorkersWithPreviousStateMutex       sync.RWMutex
+	stallUnresponsiveWorkersWithPreviousStateArgsForCall []struct {
+		arg1 context.Context
+	}
+	stallUnresponsiveWorkersWithPreviousStateReturns struct {
+		result1 []db.WorkerTransition
+		result2 error
+	}
+	stallUnresponsiveWorkersWithPreviousStateReturnsOnCall map[int]struct {
+		result1 []db.WorkerTransition
+		result2 error
+	}
+	StreamWorkerStatesStub        func(context.Context, func(name string, state db.WorkerState) error) error
+	streamWorkerStatesMutex       sync.RWMutex
+	streamWorkerStatesArgsForCall []struct {
+		arg1 context.Context
+		arg2 func(name string, state db.WorkerState) error
+	}
+	streamWorkerStatesReturns struct {
+		result1 error
+	}
+	streamWorkerStatesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	TotalActiveContainersStub        func(context.Context) (int, error)
+	totalActiveContainersMutex       sync.RWMutex
+	totalActiveContainersArgsForCall []struct {
+		arg1 context.Context
+	}
+	totalActiveContainersReturns struct {
+		result1 int
+		result2 error
+	}
+	totalActiveContainersReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	TransitionWorkerStub        func(context.Context, string, db.WorkerState, db.WorkerState) (int, error)
+	transitionWorkerMutex       sync.RWMutex
+	transitionWorkerArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 db.WorkerState
+		arg4 db.WorkerState
+	}
+	transitionWorkerReturns struct {
+		result1 int
+		result2 error
+	}
+	transitionWorkerReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	UnquarantineWorkerStub        func(context.Context, string) error
+	unquarantineWorkerMutex       sync.RWMutex
+	unquarantineWorkerArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	unquarantineWorkerReturns struct {
+		result1 error
+	}
+	unquarantineWorkerReturnsOnCall map[int]struct {
+		result1 error
+	}
+	WithLifecycleLockStub        func(context.Context, func() error) error
+	withLifecycleLockMutex       sync.RWMutex
+	withLifecycleLockArgsForCall []struct {
+		arg1 context.Context
+		arg2 func() error
+	}
+	withLifecycleLockReturns struct {
+		result1 error
+	}
+	withLifecycleLockReturnsOnCall map[int]struct {
+		result1 error
+	}
+	WorkerAgeStatsByStateStub        func(context.Context) (map[db.WorkerState]db.AgeStats, error)
+	workerAgeStatsByStateMutex       sync.RWMutex
+	workerAgeStatsByStateArgsForCall []struct {
+		arg1 context.Context
+	}
+	workerAgeStatsByStateReturns struct {
+		result1 map[db.WorkerState]db.AgeStats
+		result2 error
+	}
+	workerAgeStatsByStateReturnsOnCall map[int]struct {
+		result1 map[db.WorkerState]db.AgeStats
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeWorkerLifecycle) ClaimWorkerForLanding(arg1 context.Context, arg2 string, arg3 string, arg4 time.Duration) (bool, error) {
+	fake.claimWorkerForLandingMutex.Lock()
+	ret, specificReturn := fake.claimWorkerForLandingReturnsOnCall[len(fake.claimWorkerForLandingArgsForCall)]
+	fake.claimWorkerForLandingArgsForCall = append(fake.claimWorkerForLandingArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 time.Duration
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.ClaimWorkerForLandingStub
+	fakeReturns := fake.claimWorkerForLandingReturns
+	fake.recordInvocation("ClaimWorkerForLanding", []interface{}{arg1, arg2, arg3, arg4})
+	fake.claimWorkerForLandingMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) ClaimWorkerForLandingCallCount() int {
+	fake.claimWorkerForLandingMutex.RLock()
+	defer fake.claimWorkerForLandingMutex.RUnlock()
+	return len(fake.claimWorkerForLandingArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) ClaimWorkerForLandingCalls(stub func(context.Context, string, string, time.Duration) (bool, error)) {
+	fake.claimWorkerForLandingMutex.Lock()
+	defer fake.claimWorkerForLandingMutex.Unlock()
+	fake.ClaimWorkerForLandingStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) ClaimWorkerForLandingArgsForCall(i int) (context.Context, string, string, time.Duration) {
+	fake.claimWorkerForLandingMutex.RLock()
+	defer fake.claimWorkerForLandingMutex.RUnlock()
+	argsForCall := fake.claimWorkerForLandingArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeWorkerLifecycle) ClaimWorkerForLandingReturns(result1 bool, result2 error) {
+	fake.claimWorkerForLandingMutex.Lock()
+	defer fake.claimWorkerForLandingMutex.Unlock()
+	fake.ClaimWorkerForLandingStub = nil
+	fake.claimWorkerForLandingReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) ClaimWorkerForLandingReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.claimWorkerForLandingMutex.Lock()
+	defer fake.claimWorkerForLandingMutex.Unlock()
+	fake.ClaimWorkerForLandingStub = nil
+	if fake.claimWorkerForLandingReturnsOnCall == nil {
+		fake.claimWorkerForLandingReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.claimWorkerForLandingReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) CleanWorkerResourceCaches(arg1 context.Context, arg2 string) (int, error) {
+	fake.cleanWorkerResourceCachesMutex.Lock()
+	ret, specificReturn := fake.cleanWorkerResourceCachesReturnsOnCall[len(fake.cleanWorkerResourceCachesArgsForCall)]
+	fake.cleanWorkerResourceCachesArgsForCall = append(fake.cleanWorkerResourceCachesArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.CleanWorkerResourceCachesStub
+	fakeReturns := fake.cleanWorkerResourceCachesReturns
+	fake.recordInvocation("CleanWorkerResourceCaches", []interface{}{arg1, arg2})
+	fake.cleanWorkerResourceCachesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) CleanWorkerResourceCachesCallCount() int {
+	fake.cleanWorkerResourceCachesMutex.RLock()
+	defer fake.cleanWorkerResourceCachesMutex.RUnlock()
+	return len(fake.cleanWorkerResourceCachesArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) CleanWorkerResourceCachesCalls(stub func(context.Context, string) (int, error)) {
+	fake.cleanWorkerResourceCachesMutex.Lock()
+	defer fake.cleanWorkerResourceCachesMutex.Unlock()
+	fake.CleanWorkerResourceCachesStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) CleanWorkerResourceCachesArgsForCall(i int) (context.Context, string) {
+	fake.cleanWorkerResourceCachesMutex.RLock()
+	defer fake.cleanWorkerResourceCachesMutex.RUnlock()
+	argsForCall := fake.cleanWorkerResourceCachesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorkerLifecycle) CleanWorkerResourceCachesReturns(result1 int, result2 error) {
+	fake.cleanWorkerResourceCachesMutex.Lock()
+	defer fake.cleanWorkerResourceCachesMutex.Unlock()
+	fake.CleanWorkerResourceCachesStub = nil
+	fake.cleanWorkerResourceCachesReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) CleanWorkerResourceCachesReturnsOnCall(i int, result1 int, result2 error) {
+	fake.cleanWorkerResourceCachesMutex.Lock()
+	defer fake.cleanWorkerResourceCachesMutex.Unlock()
+	fake.CleanWorkerResourceCachesStub = nil
+	if fake.cleanWorkerResourceCachesReturnsOnCall == nil {
+		fake.cleanWorkerResourceCachesReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.cleanWorkerResourceCachesReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) CountWorkersByState(arg1 context.Context) (map[db.WorkerState]int, error) {
+	fake.countWorkersByStateMutex.Lock()
+	ret, specificReturn := fake.countWorkersByStateReturnsOnCall[len(fake.countWorkersByStateArgsForCall)]
+	fake.countWorkersByStateArgsForCall = append(fake.countWorkersByStateArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.CountWorkersByStateStub
+	fakeReturns := fake.countWorkersByStateReturns
+	fake.recordInvocation("CountWorkersByState", []interface{}{arg1})
+	fake.countWorkersByStateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) CountWorkersByStateCallCount() int {
+	fake.countWorkersByStateMutex.RLock()
+	defer fake.countWorkersByStateMutex.RUnlock()
+	return len(fake.countWorkersByStateArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) CountWorkersByStateCalls(stub func(context.Context) (map[db.WorkerState]int, error)) {
+	fake.countWorkersByStateMutex.Lock()
+	defer fake.countWorkersByStateMutex.Unlock()
+	fake.CountWorkersByStateStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) CountWorkersByStateArgsForCall(i int) context.Context {
+	fake.countWorkersByStateMutex.RLock()
+	defer fake.countWorkersByStateMutex.RUnlock()
+	argsForCall := fake.countWorkersByStateArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) CountWorkersByStateReturns(result1 map[db.WorkerState]int, result2 error) {
+	fake.countWorkersByStateMutex.Lock()
+	defer fake.countWorkersByStateMutex.Unlock()
+	fake.CountWorkersByStateStub = nil
+	fake.countWorkersByStateReturns = struct {
+		result1 map[db.WorkerState]int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) CountWorkersByStateReturnsOnCall(i int, result1 map[db.WorkerState]int, result2 error) {
+	fake.countWorkersByStateMutex.Lock()
+	defer fake.countWorkersByStateMutex.Unlock()
+	fake.CountWorkersByStateStub = nil
+	if fake.countWorkersByStateReturnsOnCall == nil {
+		fake.countWorkersByStateReturnsOnCall = make(map[int]struct {
+			result1 map[db.WorkerState]int
+			result2 error
+		})
+	}
+	fake.countWorkersByStateReturnsOnCall[i] = struct {
+		result1 map[db.WorkerState]int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) CountWorkersByTeamAndState(arg1 context.Context) (map[string]map[db.WorkerState]int, error) {
+	fake.countWorkersByTeamAndStateMutex.Lock()
+	ret, specificReturn := fake.countWorkersByTeamAndStateReturnsOnCall[len(fake.countWorkersByTeamAndStateArgsForCall)]
+	fake.countWorkersByTeamAndStateArgsForCall = append(fake.countWorkersByTeamAndStateArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.CountWorkersByTeamAndStateStub
+	fakeReturns := fake.countWorkersByTeamAndStateReturns
+	fake.recordInvocation("CountWorkersByTeamAndState", []interface{}{arg1})
+	fake.countWorkersByTeamAndStateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) CountWorkersByTeamAndStateCallCount() int {
+	fake.countWorkersByTeamAndStateMutex.RLock()
+	defer fake.countWorkersByTeamAndStateMutex.RUnlock()
+	return len(fake.countWorkersByTeamAndStateArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) CountWorkersByTeamAndStateCalls(stub func(context.Context) (map[string]map[db.WorkerState]int, error)) {
+	fake.countWorkersByTeamAndStateMutex.Lock()
+	defer fake.countWorkersByTeamAndStateMutex.Unlock()
+	fake.CountWorkersByTeamAndStateStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) CountWorkersByTeamAndStateArgsForCall(i int) context.Context {
+	fake.countWorkersByTeamAndStateMutex.RLock()
+	defer fake.countWorkersByTeamAndStateMutex.RUnlock()
+	argsForCall := fake.countWorkersByTeamAndStateArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) CountWorkersByTeamAndStateReturns(result1 map[string]map[db.WorkerState]int, result2 error) {
+	fake.countWorkersByTeamAndStateMutex.Lock()
+	defer fake.countWorkersByTeamAndStateMutex.Unlock()
+	fake.CountWorkersByTeamAndStateStub = nil
+	fake.countWorkersByTeamAndStateReturns = struct {
+		result1 map[string]map[db.WorkerState]int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) CountWorkersByTeamAndStateReturnsOnCall(i int, result1 map[string]map[db.WorkerState]int, result2 error) {
+	fake.countWorkersByTeamAndStateMutex.Lock()
+	defer fake.countWorkersByTeamAndStateMutex.Unlock()
+	fake.CountWorkersByTeamAndStateStub = nil
+	if fake.countWorkersByTeamAndStateReturnsOnCall == nil {
+		fake.countWorkersByTeamAndStateReturnsOnCall = make(map[int]struct {
+			result1 map[string]map[db.WorkerState]int
+			result2 error
+		})
+	}
+	fake.countWorkersByTeamAndStateReturnsOnCall[i] = struct {
+		result1 map[string]map[db.WorkerState]int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) DeleteExpiredStalledWorkers(arg1 context.Context) ([]string, error) {
+	fake.deleteExpiredStalledWorkersMutex.Lock()
+	ret, specificReturn := fake.deleteExpiredStalledWorkersReturnsOnCall[len(fake.deleteExpiredStalledWorkersArgsForCall)]
+	fake.deleteExpiredStalledWorkersArgsForCall = append(fake.deleteExpiredStalledWorkersArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.DeleteExpiredStalledWorkersStub
+	fakeReturns := fake.deleteExpiredStalledWorkersReturns
+	fake.recordInvocation("DeleteExpiredStalledWorkers", []interface{}{arg1})
+	fake.deleteExpiredStalledWorkersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) DeleteExpiredStalledWorkersCallCount() int {
+	fake.deleteExpiredStalledWorkersMutex.RLock()
+	defer fake.deleteExpiredStalledWorkersMutex.RUnlock()
+	return len(fake.deleteExpiredStalledWorkersArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) DeleteExpiredStalledWorkersCalls(stub func(context.Context) ([]string, error)) {
+	fake.deleteExpiredStalledWorkersMutex.Lock()
+	defer fake.deleteExpiredStalledWorkersMutex.Unlock()
+	fake.DeleteExpiredStalledWorkersStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) DeleteExpiredStalledWorkersArgsForCall(i int) context.Context {
+	fake.deleteExpiredStalledWorkersMutex.RLock()
+	defer fake.deleteExpiredStalledWorkersMutex.RUnlock()
+	argsForCall := fake.deleteExpiredStalledWorkersArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) DeleteExpiredStalledWorkersReturns(result1 []string, result2 error) {
+	fake.deleteExpiredStalledWorkersMutex.Lock()
+	defer fake.deleteExpiredStalledWorkersMutex.Unlock()
+	fake.DeleteExpiredStalledWorkersStub = nil
+	fake.deleteExpiredStalledWorkersReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) DeleteExpiredStalledWorkersReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.deleteExpiredStalledWorkersMutex.Lock()
+	defer fake.deleteExpiredStalledWorkersMutex.Unlock()
+	fake.DeleteExpiredStalledWorkersStub = nil
+	if fake.deleteExpiredStalledWorkersReturnsOnCall == nil {
+		fake.deleteExpiredStalledWorkersReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.deleteExpiredStalledWorkersReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) DeleteFinishedRetiringWorkers(arg1 context.Context) ([]string, error) {
+	fake.deleteFinishedRetiringWorkersMutex.Lock()
+	ret, specificReturn := fake.deleteFinishedRetiringWorkersReturnsOnCall[len(fake.deleteFinishedRetiringWorkersArgsForCall)]
+	fake.deleteFinishedRetiringWorkersArgsForCall = append(fake.deleteFinishedRetiringWorkersArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.DeleteFinishedRetiringWorkersStub
+	fakeReturns := fake.deleteFinishedRetiringWorkersReturns
+	fake.recordInvocation("DeleteFinishedRetiringWorkers", []interface{}{arg1})
+	fake.deleteFinishedRetiringWorkersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) DeleteFinishedRetiringWorkersCallCount() int {
+	fake.deleteFinishedRetiringWorkersMutex.RLock()
+	defer fake.deleteFinishedRetiringWorkersMutex.RUnlock()
+	return len(fake.deleteFinishedRetiringWorkersArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) DeleteFinishedRetiringWorkersCalls(stub func(context.Context) ([]string, error)) {
+	fake.deleteFinishedRetiringWorkersMutex.Lock()
+	defer fake.deleteFinishedRetiringWorkersMutex.Unlock()
+	fake.DeleteFinishedRetiringWorkersStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) DeleteFinishedRetiringWorkersArgsForCall(i int) context.Context {
+	fake.deleteFinishedRetiringWorkersMutex.RLock()
+	defer fake.deleteFinishedRetiringWorkersMutex.RUnlock()
+	argsForCall := fake.deleteFinishedRetiringWorkersArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) DeleteFinishedRetiringWorkersReturns(result1 []string, result2 error) {
+	fake.deleteFinishedRetiringWorkersMutex.Lock()
+	defer fake.deleteFinishedRetiringWorkersMutex.Unlock()
+	fake.DeleteFinishedRetiringWorkersStub = nil
+	fake.deleteFinishedRetiringWorkersReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) DeleteFinishedRetiringWorkersReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.deleteFinishedRetiringWorkersMutex.Lock()
+	defer fake.deleteFinishedRetiringWorkersMutex.Unlock()
+	fake.DeleteFinishedRetiringWorkersStub = nil
+	if fake.deleteFinishedRetiringWorkersReturnsOnCall == nil {
+		fake.deleteFinishedRetiringWorkersReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.deleteFinishedRetiringWorkersReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) DeleteFinishedRetiringWorkersBatch(arg1 context.Context, arg2 int) ([]string, error) {
+	fake.deleteFinishedRetiringWorkersBatchMutex.Lock()
+	ret, specificReturn := fake.deleteFinishedRetiringWorkersBatchReturnsOnCall[len(fake.deleteFinishedRetiringWorkersBatchArgsForCall)]
+	fake.deleteFinishedRetiringWorkersBatchArgsForCall = append(fake.deleteFinishedRetiringWorkersBatchArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.DeleteFinishedRetiringWorkersBatchStub
+	fakeReturns := fake.deleteFinishedRetiringWorkersBatchReturns
+	fake.recordInvocation("DeleteFinishedRetiringWorkersBatch", []interface{}{arg1, arg2})
+	fake.deleteFinishedRetiringWorkersBatchMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) DeleteFinishedRetiringWorkersBatchCallCount() int {
+	fake.deleteFinishedRetiringWorkersBatchMutex.RLock()
+	defer fake.deleteFinishedRetiringWorkersBatchMutex.RUnlock()
+	return len(fake.deleteFinishedRetiringWorkersBatchArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) DeleteFinishedRetiringWorkersBatchCalls(stub func(context.Context, int) ([]string, error)) {
+	fake.deleteFinishedRetiringWorkersBatchMutex.Lock()
+	defer fake.deleteFinishedRetiringWorkersBatchMutex.Unlock()
+	fake.DeleteFinishedRetiringWorkersBatchStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) DeleteFinishedRetiringWorkersBatchArgsForCall(i int) (context.Context, int) {
+	fake.deleteFinishedRetiringWorkersBatchMutex.RLock()
+	defer fake.deleteFinishedRetiringWorkersBatchMutex.RUnlock()
+	argsForCall := fake.deleteFinishedRetiringWorkersBatchArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorkerLifecycle) DeleteFinishedRetiringWorkersBatchReturns(result1 []string, result2 error) {
+	fake.deleteFinishedRetiringWorkersBatchMutex.Lock()
+	defer fake.deleteFinishedRetiringWorkersBatchMutex.Unlock()
+	fake.DeleteFinishedRetiringWorkersBatchStub = nil
+	fake.deleteFinishedRetiringWorkersBatchReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) DeleteFinishedRetiringWorkersBatchReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.deleteFinishedRetiringWorkersBatchMutex.Lock()
+	defer fake.deleteFinishedRetiringWorkersBatchMutex.Unlock()
+	fake.DeleteFinishedRetiringWorkersBatchStub = nil
+	if fake.deleteFinishedRetiringWorkersBatchReturnsOnCall == nil {
+		fake.deleteFinishedRetiringWorkersBatchReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.deleteFinishedRetiringWorkersBatchReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) DeleteFinishedRetiringWorkersCount(arg1 context.Context) (int, error) {
+	fake.deleteFinishedRetiringWorkersCountMutex.Lock()
+	ret, specificReturn := fake.deleteFinishedRetiringWorkersCountReturnsOnCall[len(fake.deleteFinishedRetiringWorkersCountArgsForCall)]
+	fake.deleteFinishedRetiringWorkersCountArgsForCall = append(fake.deleteFinishedRetiringWorkersCountArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.DeleteFinishedRetiringWorkersCountStub
+	fakeReturns := fake.deleteFinishedRetiringWorkersCountReturns
+	fake.recordInvocation("DeleteFinishedRetiringWorkersCount", []interface{}{arg1})
+	fake.deleteFinishedRetiringWorkersCountMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) DeleteFinishedRetiringWorkersCountCallCount() int {
+	fake.deleteFinishedRetiringWorkersCountMutex.RLock()
+	defer fake.deleteFinishedRetiringWorkersCountMutex.RUnlock()
+	return len(fake.deleteFinishedRetiringWorkersCountArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) DeleteFinishedRetiringWorkersCountCalls(stub func(context.Context) (int, error)) {
+	fake.deleteFinishedRetiringWorkersCountMutex.Lock()
+	defer fake.deleteFinishedRetiringWorkersCountMutex.Unlock()
+	fake.DeleteFinishedRetiringWorkersCountStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) DeleteFinishedRetiringWorkersCountArgsForCall(i int) context.Context {
+	fake.deleteFinishedRetiringWorkersCountMutex.RLock()
+	defer fake.deleteFinishedRetiringWorkersCountMutex.RUnlock()
+	argsForCall := fake.deleteFinishedRetiringWorkersCountArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) DeleteFinishedRetiringWorkersCountReturns(result1 int, result2 error) {
+	fake.deleteFinishedRetiringWorkersCountMutex.Lock()
+	defer fake.deleteFinishedRetiringWorkersCountMutex.Unlock()
+	fake.DeleteFinishedRetiringWorkersCountStub = nil
+	fake.deleteFinishedRetiringWorkersCountReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) DeleteFinishedRetiringWorkersCountReturnsOnCall(i int, result1 int, result2 error) {
+	fake.deleteFinishedRetiringWorkersCountMutex.Lock()
+	defer fake.deleteFinishedRetiringWorkersCountMutex.Unlock()
+	fake.DeleteFinishedRetiringWorkersCountStub = nil
+	if fake.deleteFinishedRetiringWorkersCountReturnsOnCall == nil {
+		fake.deleteFinishedRetiringWorkersCountReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.deleteFinishedRetiringWorkersCountReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) DeleteFinishedRetiringWorkersDetailed(arg1 context.Context) ([]db.WorkerAffected, error) {
+	fake.deleteFinishedRetiringWorkersDetailedMutex.Lock()
+	ret, specificReturn := fake.deleteFinishedRetiringWorkersDetailedReturnsOnCall[len(fake.deleteFinishedRetiringWorkersDetailedArgsForCall)]
+	fake.deleteFinishedRetiringWorkersDetailedArgsForCall = append(fake.deleteFinishedRetiringWorkersDetailedArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.DeleteFinishedRetiringWorkersDetailedStub
+	fakeReturns := fake.deleteFinishedRetiringWorkersDetailedReturns
+	fake.recordInvocation("DeleteFinishedRetiringWorkersDetailed", []interface{}{arg1})
+	fake.deleteFinishedRetiringWorkersDetailedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) DeleteFinishedRetiringWorkersDetailedCallCount() int {
+	fake.deleteFinishedRetiringWorkersDetailedMutex.RLock()
+	defer fake.deleteFinishedRetiringWorkersDetailedMutex.RUnlock()
+	return len(fake.deleteFinishedRetiringWorkersDetailedArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) DeleteFinishedRetiringWorkersDetailedCalls(stub func(context.Context) ([]db.WorkerAffected, error)) {
+	fake.deleteFinishedRetiringWorkersDetailedMutex.Lock()
+	defer fake.deleteFinishedRetiringWorkersDetailedMutex.Unlock()
+	fake.DeleteFinishedRetiringWorkersDetailedStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) DeleteFinishedRetiringWorkersDetailedArgsForCall(i int) context.Context {
+	fake.deleteFinishedRetiringWorkersDetailedMutex.RLock()
+	defer fake.deleteFinishedRetiringWorkersDetailedMutex.RUnlock()
+	argsForCall := fake.deleteFinishedRetiringWorkersDetailedArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) DeleteFinishedRetiringWorkersDetailedReturns(result1 []db.WorkerAffected, result2 error) {
+	fake.deleteFinishedRetiringWorkersDetailedMutex.Lock()
+	defer fake.deleteFinishedRetiringWorkersDetailedMutex.Unlock()
+	fake.DeleteFinishedRetiringWorkersDetailedStub = nil
+	fake.deleteFinishedRetiringWorkersDetailedReturns = struct {
+		result1 []db.WorkerAffected
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) DeleteFinishedRetiringWorkersDetailedReturnsOnCall(i int, result1 []db.WorkerAffected, result2 error) {
+	fake.deleteFinishedRetiringWorkersDetailedMutex.Lock()
+	defer fake.deleteFinishedRetiringWorkersDetailedMutex.Unlock()
+	fake.DeleteFinishedRetiringWorkersDetailedStub = nil
+	if fake.deleteFinishedRetiringWorkersDetailedReturnsOnCall == nil {
+		fake.deleteFinishedRetiringWorkersDetailedReturnsOnCall = make(map[int]struct {
+			result1 []db.WorkerAffected
+			result2 error
+		})
+	}
+	fake.deleteFinishedRetiringWorkersDetailedReturnsOnCall[i] = struct {
+		result1 []db.WorkerAffected
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) DeleteFinishedRetiringWorkersSQL() (string, []any, error) {
+	fake.deleteFinishedRetiringWorkersSQLMutex.Lock()
+	ret, specificReturn := fake.deleteFinishedRetiringWorkersSQLReturnsOnCall[len(fake.deleteFinishedRetiringWorkersSQLArgsForCall)]
+	fake.deleteFinishedRetiringWorkersSQLArgsForCall = append(fake.deleteFinishedRetiringWorkersSQLArgsForCall, struct {
+	}{})
+	stub := fake.DeleteFinishedRetiringWorkersSQLStub
+	fakeReturns := fake.deleteFinishedRetiringWorkersSQLReturns
+	fake.recordInvocation("DeleteFinishedRetiringWorkersSQL", []interface{}{})
+	fake.deleteFinishedRetiringWorkersSQLMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeWorkerLifecycle) DeleteFinishedRetiringWorkersSQLCallCount() int {
+	fake.deleteFinishedRetiringWorkersSQLMutex.RLock()
+	defer fake.deleteFinishedRetiringWorkersSQLMutex.RUnlock()
+	return len(fake.deleteFinishedRetiringWorkersSQLArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) DeleteFinishedRetiringWorkersSQLCalls(stub func() (string, []any, error)) {
+	fake.deleteFinishedRetiringWorkersSQLMutex.Lock()
+	defer fake.deleteFinishedRetiringWorkersSQLMutex.Unlock()
+	fake.DeleteFinishedRetiringWorkersSQLStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) DeleteFinishedRetiringWorkersSQLReturns(result1 string, result2 []any, result3 error) {
+	fake.deleteFinishedRetiringWorkersSQLMutex.Lock()
+	defer fake.deleteFinishedRetiringWorkersSQLMutex.Unlock()
+	fake.DeleteFinishedRetiringWorkersSQLStub = nil
+	fake.deleteFinishedRetiringWorkersSQLReturns = struct {
+		result1 string
+		result2 []any
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeWorkerLifecycle) DeleteFinishedRetiringWorkersSQLReturnsOnCall(i int, result1 string, result2 []any, result3 error) {
+	fake.deleteFinishedRetiringWorkersSQLMutex.Lock()
+	defer fake.deleteFinishedRetiringWorkersSQLMutex.Unlock()
+	fake.DeleteFinishedRetiringWorkersSQLStub = nil
+	if fake.deleteFinishedRetiringWorkersSQLReturnsOnCall == nil {
+		fake.deleteFinishedRetiringWorkersSQLReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 []any
+			result3 error
+		})
+	}
+	fake.deleteFinishedRetiringWorkersSQLReturnsOnCall[i] = struct {
+		result1 string
+		result2 []any
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeWorkerLifecycle) DeleteFinishedRetiringWorkersWithPreviousState(arg1 context.Context) ([]db.WorkerTransition, error) {
+	fake.deleteFinishedRetiringWorkersWithPreviousStateMutex.Lock()
+	ret, specificReturn := fake.deleteFinishedRetiringWorkersWithPreviousStateReturnsOnCall[len(fake.deleteFinishedRetiringWorkersWithPreviousStateArgsForCall)]
+	fake.deleteFinishedRetiringWorkersWithPreviousStateArgsForCall = append(fake.deleteFinishedRetiringWorkersWithPreviousStateArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.DeleteFinishedRetiringWorkersWithPreviousStateStub
+	fakeReturns := fake.deleteFinishedRetiringWorkersWithPreviousStateReturns
+	fake.recordInvocation("DeleteFinishedRetiringWorkersWithPreviousState", []interface{}{arg1})
+	fake.deleteFinishedRetiringWorkersWithPreviousStateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) DeleteFinishedRetiringWorkersWithPreviousStateCallCount() int {
+	fake.deleteFinishedRetiringWorkersWithPreviousStateMutex.RLock()
+	defer fake.deleteFinishedRetiringWorkersWithPreviousStateMutex.RUnlock()
+	return len(fake.deleteFinishedRetiringWorkersWithPreviousStateArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) DeleteFinishedRetiringWorkersWithPreviousStateCalls(stub func(context.Context) ([]db.WorkerTransition, error)) {
+	fake.deleteFinishedRetiringWorkersWithPreviousStateMutex.Lock()
+	defer fake.deleteFinishedRetiringWorkersWithPreviousStateMutex.Unlock()
+	fake.DeleteFinishedRetiringWorkersWithPreviousStateStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) DeleteFinishedRetiringWorkersWithPreviousStateArgsForCall(i int) context.Context {
+	fake.deleteFinishedRetiringWorkersWithPreviousStateMutex.RLock()
+	defer fake.deleteFinishedRetiringWorkersWithPreviousStateMutex.RUnlock()
+	argsForCall := fake.deleteFinishedRetiringWorkersWithPreviousStateArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) DeleteFinishedRetiringWorkersWithPreviousStateReturns(result1 []db.WorkerTransition, result2 error) {
+	fake.deleteFinishedRetiringWorkersWithPreviousStateMutex.Lock()
+	defer fake.deleteFinishedRetiringWorkersWithPreviousStateMutex.Unlock()
+	fake.DeleteFinishedRetiringWorkersWithPreviousStateStub = nil
+	fake.deleteFinishedRetiringWorkersWithPreviousStateReturns = struct {
+		result1 []db.WorkerTransition
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) DeleteFinishedRetiringWorkersWithPreviousStateReturnsOnCall(i int, result1 []db.WorkerTransition, result2 error) {
+	fake.deleteFinishedRetiringWorkersWithPreviousStateMutex.Lock()
+	defer fake.deleteFinishedRetiringWorkersWithPreviousStateMutex.Unlock()
+	fake.DeleteFinishedRetiringWorkersWithPreviousStateStub = nil
+	if fake.deleteFinishedRetiringWorkersWithPreviousStateReturnsOnCall == nil {
+		fake.deleteFinishedRetiringWorkersWithPreviousStateReturnsOnCall = make(map[int]struct {
+			result1 []db.WorkerTransition
+			result2 error
+		})
+	}
+	fake.deleteFinishedRetiringWorkersWithPreviousStateReturnsOnCall[i] = struct {
+		result1 []db.WorkerTransition
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) DeleteStalledWorkers(arg1 context.Context, arg2 time.Duration) ([]string, error) {
+	fake.deleteStalledWorkersMutex.Lock()
+	ret, specificReturn := fake.deleteStalledWorkersReturnsOnCall[len(fake.deleteStalledWorkersArgsForCall)]
+	fake.deleteStalledWorkersArgsForCall = append(fake.deleteStalledWorkersArgsForCall, struct {
+		arg1 context.Context
+		arg2 time.Duration
+	}{arg1, arg2})
+	stub := fake.DeleteStalledWorkersStub
+	fakeReturns := fake.deleteStalledWorkersReturns
+	fake.recordInvocation("DeleteStalledWorkers", []interface{}{arg1, arg2})
+	fake.deleteStalledWorkersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) DeleteStalledWorkersCallCount() int {
+	fake.deleteStalledWorkersMutex.RLock()
+	defer fake.deleteStalledWorkersMutex.RUnlock()
+	return len(fake.deleteStalledWorkersArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) DeleteStalledWorkersCalls(stub func(context.Context, time.Duration) ([]string, error)) {
+	fake.deleteStalledWorkersMutex.Lock()
+	defer fake.deleteStalledWorkersMutex.Unlock()
+	fake.DeleteStalledWorkersStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) DeleteStalledWorkersArgsForCall(i int) (context.Context, time.Duration) {
+	fake.deleteStalledWorkersMutex.RLock()
+	defer fake.deleteStalledWorkersMutex.RUnlock()
+	argsForCall := fake.deleteStalledWorkersArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorkerLifecycle) DeleteStalledWorkersReturns(result1 []string, result2 error) {
+	fake.deleteStalledWorkersMutex.Lock()
+	defer fake.deleteStalledWorkersMutex.Unlock()
+	fake.DeleteStalledWorkersStub = nil
+	fake.deleteStalledWorkersReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) DeleteStalledWorkersReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.deleteStalledWorkersMutex.Lock()
+	defer fake.deleteStalledWorkersMutex.Unlock()
+	fake.DeleteStalledWorkersStub = nil
+	if fake.deleteStalledWorkersReturnsOnCall == nil {
+		fake.deleteStalledWorkersReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.deleteStalledWorkersReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) DeleteStalledWorkersCount(arg1 context.Context, arg2 time.Duration) (int, error) {
+	fake.deleteStalledWorkersCountMutex.Lock()
+	ret, specificReturn := fake.deleteStalledWorkersCountReturnsOnCall[len(fake.deleteStalledWorkersCountArgsForCall)]
+	fake.deleteStalledWorkersCountArgsForCall = append(fake.deleteStalledWorkersCountArgsForCall, struct {
+		arg1 context.Context
+		arg2 time.Duration
+	}{arg1, arg2})
+	stub := fake.DeleteStalledWorkersCountStub
+	fakeReturns := fake.deleteStalledWorkersCountReturns
+	fake.recordInvocation("DeleteStalledWorkersCount", []interface{}{arg1, arg2})
+	fake.deleteStalledWorkersCountMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) DeleteStalledWorkersCountCallCount() int {
+	fake.deleteStalledWorkersCountMutex.RLock()
+	defer fake.deleteStalledWorkersCountMutex.RUnlock()
+	return len(fake.deleteStalledWorkersCountArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) DeleteStalledWorkersCountCalls(stub func(context.Context, time.Duration) (int, error)) {
+	fake.deleteStalledWorkersCountMutex.Lock()
+	defer fake.deleteStalledWorkersCountMutex.Unlock()
+	fake.DeleteStalledWorkersCountStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) DeleteStalledWorkersCountArgsForCall(i int) (context.Context, time.Duration) {
+	fake.deleteStalledWorkersCountMutex.RLock()
+	defer fake.deleteStalledWorkersCountMutex.RUnlock()
+	argsForCall := fake.deleteStalledWorkersCountArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorkerLifecycle) DeleteStalledWorkersCountReturns(result1 int, result2 error) {
+	fake.deleteStalledWorkersCountMutex.Lock()
+	defer fake.deleteStalledWorkersCountMutex.Unlock()
+	fake.DeleteStalledWorkersCountStub = nil
+	fake.deleteStalledWorkersCountReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) DeleteStalledWorkersCountReturnsOnCall(i int, result1 int, result2 error) {
+	fake.deleteStalledWorkersCountMutex.Lock()
+	defer fake.deleteStalledWorkersCountMutex.Unlock()
+	fake.DeleteStalledWorkersCountStub = nil
+	if fake.deleteStalledWorkersCountReturnsOnCall == nil {
+		fake.deleteStalledWorkersCountReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.deleteStalledWorkersCountReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) DeleteStalledWorkersSQL(arg1 time.Duration) (string, []any, error) {
+	fake.deleteStalledWorkersSQLMutex.Lock()
+	ret, specificReturn := fake.deleteStalledWorkersSQLReturnsOnCall[len(fake.deleteStalledWorkersSQLArgsForCall)]
+	fake.deleteStalledWorkersSQLArgsForCall = append(fake.deleteStalledWorkersSQLArgsForCall, struct {
+		arg1 time.Duration
+	}{arg1})
+	stub := fake.DeleteStalledWorkersSQLStub
+	fakeReturns := fake.deleteStalledWorkersSQLReturns
+	fake.recordInvocation("DeleteStalledWorkersSQL", []interface{}{arg1})
+	fake.deleteStalledWorkersSQLMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeWorkerLifecycle) DeleteStalledWorkersSQLCallCount() int {
+	fake.deleteStalledWorkersSQLMutex.RLock()
+	defer fake.deleteStalledWorkersSQLMutex.RUnlock()
+	return len(fake.deleteStalledWorkersSQLArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) DeleteStalledWorkersSQLCalls(stub func(time.Duration) (string, []any, error)) {
+	fake.deleteStalledWorkersSQLMutex.Lock()
+	defer fake.deleteStalledWorkersSQLMutex.Unlock()
+	fake.DeleteStalledWorkersSQLStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) DeleteStalledWorkersSQLArgsForCall(i int) time.Duration {
+	fake.deleteStalledWorkersSQLMutex.RLock()
+	defer fake.deleteStalledWorkersSQLMutex.RUnlock()
+	argsForCall := fake.deleteStalledWorkersSQLArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) DeleteStalledWorkersSQLReturns(result1 string, result2 []any, result3 error) {
+	fake.deleteStalledWorkersSQLMutex.Lock()
+	defer fake.deleteStalledWorkersSQLMutex.Unlock()
+	fake.DeleteStalledWorkersSQLStub = nil
+	fake.deleteStalledWorkersSQLReturns = struct {
+		result1 string
+		result2 []any
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeWorkerLifecycle) DeleteStalledWorkersSQLReturnsOnCall(i int, result1 string, result2 []any, result3 error) {
+	fake.deleteStalledWorkersSQLMutex.Lock()
+	defer fake.deleteStalledWorkersSQLMutex.Unlock()
+	fake.DeleteStalledWorkersSQLStub = nil
+	if fake.deleteStalledWorkersSQLReturnsOnCall == nil {
+		fake.deleteStalledWorkersSQLReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 []any
+			result3 error
+		})
+	}
+	fake.deleteStalledWorkersSQLReturnsOnCall[i] = struct {
+		result1 string
+		result2 []any
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkers(arg1 context.Context) ([]string, error) {
+	fake.deleteUnresponsiveEphemeralWorkersMutex.Lock()
+	ret, specificReturn := fake.deleteUnresponsiveEphemeralWorkersReturnsOnCall[len(fake.deleteUnresponsiveEphemeralWorkersArgsForCall)]
+	fake.deleteUnresponsiveEphemeralWorkersArgsForCall = append(fake.deleteUnresponsiveEphemeralWorkersArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.DeleteUnresponsiveEphemeralWorkersStub
+	fakeReturns := fake.deleteUnresponsiveEphemeralWorkersReturns
+	fake.recordInvocation("DeleteUnresponsiveEphemeralWorkers", []interface{}{arg1})
+	fake.deleteUnresponsiveEphemeralWorkersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkersCallCount() int {
+	fake.deleteUnresponsiveEphemeralWorkersMutex.RLock()
+	defer fake.deleteUnresponsiveEphemeralWorkersMutex.RUnlock()
+	return len(fake.deleteUnresponsiveEphemeralWorkersArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkersCalls(stub func(context.Context) ([]string, error)) {
+	fake.deleteUnresponsiveEphemeralWorkersMutex.Lock()
+	defer fake.deleteUnresponsiveEphemeralWorkersMutex.Unlock()
+	fake.DeleteUnresponsiveEphemeralWorkersStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkersArgsForCall(i int) context.Context {
+	fake.deleteUnresponsiveEphemeralWorkersMutex.RLock()
+	defer fake.deleteUnresponsiveEphemeralWorkersMutex.RUnlock()
+	argsForCall := fake.deleteUnresponsiveEphemeralWorkersArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkersReturns(result1 []string, result2 error) {
+	fake.deleteUnresponsiveEphemeralWorkersMutex.Lock()
+	defer fake.deleteUnresponsiveEphemeralWorkersMutex.Unlock()
+	fake.DeleteUnresponsiveEphemeralWorkersStub = nil
+	fake.deleteUnresponsiveEphemeralWorkersReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkersReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.deleteUnresponsiveEphemeralWorkersMutex.Lock()
+	defer fake.deleteUnresponsiveEphemeralWorkersMutex.Unlock()
+	fake.DeleteUnresponsiveEphemeralWorkersStub = nil
+	if fake.deleteUnresponsiveEphemeralWorkersReturnsOnCall == nil {
+		fake.deleteUnresponsiveEphemeralWorkersReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.deleteUnresponsiveEphemeralWorkersReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkersCount(arg1 context.Context) (int, error) {
+	fake.deleteUnresponsiveEphemeralWorkersCountMutex.Lock()
+	ret, specificReturn := fake.deleteUnresponsiveEphemeralWorkersCountReturnsOnCall[len(fake.deleteUnresponsiveEphemeralWorkersCountArgsForCall)]
+	fake.deleteUnresponsiveEphemeralWorkersCountArgsForCall = append(fake.deleteUnresponsiveEphemeralWorkersCountArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.DeleteUnresponsiveEphemeralWorkersCountStub
+	fakeReturns := fake.deleteUnresponsiveEphemeralWorkersCountReturns
+	fake.recordInvocation("DeleteUnresponsiveEphemeralWorkersCount", []interface{}{arg1})
+	fake.deleteUnresponsiveEphemeralWorkersCountMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkersCountCallCount() int {
+	fake.deleteUnresponsiveEphemeralWorkersCountMutex.RLock()
+	defer fake.deleteUnresponsiveEphemeralWorkersCountMutex.RUnlock()
+	return len(fake.deleteUnresponsiveEphemeralWorkersCountArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkersCountCalls(stub func(context.Context) (int, error)) {
+	fake.deleteUnresponsiveEphemeralWorkersCountMutex.Lock()
+	defer fake.deleteUnresponsiveEphemeralWorkersCountMutex.Unlock()
+	fake.DeleteUnresponsiveEphemeralWorkersCountStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkersCountArgsForCall(i int) context.Context {
+	fake.deleteUnresponsiveEphemeralWorkersCountMutex.RLock()
+	defer fake.deleteUnresponsiveEphemeralWorkersCountMutex.RUnlock()
+	argsForCall := fake.deleteUnresponsiveEphemeralWorkersCountArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkersCountReturns(result1 int, result2 error) {
+	fake.deleteUnresponsiveEphemeralWorkersCountMutex.Lock()
+	defer fake.deleteUnresponsiveEphemeralWorkersCountMutex.Unlock()
+	fake.DeleteUnresponsiveEphemeralWorkersCountStub = nil
+	fake.deleteUnresponsiveEphemeralWorkersCountReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkersCountReturnsOnCall(i int, result1 int, result2 error) {
+	fake.deleteUnresponsiveEphemeralWorkersCountMutex.Lock()
+	defer fake.deleteUnresponsiveEphemeralWorkersCountMutex.Unlock()
+	fake.DeleteUnresponsiveEphemeralWorkersCountStub = nil
+	if fake.deleteUnresponsiveEphemeralWorkersCountReturnsOnCall == nil {
+		fake.deleteUnresponsiveEphemeralWorkersCountReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.deleteUnresponsiveEphemeralWorkersCountReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkersDetailed(arg1 context.Context) ([]db.DeletedWorker, error) {
+	fake.deleteUnresponsiveEphemeralWorkersDetailedMutex.Lock()
+	ret, specificReturn := fake.deleteUnresponsiveEphemeralWorkersDetailedReturnsOnCall[len(fake.deleteUnresponsiveEphemeralWorkersDetailedArgsForCall)]
+	fake.deleteUnresponsiveEphemeralWorkersDetailedArgsForCall = append(fake.deleteUnresponsiveEphemeralWorkersDetailedArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.DeleteUnresponsiveEphemeralWorkersDetailedStub
+	fakeReturns := fake.deleteUnresponsiveEphemeralWorkersDetailedReturns
+	fake.recordInvocation("DeleteUnresponsiveEphemeralWorkersDetailed", []interface{}{arg1})
+	fake.deleteUnresponsiveEphemeralWorkersDetailedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkersDetailedCallCount() int {
+	fake.deleteUnresponsiveEphemeralWorkersDetailedMutex.RLock()
+	defer fake.deleteUnresponsiveEphemeralWorkersDetailedMutex.RUnlock()
+	return len(fake.deleteUnresponsiveEphemeralWorkersDetailedArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkersDetailedCalls(stub func(context.Context) ([]db.DeletedWorker, error)) {
+	fake.deleteUnresponsiveEphemeralWorkersDetailedMutex.Lock()
+	defer fake.deleteUnresponsiveEphemeralWorkersDetailedMutex.Unlock()
+	fake.DeleteUnresponsiveEphemeralWorkersDetailedStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkersDetailedArgsForCall(i int) context.Context {
+	fake.deleteUnresponsiveEphemeralWorkersDetailedMutex.RLock()
+	defer fake.deleteUnresponsiveEphemeralWorkersDetailedMutex.RUnlock()
+	argsForCall := fake.deleteUnresponsiveEphemeralWorkersDetailedArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkersDetailedReturns(result1 []db.DeletedWorker, result2 error) {
+	fake.deleteUnresponsiveEphemeralWorkersDetailedMutex.Lock()
+	defer fake.deleteUnresponsiveEphemeralWorkersDetailedMutex.Unlock()
+	fake.DeleteUnresponsiveEphemeralWorkersDetailedStub = nil
+	fake.deleteUnresponsiveEphemeralWorkersDetailedReturns = struct {
+		result1 []db.DeletedWorker
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkersDetailedReturnsOnCall(i int, result1 []db.DeletedWorker, result2 error) {
+	fake.deleteUnresponsiveEphemeralWorkersDetailedMutex.Lock()
+	defer fake.deleteUnresponsiveEphemeralWorkersDetailedMutex.Unlock()
+	fake.DeleteUnresponsiveEphemeralWorkersDetailedStub = nil
+	if fake.deleteUnresponsiveEphemeralWorkersDetailedReturnsOnCall == nil {
+		fake.deleteUnresponsiveEphemeralWorkersDetailedReturnsOnCall = make(map[int]struct {
+			result1 []db.DeletedWorker
+			result2 error
+		})
+	}
+	fake.deleteUnresponsiveEphemeralWorkersDetailedReturnsOnCall[i] = struct {
+		result1 []db.DeletedWorker
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkersExcept(arg1 context.Context, arg2 []string) ([]string, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.deleteUnresponsiveEphemeralWorkersExceptMutex.Lock()
+	ret, specificReturn := fake.deleteUnresponsiveEphemeralWorkersExceptReturnsOnCall[len(fake.deleteUnresponsiveEphemeralWorkersExceptArgsForCall)]
+	fake.deleteUnresponsiveEphemeralWorkersExceptArgsForCall = append(fake.deleteUnresponsiveEphemeralWorkersExceptArgsForCall, struct {
+		arg1 context.Context
+		arg2 []string
+	}{arg1, arg2Copy})
+	stub := fake.DeleteUnresponsiveEphemeralWorkersExceptStub
+	fakeReturns := fake.deleteUnresponsiveEphemeralWorkersExceptReturns
+	fake.recordInvocation("DeleteUnresponsiveEphemeralWorkersExcept", []interface{}{arg1, arg2Copy})
+	fake.deleteUnresponsiveEphemeralWorkersExceptMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkersExceptCallCount() int {
+	fake.deleteUnresponsiveEphemeralWorkersExceptMutex.RLock()
+	defer fake.deleteUnresponsiveEphemeralWorkersExceptMutex.RUnlock()
+	return len(fake.deleteUnresponsiveEphemeralWorkersExceptArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkersExceptCalls(stub func(context.Context, []string) ([]string, error)) {
+	fake.deleteUnresponsiveEphemeralWorkersExceptMutex.Lock()
+	defer fake.deleteUnresponsiveEphemeralWorkersExceptMutex.Unlock()
+	fake.DeleteUnresponsiveEphemeralWorkersExceptStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkersExceptArgsForCall(i int) (context.Context, []string) {
+	fake.deleteUnresponsiveEphemeralWorkersExceptMutex.RLock()
+	defer fake.deleteUnresponsiveEphemeralWorkersExceptMutex.RUnlock()
+	argsForCall := fake.deleteUnresponsiveEphemeralWorkersExceptArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkersExceptReturns(result1 []string, result2 error) {
+	fake.deleteUnresponsiveEphemeralWorkersExceptMutex.Lock()
+	defer fake.deleteUnresponsiveEphemeralWorkersExceptMutex.Unlock()
+	fake.DeleteUnresponsiveEphemeralWorkersExceptStub = nil
+	fake.deleteUnresponsiveEphemeralWorkersExceptReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkersExceptReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.deleteUnresponsiveEphemeralWorkersExceptMutex.Lock()
+	defer fake.deleteUnresponsiveEphemeralWorkersExceptMutex.Unlock()
+	fake.DeleteUnresponsiveEphemeralWorkersExceptStub = nil
+	if fake.deleteUnresponsiveEphemeralWorkersExceptReturnsOnCall == nil {
+		fake.deleteUnresponsiveEphemeralWorkersExceptReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.deleteUnresponsiveEphemeralWorkersExceptReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkersSQL() (string, []any, error) {
+	fake.deleteUnresponsiveEphemeralWorkersSQLMutex.Lock()
+	ret, specificReturn := fake.deleteUnresponsiveEphemeralWorkersSQLReturnsOnCall[len(fake.deleteUnresponsiveEphemeralWorkersSQLArgsForCall)]
+	fake.deleteUnresponsiveEphemeralWorkersSQLArgsForCall = append(fake.deleteUnresponsiveEphemeralWorkersSQLArgsForCall, struct {
+	}{})
+	stub := fake.DeleteUnresponsiveEphemeralWorkersSQLStub
+	fakeReturns := fake.deleteUnresponsiveEphemeralWorkersSQLReturns
+	fake.recordInvocation("DeleteUnresponsiveEphemeralWorkersSQL", []interface{}{})
+	fake.deleteUnresponsiveEphemeralWorkersSQLMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkersSQLCallCount() int {
+	fake.deleteUnresponsiveEphemeralWorkersSQLMutex.RLock()
+	defer fake.deleteUnresponsiveEphemeralWorkersSQLMutex.RUnlock()
+	return len(fake.deleteUnresponsiveEphemeralWorkersSQLArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkersSQLCalls(stub func() (string, []any, error)) {
+	fake.deleteUnresponsiveEphemeralWorkersSQLMutex.Lock()
+	defer fake.deleteUnresponsiveEphemeralWorkersSQLMutex.Unlock()
+	fake.DeleteUnresponsiveEphemeralWorkersSQLStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkersSQLReturns(result1 string, result2 []any, result3 error) {
+	fake.deleteUnresponsiveEphemeralWorkersSQLMutex.Lock()
+	defer fake.deleteUnresponsiveEphemeralWorkersSQLMutex.Unlock()
+	fake.DeleteUnresponsiveEphemeralWorkersSQLStub = nil
+	fake.deleteUnresponsiveEphemeralWorkersSQLReturns = struct {
+		result1 string
+		result2 []any
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkersSQLReturnsOnCall(i int, result1 string, result2 []any, result3 error) {
+	fake.deleteUnresponsiveEphemeralWorkersSQLMutex.Lock()
+	defer fake.deleteUnresponsiveEphemeralWorkersSQLMutex.Unlock()
+	fake.DeleteUnresponsiveEphemeralWorkersSQLStub = nil
+	if fake.deleteUnresponsiveEphemeralWorkersSQLReturnsOnCall == nil {
+		fake.deleteUnresponsiveEphemeralWorkersSQLReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 []any
+			result3 error
+		})
+	}
+	fake.deleteUnresponsiveEphemeralWorkersSQLReturnsOnCall[i] = struct {
+		result1 string
+		result2 []any
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkersWithSkew(arg1 context.Context, arg2 time.Duration) ([]string, error) {
+	fake.deleteUnresponsiveEphemeralWorkersWithSkewMutex.Lock()
+	ret, specificReturn := fake.deleteUnresponsiveEphemeralWorkersWithSkewReturnsOnCall[len(fake.deleteUnresponsiveEphemeralWorkersWithSkewArgsForCall)]
+	fake.deleteUnresponsiveEphemeralWorkersWithSkewArgsForCall = append(fake.deleteUnresponsiveEphemeralWorkersWithSkewArgsForCall, struct {
+		arg1 context.Context
+		arg2 time.Duration
+	}{arg1, arg2})
+	stub := fake.DeleteUnresponsiveEphemeralWorkersWithSkewStub
+	fakeReturns := fake.deleteUnresponsiveEphemeralWorkersWithSkewReturns
+	fake.recordInvocation("DeleteUnresponsiveEphemeralWorkersWithSkew", []interface{}{arg1, arg2})
+	fake.deleteUnresponsiveEphemeralWorkersWithSkewMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkersWithSkewCallCount() int {
+	fake.deleteUnresponsiveEphemeralWorkersWithSkewMutex.RLock()
+	defer fake.deleteUnresponsiveEphemeralWorkersWithSkewMutex.RUnlock()
+	return len(fake.deleteUnresponsiveEphemeralWorkersWithSkewArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkersWithSkewCalls(stub func(context.Context, time.Duration) ([]string, error)) {
+	fake.deleteUnresponsiveEphemeralWorkersWithSkewMutex.Lock()
+	defer fake.deleteUnresponsiveEphemeralWorkersWithSkewMutex.Unlock()
+	fake.DeleteUnresponsiveEphemeralWorkersWithSkewStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkersWithSkewArgsForCall(i int) (context.Context, time.Duration) {
+	fake.deleteUnresponsiveEphemeralWorkersWithSkewMutex.RLock()
+	defer fake.deleteUnresponsiveEphemeralWorkersWithSkewMutex.RUnlock()
+	argsForCall := fake.deleteUnresponsiveEphemeralWorkersWithSkewArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkersWithSkewReturns(result1 []string, result2 error) {
+	fake.deleteUnresponsiveEphemeralWorkersWithSkewMutex.Lock()
+	defer fake.deleteUnresponsiveEphemeralWorkersWithSkewMutex.Unlock()
+	fake.DeleteUnresponsiveEphemeralWorkersWithSkewStub = nil
+	fake.deleteUnresponsiveEphemeralWorkersWithSkewReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkersWithSkewReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.deleteUnresponsiveEphemeralWorkersWithSkewMutex.Lock()
+	defer fake.deleteUnresponsiveEphemeralWorkersWithSkewMutex.Unlock()
+	fake.DeleteUnresponsiveEphemeralWorkersWithSkewStub = nil
+	if fake.deleteUnresponsiveEphemeralWorkersWithSkewReturnsOnCall == nil {
+		fake.deleteUnresponsiveEphemeralWorkersWithSkewReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.deleteUnresponsiveEphemeralWorkersWithSkewReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) DeleteWorkersForDeletedTeams(arg1 context.Context) ([]string, error) {
+	fake.deleteWorkersForDeletedTeamsMutex.Lock()
+	ret, specificReturn := fake.deleteWorkersForDeletedTeamsReturnsOnCall[len(fake.deleteWorkersForDeletedTeamsArgsForCall)]
+	fake.deleteWorkersForDeletedTeamsArgsForCall = append(fake.deleteWorkersForDeletedTeamsArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.DeleteWorkersForDeletedTeamsStub
+	fakeReturns := fake.deleteWorkersForDeletedTeamsReturns
+	fake.recordInvocation("DeleteWorkersForDeletedTeams", []interface{}{arg1})
+	fake.deleteWorkersForDeletedTeamsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) DeleteWorkersForDeletedTeamsCallCount() int {
+	fake.deleteWorkersForDeletedTeamsMutex.RLock()
+	defer fake.deleteWorkersForDeletedTeamsMutex.RUnlock()
+	return len(fake.deleteWorkersForDeletedTeamsArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) DeleteWorkersForDeletedTeamsCalls(stub func(context.Context) ([]string, error)) {
+	fake.deleteWorkersForDeletedTeamsMutex.Lock()
+	defer fake.deleteWorkersForDeletedTeamsMutex.Unlock()
+	fake.DeleteWorkersForDeletedTeamsStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) DeleteWorkersForDeletedTeamsArgsForCall(i int) context.Context {
+	fake.deleteWorkersForDeletedTeamsMutex.RLock()
+	defer fake.deleteWorkersForDeletedTeamsMutex.RUnlock()
+	argsForCall := fake.deleteWorkersForDeletedTeamsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) DeleteWorkersForDeletedTeamsReturns(result1 []string, result2 error) {
+	fake.deleteWorkersForDeletedTeamsMutex.Lock()
+	defer fake.deleteWorkersForDeletedTeamsMutex.Unlock()
+	fake.DeleteWorkersForDeletedTeamsStub = nil
+	fake.deleteWorkersForDeletedTeamsReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) DeleteWorkersForDeletedTeamsReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.deleteWorkersForDeletedTeamsMutex.Lock()
+	defer fake.deleteWorkersForDeletedTeamsMutex.Unlock()
+	fake.DeleteWorkersForDeletedTeamsStub = nil
+	if fake.deleteWorkersForDeletedTeamsReturnsOnCall == nil {
+		fake.deleteWorkersForDeletedTeamsReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.deleteWorkersForDeletedTeamsReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) DrainWorker(arg1 context.Context, arg2 string) error {
+	fake.drainWorkerMutex.Lock()
+	ret, specificReturn := fake.drainWorkerReturnsOnCall[len(fake.drainWorkerArgsForCall)]
+	fake.drainWorkerArgsForCall = append(fake.drainWorkerArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.DrainWorkerStub
+	fakeReturns := fake.drainWorkerReturns
+	fake.recordInvocation("DrainWorker", []interface{}{arg1, arg2})
+	fake.drainWorkerMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorkerLifecycle) DrainWorkerCallCount() int {
+	fake.drainWorkerMutex.RLock()
+	defer fake.drainWorkerMutex.RUnlock()
+	return len(fake.drainWorkerArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) DrainWorkerCalls(stub func(context.Context, string) error) {
+	fake.drainWorkerMutex.Lock()
+	defer fake.drainWorkerMutex.Unlock()
+	fake.DrainWorkerStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) DrainWorkerArgsForCall(i int) (context.Context, string) {
+	fake.drainWorkerMutex.RLock()
+	defer fake.drainWorkerMutex.RUnlock()
+	argsForCall := fake.drainWorkerArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorkerLifecycle) DrainWorkerReturns(result1 error) {
+	fake.drainWorkerMutex.Lock()
+	defer fake.drainWorkerMutex.Unlock()
+	fake.DrainWorkerStub = nil
+	fake.drainWorkerReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorkerLifecycle) DrainWorkerReturnsOnCall(i int, result1 error) {
+	fake.drainWorkerMutex.Lock()
+	defer fake.drainWorkerMutex.Unlock()
+	fake.DrainWorkerStub = nil
+	if fake.drainWorkerReturnsOnCall == nil {
+		fake.drainWorkerReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.drainWorkerReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorkerLifecycle) EphemeralExpiryHistogram(arg1 context.Context, arg2 []time.Duration) (map[time.Duration]int, error) {
+	var arg2Copy []time.Duration
+	if arg2 != nil {
+		arg2Copy = make([]time.Duration, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.ephemeralExpiryHistogramMutex.Lock()
+	ret, specificReturn := fake.ephemeralExpiryHistogramReturnsOnCall[len(fake.ephemeralExpiryHistogramArgsForCall)]
+	fake.ephemeralExpiryHistogramArgsForCall = append(fake.ephemeralExpiryHistogramArgsForCall, struct {
+		arg1 context.Context
+		arg2 []time.Duration
+	}{arg1, arg2Copy})
+	stub := fake.EphemeralExpiryHistogramStub
+	fakeReturns := fake.ephemeralExpiryHistogramReturns
+	fake.recordInvocation("EphemeralExpiryHistogram", []interface{}{arg1, arg2Copy})
+	fake.ephemeralExpiryHistogramMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) EphemeralExpiryHistogramCallCount() int {
+	fake.ephemeralExpiryHistogramMutex.RLock()
+	defer fake.ephemeralExpiryHistogramMutex.RUnlock()
+	return len(fake.ephemeralExpiryHistogramArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) EphemeralExpiryHistogramCalls(stub func(context.Context, []time.Duration) (map[time.Duration]int, error)) {
+	fake.ephemeralExpiryHistogramMutex.Lock()
+	defer fake.ephemeralExpiryHistogramMutex.Unlock()
+	fake.EphemeralExpiryHistogramStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) EphemeralExpiryHistogramArgsForCall(i int) (context.Context, []time.Duration) {
+	fake.ephemeralExpiryHistogramMutex.RLock()
+	defer fake.ephemeralExpiryHistogramMutex.RUnlock()
+	argsForCall := fake.ephemeralExpiryHistogramArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorkerLifecycle) EphemeralExpiryHistogramReturns(result1 map[time.Duration]int, result2 error) {
+	fake.ephemeralExpiryHistogramMutex.Lock()
+	defer fake.ephemeralExpiryHistogramMutex.Unlock()
+	fake.EphemeralExpiryHistogramStub = nil
+	fake.ephemeralExpiryHistogramReturns = struct {
+		result1 map[time.Duration]int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) EphemeralExpiryHistogramReturnsOnCall(i int, result1 map[time.Duration]int, result2 error) {
+	fake.ephemeralExpiryHistogramMutex.Lock()
+	defer fake.ephemeralExpiryHistogramMutex.Unlock()
+	fake.EphemeralExpiryHistogramStub = nil
+	if fake.ephemeralExpiryHistogramReturnsOnCall == nil {
+		fake.ephemeralExpiryHistogramReturnsOnCall = make(map[int]struct {
+			result1 map[time.Duration]int
+			result2 error
+		})
+	}
+	fake.ephemeralExpiryHistogramReturnsOnCall[i] = struct {
+		result1 map[time.Duration]int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) EstimateLandingDrainTimes(arg1 context.Context) (map[string]time.Duration, error) {
+	fake.estimateLandingDrainTimesMutex.Lock()
+	ret, specificReturn := fake.estimateLandingDrainTimesReturnsOnCall[len(fake.estimateLandingDrainTimesArgsForCall)]
+	fake.estimateLandingDrainTimesArgsForCall = append(fake.estimateLandingDrainTimesArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.EstimateLandingDrainTimesStub
+	fakeReturns := fake.estimateLandingDrainTimesReturns
+	fake.recordInvocation("EstimateLandingDrainTimes", []interface{}{arg1})
+	fake.estimateLandingDrainTimesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) EstimateLandingDrainTimesCallCount() int {
+	fake.estimateLandingDrainTimesMutex.RLock()
+	defer fake.estimateLandingDrainTimesMutex.RUnlock()
+	return len(fake.estimateLandingDrainTimesArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) EstimateLandingDrainTimesCalls(stub func(context.Context) (map[string]time.Duration, error)) {
+	fake.estimateLandingDrainTimesMutex.Lock()
+	defer fake.estimateLandingDrainTimesMutex.Unlock()
+	fake.EstimateLandingDrainTimesStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) EstimateLandingDrainTimesArgsForCall(i int) context.Context {
+	fake.estimateLandingDrainTimesMutex.RLock()
+	defer fake.estimateLandingDrainTimesMutex.RUnlock()
+	argsForCall := fake.estimateLandingDrainTimesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) EstimateLandingDrainTimesReturns(result1 map[string]time.Duration, result2 error) {
+	fake.estimateLandingDrainTimesMutex.Lock()
+	defer fake.estimateLandingDrainTimesMutex.Unlock()
+	fake.EstimateLandingDrainTimesStub = nil
+	fake.estimateLandingDrainTimesReturns = struct {
+		result1 map[string]time.Duration
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) EstimateLandingDrainTimesReturnsOnCall(i int, result1 map[string]time.Duration, result2 error) {
+	fake.estimateLandingDrainTimesMutex.Lock()
+	defer fake.estimateLandingDrainTimesMutex.Unlock()
+	fake.EstimateLandingDrainTimesStub = nil
+	if fake.estimateLandingDrainTimesReturnsOnCall == nil {
+		fake.estimateLandingDrainTimesReturnsOnCall = make(map[int]struct {
+			result1 map[string]time.Duration
+			result2 error
+		})
+	}
+	fake.estimateLandingDrainTimesReturnsOnCall[i] = struct {
+		result1 map[string]time.Duration
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) ExpireWorker(arg1 context.Context, arg2 string) error {
+	fake.expireWorkerMutex.Lock()
+	ret, specificReturn := fake.expireWorkerReturnsOnCall[len(fake.expireWorkerArgsForCall)]
+	fake.expireWorkerArgsForCall = append(fake.expireWorkerArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ExpireWorkerStub
+	fakeReturns := fake.expireWorkerReturns
+	fake.recordInvocation("ExpireWorker", []interface{}{arg1, arg2})
+	fake.expireWorkerMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorkerLifecycle) ExpireWorkerCallCount() int {
+	fake.expireWorkerMutex.RLock()
+	defer fake.expireWorkerMutex.RUnlock()
+	return len(fake.expireWorkerArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) ExpireWorkerCalls(stub func(context.Context, string) error) {
+	fake.expireWorkerMutex.Lock()
+	defer fake.expireWorkerMutex.Unlock()
+	fake.ExpireWorkerStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) ExpireWorkerArgsForCall(i int) (context.Context, string) {
+	fake.expireWorkerMutex.RLock()
+	defer fake.expireWorkerMutex.RUnlock()
+	argsForCall := fake.expireWorkerArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorkerLifecycle) ExpireWorkerReturns(result1 error) {
+	fake.expireWorkerMutex.Lock()
+	defer fake.expireWorkerMutex.Unlock()
+	fake.ExpireWorkerStub = nil
+	fake.expireWorkerReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorkerLifecycle) ExpireWorkerReturnsOnCall(i int, result1 error) {
+	fake.expireWorkerMutex.Lock()
+	defer fake.expireWorkerMutex.Unlock()
+	fake.ExpireWorkerStub = nil
+	if fake.expireWorkerReturnsOnCall == nil {
+		fake.expireWorkerReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.expireWorkerReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorkerLifecycle) ExtendEphemeralExpiry(arg1 context.Context, arg2 string, arg3 time.Duration) (bool, error) {
+	fake.extendEphemeralExpiryMutex.Lock()
+	ret, specificReturn := fake.extendEphemeralExpiryReturnsOnCall[len(fake.extendEphemeralExpiryArgsForCall)]
+	fake.extendEphemeralExpiryArgsForCall = append(fake.extendEphemeralExpiryArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 time.Duration
+	}{arg1, arg2, arg3})
+	stub := fake.ExtendEphemeralExpiryStub
+	fakeReturns := fake.extendEphemeralExpiryReturns
+	fake.recordInvocation("ExtendEphemeralExpiry", []interface{}{arg1, arg2, arg3})
+	fake.extendEphemeralExpiryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) ExtendEphemeralExpiryCallCount() int {
+	fake.extendEphemeralExpiryMutex.RLock()
+	defer fake.extendEphemeralExpiryMutex.RUnlock()
+	return len(fake.extendEphemeralExpiryArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) ExtendEphemeralExpiryCalls(stub func(context.Context, string, time.Duration) (bool, error)) {
+	fake.extendEphemeralExpiryMutex.Lock()
+	defer fake.extendEphemeralExpiryMutex.Unlock()
+	fake.ExtendEphemeralExpiryStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) ExtendEphemeralExpiryArgsForCall(i int) (context.Context, string, time.Duration) {
+	fake.extendEphemeralExpiryMutex.RLock()
+	defer fake.extendEphemeralExpiryMutex.RUnlock()
+	argsForCall := fake.extendEphemeralExpiryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeWorkerLifecycle) ExtendEphemeralExpiryReturns(result1 bool, result2 error) {
+	fake.extendEphemeralExpiryMutex.Lock()
+	defer fake.extendEphemeralExpiryMutex.Unlock()
+	fake.ExtendEphemeralExpiryStub = nil
+	fake.extendEphemeralExpiryReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) ExtendEphemeralExpiryReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.extendEphemeralExpiryMutex.Lock()
+	defer fake.extendEphemeralExpiryMutex.Unlock()
+	fake.ExtendEphemeralExpiryStub = nil
+	if fake.extendEphemeralExpiryReturnsOnCall == nil {
+		fake.extendEphemeralExpiryReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.extendEphemeralExpiryReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) FindDuplicateWorkerAddresses(arg1 context.Context) (map[string][]string, error) {
+	fake.findDuplicateWorkerAddressesMutex.Lock()
+	ret, specificReturn := fake.findDuplicateWorkerAddressesReturnsOnCall[len(fake.findDuplicateWorkerAddressesArgsForCall)]
+	fake.findDuplicateWorkerAddressesArgsForCall = append(fake.findDuplicateWorkerAddressesArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.FindDuplicateWorkerAddressesStub
+	fakeReturns := fake.findDuplicateWorkerAddressesReturns
+	fake.recordInvocation("FindDuplicateWorkerAddresses", []interface{}{arg1})
+	fake.findDuplicateWorkerAddressesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) FindDuplicateWorkerAddressesCallCount() int {
+	fake.findDuplicateWorkerAddressesMutex.RLock()
+	defer fake.findDuplicateWorkerAddressesMutex.RUnlock()
+	return len(fake.findDuplicateWorkerAddressesArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) FindDuplicateWorkerAddressesCalls(stub func(context.Context) (map[string][]string, error)) {
+	fake.findDuplicateWorkerAddressesMutex.Lock()
+	defer fake.findDuplicateWorkerAddressesMutex.Unlock()
+	fake.FindDuplicateWorkerAddressesStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) FindDuplicateWorkerAddressesArgsForCall(i int) context.Context {
+	fake.findDuplicateWorkerAddressesMutex.RLock()
+	defer fake.findDuplicateWorkerAddressesMutex.RUnlock()
+	argsForCall := fake.findDuplicateWorkerAddressesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) FindDuplicateWorkerAddressesReturns(result1 map[string][]string, result2 error) {
+	fake.findDuplicateWorkerAddressesMutex.Lock()
+	defer fake.findDuplicateWorkerAddressesMutex.Unlock()
+	fake.FindDuplicateWorkerAddressesStub = nil
+	fake.findDuplicateWorkerAddressesReturns = struct {
+		result1 map[string][]string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) FindDuplicateWorkerAddressesReturnsOnCall(i int, result1 map[string][]string, result2 error) {
+	fake.findDuplicateWorkerAddressesMutex.Lock()
+	defer fake.findDuplicateWorkerAddressesMutex.Unlock()
+	fake.FindDuplicateWorkerAddressesStub = nil
+	if fake.findDuplicateWorkerAddressesReturnsOnCall == nil {
+		fake.findDuplicateWorkerAddressesReturnsOnCall = make(map[int]struct {
+			result1 map[string][]string
+			result2 error
+		})
+	}
+	fake.findDuplicateWorkerAddressesReturnsOnCall[i] = struct {
+		result1 map[string][]string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) FindExpiredWorkersWithActiveBuilds(arg1 context.Context) ([]string, error) {
+	fake.findExpiredWorkersWithActiveBuildsMutex.Lock()
+	ret, specificReturn := fake.findExpiredWorkersWithActiveBuildsReturnsOnCall[len(fake.findExpiredWorkersWithActiveBuildsArgsForCall)]
+	fake.findExpiredWorkersWithActiveBuildsArgsForCall = append(fake.findExpiredWorkersWithActiveBuildsArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.FindExpiredWorkersWithActiveBuildsStub
+	fakeReturns := fake.findExpiredWorkersWithActiveBuildsReturns
+	fake.recordInvocation("FindExpiredWorkersWithActiveBuilds", []interface{}{arg1})
+	fake.findExpiredWorkersWithActiveBuildsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) FindExpiredWorkersWithActiveBuildsCallCount() int {
+	fake.findExpiredWorkersWithActiveBuildsMutex.RLock()
+	defer fake.findExpiredWorkersWithActiveBuildsMutex.RUnlock()
+	return len(fake.findExpiredWorkersWithActiveBuildsArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) FindExpiredWorkersWithActiveBuildsCalls(stub func(context.Context) ([]string, error)) {
+	fake.findExpiredWorkersWithActiveBuildsMutex.Lock()
+	defer fake.findExpiredWorkersWithActiveBuildsMutex.Unlock()
+	fake.FindExpiredWorkersWithActiveBuildsStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) FindExpiredWorkersWithActiveBuildsArgsForCall(i int) context.Context {
+	fake.findExpiredWorkersWithActiveBuildsMutex.RLock()
+	defer fake.findExpiredWorkersWithActiveBuildsMutex.RUnlock()
+	argsForCall := fake.findExpiredWorkersWithActiveBuildsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) FindExpiredWorkersWithActiveBuildsReturns(result1 []string, result2 error) {
+	fake.findExpiredWorkersWithActiveBuildsMutex.Lock()
+	defer fake.findExpiredWorkersWithActiveBuildsMutex.Unlock()
+	fake.FindExpiredWorkersWithActiveBuildsStub = nil
+	fake.findExpiredWorkersWithActiveBuildsReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) FindExpiredWorkersWithActiveBuildsReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.findExpiredWorkersWithActiveBuildsMutex.Lock()
+	defer fake.findExpiredWorkersWithActiveBuildsMutex.Unlock()
+	fake.FindExpiredWorkersWithActiveBuildsStub = nil
+	if fake.findExpiredWorkersWithActiveBuildsReturnsOnCall == nil {
+		fake.findExpiredWorkersWithActiveBuildsReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.findExpiredWorkersWithActiveBuildsReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) FindIdleWorkers(arg1 context.Context, arg2 time.Duration) ([]string, error) {
+	fake.findIdleWorkersMutex.Lock()
+	ret, specificReturn := fake.findIdleWorkersReturnsOnCall[len(fake.findIdleWorkersArgsForCall)]
+	fake.findIdleWorkersArgsForCall = append(fake.findIdleWorkersArgsForCall, struct {
+		arg1 context.Context
+		arg2 time.Duration
+	}{arg1, arg2})
+	stub := fake.FindIdleWorkersStub
+	fakeReturns := fake.findIdleWorkersReturns
+	fake.recordInvocation("FindIdleWorkers", []interface{}{arg1, arg2})
+	fake.findIdleWorkersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) FindIdleWorkersCallCount() int {
+	fake.findIdleWorkersMutex.RLock()
+	defer fake.findIdleWorkersMutex.RUnlock()
+	return len(fake.findIdleWorkersArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) FindIdleWorkersCalls(stub func(context.Context, time.Duration) ([]string, error)) {
+	fake.findIdleWorkersMutex.Lock()
+	defer fake.findIdleWorkersMutex.Unlock()
+	fake.FindIdleWorkersStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) FindIdleWorkersArgsForCall(i int) (context.Context, time.Duration) {
+	fake.findIdleWorkersMutex.RLock()
+	defer fake.findIdleWorkersMutex.RUnlock()
+	argsForCall := fake.findIdleWorkersArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorkerLifecycle) FindIdleWorkersReturns(result1 []string, result2 error) {
+	fake.findIdleWorkersMutex.Lock()
+	defer fake.findIdleWorkersMutex.Unlock()
+	fake.FindIdleWorkersStub = nil
+	fake.findIdleWorkersReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) FindIdleWorkersReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.findIdleWorkersMutex.Lock()
+	defer fake.findIdleWorkersMutex.Unlock()
+	fake.FindIdleWorkersStub = nil
+	if fake.findIdleWorkersReturnsOnCall == nil {
+		fake.findIdleWorkersReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.findIdleWorkersReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) FindInconsistentWorkers(arg1 context.Context) ([]db.WorkerInconsistency, error) {
+	fake.findInconsistentWorkersMutex.Lock()
+	ret, specificReturn := fake.findInconsistentWorkersReturnsOnCall[len(fake.findInconsistentWorkersArgsForCall)]
+	fake.findInconsistentWorkersArgsForCall = append(fake.findInconsistentWorkersArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.FindInconsistentWorkersStub
+	fakeReturns := fake.findInconsistentWorkersReturns
+	fake.recordInvocation("FindInconsistentWorkers", []interface{}{arg1})
+	fake.findInconsistentWorkersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) FindInconsistentWorkersCallCount() int {
+	fake.findInconsistentWorkersMutex.RLock()
+	defer fake.findInconsistentWorkersMutex.RUnlock()
+	return len(fake.findInconsistentWorkersArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) FindInconsistentWorkersCalls(stub func(context.Context) ([]db.WorkerInconsistency, error)) {
+	fake.findInconsistentWorkersMutex.Lock()
+	defer fake.findInconsistentWorkersMutex.Unlock()
+	fake.FindInconsistentWorkersStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) FindInconsistentWorkersArgsForCall(i int) context.Context {
+	fake.findInconsistentWorkersMutex.RLock()
+	defer fake.findInconsistentWorkersMutex.RUnlock()
+	argsForCall := fake.findInconsistentWorkersArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) FindInconsistentWorkersReturns(result1 []db.WorkerInconsistency, result2 error) {
+	fake.findInconsistentWorkersMutex.Lock()
+	defer fake.findInconsistentWorkersMutex.Unlock()
+	fake.FindInconsistentWorkersStub = nil
+	fake.findInconsistentWorkersReturns = struct {
+		result1 []db.WorkerInconsistency
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) FindInconsistentWorkersReturnsOnCall(i int, result1 []db.WorkerInconsistency, result2 error) {
+	fake.findInconsistentWorkersMutex.Lock()
+	defer fake.findInconsistentWorkersMutex.Unlock()
+	fake.FindInconsistentWorkersStub = nil
+	if fake.findInconsistentWorkersReturnsOnCall == nil {
+		fake.findInconsistentWorkersReturnsOnCall = make(map[int]struct {
+			result1 []db.WorkerInconsistency
+			result2 error
+		})
+	}
+	fake.findInconsistentWorkersReturnsOnCall[i] = struct {
+		result1 []db.WorkerInconsistency
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) FindNeverLandedWorkers(arg1 context.Context) ([]string, error) {
+	fake.findNeverLandedWorkersMutex.Lock()
+	ret, specificReturn := fake.findNeverLandedWorkersReturnsOnCall[len(fake.findNeverLandedWorkersArgsForCall)]
+	fake.findNeverLandedWorkersArgsForCall = append(fake.findNeverLandedWorkersArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.FindNeverLandedWorkersStub
+	fakeReturns := fake.findNeverLandedWorkersReturns
+	fake.recordInvocation("FindNeverLandedWorkers", []interface{}{arg1})
+	fake.findNeverLandedWorkersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) FindNeverLandedWorkersCallCount() int {
+	fake.findNeverLandedWorkersMutex.RLock()
+	defer fake.findNeverLandedWorkersMutex.RUnlock()
+	return len(fake.findNeverLandedWorkersArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) FindNeverLandedWorkersCalls(stub func(context.Context) ([]string, error)) {
+	fake.findNeverLandedWorkersMutex.Lock()
+	defer fake.findNeverLandedWorkersMutex.Unlock()
+	fake.FindNeverLandedWorkersStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) FindNeverLandedWorkersArgsForCall(i int) context.Context {
+	fake.findNeverLandedWorkersMutex.RLock()
+	defer fake.findNeverLandedWorkersMutex.RUnlock()
+	argsForCall := fake.findNeverLandedWorkersArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) FindNeverLandedWorkersReturns(result1 []string, result2 error) {
+	fake.findNeverLandedWorkersMutex.Lock()
+	defer fake.findNeverLandedWorkersMutex.Unlock()
+	fake.FindNeverLandedWorkersStub = nil
+	fake.findNeverLandedWorkersReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) FindNeverLandedWorkersReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.findNeverLandedWorkersMutex.Lock()
+	defer fake.findNeverLandedWorkersMutex.Unlock()
+	fake.FindNeverLandedWorkersStub = nil
+	if fake.findNeverLandedWorkersReturnsOnCall == nil {
+		fake.findNeverLandedWorkersReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.findNeverLandedWorkersReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) FindScaleDownCandidates(arg1 context.Context, arg2 time.Duration, arg3 int) ([]string, error) {
+	fake.findScaleDownCandidatesMutex.Lock()
+	ret, specificReturn := fake.findScaleDownCandidatesReturnsOnCall[len(fake.findScaleDownCandidatesArgsForCall)]
+	fake.findScaleDownCandidatesArgsForCall = append(fake.findScaleDownCandidatesArgsForCall, struct {
+		arg1 context.Context
+		arg2 time.Duration
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.FindScaleDownCandidatesStub
+	fakeReturns := fake.findScaleDownCandidatesReturns
+	fake.recordInvocation("FindScaleDownCandidates", []interface{}{arg1, arg2, arg3})
+	fake.findScaleDownCandidatesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) FindScaleDownCandidatesCallCount() int {
+	fake.findScaleDownCandidatesMutex.RLock()
+	defer fake.findScaleDownCandidatesMutex.RUnlock()
+	return len(fake.findScaleDownCandidatesArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) FindScaleDownCandidatesCalls(stub func(context.Context, time.Duration, int) ([]string, error)) {
+	fake.findScaleDownCandidatesMutex.Lock()
+	defer fake.findScaleDownCandidatesMutex.Unlock()
+	fake.FindScaleDownCandidatesStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) FindScaleDownCandidatesArgsForCall(i int) (context.Context, time.Duration, int) {
+	fake.findScaleDownCandidatesMutex.RLock()
+	defer fake.findScaleDownCandidatesMutex.RUnlock()
+	argsForCall := fake.findScaleDownCandidatesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeWorkerLifecycle) FindScaleDownCandidatesReturns(result1 []string, result2 error) {
+	fake.findScaleDownCandidatesMutex.Lock()
+	defer fake.findScaleDownCandidatesMutex.Unlock()
+	fake.FindScaleDownCandidatesStub = nil
+	fake.findScaleDownCandidatesReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) FindScaleDownCandidatesReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.findScaleDownCandidatesMutex.Lock()
+	defer fake.findScaleDownCandidatesMutex.Unlock()
+	fake.FindScaleDownCandidatesStub = nil
+	if fake.findScaleDownCandidatesReturnsOnCall == nil {
+		fake.findScaleDownCandidatesReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.findScaleDownCandidatesReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) FindStuckRetiringWorkers(arg1 context.Context, arg2 time.Duration) ([]string, error) {
+	fake.findStuckRetiringWorkersMutex.Lock()
+	ret, specificReturn := fake.findStuckRetiringWorkersReturnsOnCall[len(fake.findStuckRetiringWorkersArgsForCall)]
+	fake.findStuckRetiringWorkersArgsForCall = append(fake.findStuckRetiringWorkersArgsForCall, struct {
+		arg1 context.Context
+		arg2 time.Duration
+	}{arg1, arg2})
+	stub := fake.FindStuckRetiringWorkersStub
+	fakeReturns := fake.findStuckRetiringWorkersReturns
+	fake.recordInvocation("FindStuckRetiringWorkers", []interface{}{arg1, arg2})
+	fake.findStuckRetiringWorkersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) FindStuckRetiringWorkersCallCount() int {
+	fake.findStuckRetiringWorkersMutex.RLock()
+	defer fake.findStuckRetiringWorkersMutex.RUnlock()
+	return len(fake.findStuckRetiringWorkersArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) FindStuckRetiringWorkersCalls(stub func(context.Context, time.Duration) ([]string, error)) {
+	fake.findStuckRetiringWorkersMutex.Lock()
+	defer fake.findStuckRetiringWorkersMutex.Unlock()
+	fake.FindStuckRetiringWorkersStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) FindStuckRetiringWorkersArgsForCall(i int) (context.Context, time.Duration) {
+	fake.findStuckRetiringWorkersMutex.RLock()
+	defer fake.findStuckRetiringWorkersMutex.RUnlock()
+	argsForCall := fake.findStuckRetiringWorkersArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorkerLifecycle) FindStuckRetiringWorkersReturns(result1 []string, result2 error) {
+	fake.findStuckRetiringWorkersMutex.Lock()
+	defer fake.findStuckRetiringWorkersMutex.Unlock()
+	fake.FindStuckRetiringWorkersStub = nil
+	fake.findStuckRetiringWorkersReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) FindStuckRetiringWorkersReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.findStuckRetiringWorkersMutex.Lock()
+	defer fake.findStuckRetiringWorkersMutex.Unlock()
+	fake.FindStuckRetiringWorkersStub = nil
+	if fake.findStuckRetiringWorkersReturnsOnCall == nil {
+		fake.findStuckRetiringWorkersReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.findStuckRetiringWorkersReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) FindUnhealthyBaggageclaimWorkers(arg1 context.Context) ([]string, error) {
+	fake.findUnhealthyBaggageclaimWorkersMutex.Lock()
+	ret, specificReturn := fake.findUnhealthyBaggageclaimWorkersReturnsOnCall[len(fake.findUnhealthyBaggageclaimWorkersArgsForCall)]
+	fake.findUnhealthyBaggageclaimWorkersArgsForCall = append(fake.findUnhealthyBaggageclaimWorkersArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.FindUnhealthyBaggageclaimWorkersStub
+	fakeReturns := fake.findUnhealthyBaggageclaimWorkersReturns
+	fake.recordInvocation("FindUnhealthyBaggageclaimWorkers", []interface{}{arg1})
+	fake.findUnhealthyBaggageclaimWorkersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) FindUnhealthyBaggageclaimWorkersCallCount() int {
+	fake.findUnhealthyBaggageclaimWorkersMutex.RLock()
+	defer fake.findUnhealthyBaggageclaimWorkersMutex.RUnlock()
+	return len(fake.findUnhealthyBaggageclaimWorkersArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) FindUnhealthyBaggageclaimWorkersCalls(stub func(context.Context) ([]string, error)) {
+	fake.findUnhealthyBaggageclaimWorkersMutex.Lock()
+	defer fake.findUnhealthyBaggageclaimWorkersMutex.Unlock()
+	fake.FindUnhealthyBaggageclaimWorkersStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) FindUnhealthyBaggageclaimWorkersArgsForCall(i int) context.Context {
+	fake.findUnhealthyBaggageclaimWorkersMutex.RLock()
+	defer fake.findUnhealthyBaggageclaimWorkersMutex.RUnlock()
+	argsForCall := fake.findUnhealthyBaggageclaimWorkersArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) FindUnhealthyBaggageclaimWorkersReturns(result1 []string, result2 error) {
+	fake.findUnhealthyBaggageclaimWorkersMutex.Lock()
+	defer fake.findUnhealthyBaggageclaimWorkersMutex.Unlock()
+	fake.FindUnhealthyBaggageclaimWorkersStub = nil
+	fake.findUnhealthyBaggageclaimWorkersReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) FindUnhealthyBaggageclaimWorkersReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.findUnhealthyBaggageclaimWorkersMutex.Lock()
+	defer fake.findUnhealthyBaggageclaimWorkersMutex.Unlock()
+	fake.FindUnhealthyBaggageclaimWorkersStub = nil
+	if fake.findUnhealthyBaggageclaimWorkersReturnsOnCall == nil {
+		fake.findUnhealthyBaggageclaimWorkersReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.findUnhealthyBaggageclaimWorkersReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) FindWorkersBlockedByDeletedJobs(arg1 context.Context) ([]string, error) {
+	fake.findWorkersBlockedByDeletedJobsMutex.Lock()
+	ret, specificReturn := fake.findWorkersBlockedByDeletedJobsReturnsOnCall[len(fake.findWorkersBlockedByDeletedJobsArgsForCall)]
+	fake.findWorkersBlockedByDeletedJobsArgsForCall = append(fake.findWorkersBlockedByDeletedJobsArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.FindWorkersBlockedByDeletedJobsStub
+	fakeReturns := fake.findWorkersBlockedByDeletedJobsReturns
+	fake.recordInvocation("FindWorkersBlockedByDeletedJobs", []interface{}{arg1})
+	fake.findWorkersBlockedByDeletedJobsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) FindWorkersBlockedByDeletedJobsCallCount() int {
+	fake.findWorkersBlockedByDeletedJobsMutex.RLock()
+	defer fake.findWorkersBlockedByDeletedJobsMutex.RUnlock()
+	return len(fake.findWorkersBlockedByDeletedJobsArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) FindWorkersBlockedByDeletedJobsCalls(stub func(context.Context) ([]string, error)) {
+	fake.findWorkersBlockedByDeletedJobsMutex.Lock()
+	defer fake.findWorkersBlockedByDeletedJobsMutex.Unlock()
+	fake.FindWorkersBlockedByDeletedJobsStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) FindWorkersBlockedByDeletedJobsArgsForCall(i int) context.Context {
+	fake.findWorkersBlockedByDeletedJobsMutex.RLock()
+	defer fake.findWorkersBlockedByDeletedJobsMutex.RUnlock()
+	argsForCall := fake.findWorkersBlockedByDeletedJobsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) FindWorkersBlockedByDeletedJobsReturns(result1 []string, result2 error) {
+	fake.findWorkersBlockedByDeletedJobsMutex.Lock()
+	defer fake.findWorkersBlockedByDeletedJobsMutex.Unlock()
+	fake.FindWorkersBlockedByDeletedJobsStub = nil
+	fake.findWorkersBlockedByDeletedJobsReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) FindWorkersBlockedByDeletedJobsReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.findWorkersBlockedByDeletedJobsMutex.Lock()
+	defer fake.findWorkersBlockedByDeletedJobsMutex.Unlock()
+	fake.FindWorkersBlockedByDeletedJobsStub = nil
+	if fake.findWorkersBlockedByDeletedJobsReturnsOnCall == nil {
+		fake.findWorkersBlockedByDeletedJobsReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.findWorkersBlockedByDeletedJobsReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) FindWorkersMissingHeartbeats(arg1 context.Context, arg2 int) ([]string, error) {
+	fake.findWorkersMissingHeartbeatsMutex.Lock()
+	ret, specificReturn := fake.findWorkersMissingHeartbeatsReturnsOnCall[len(fake.findWorkersMissingHeartbeatsArgsForCall)]
+	fake.findWorkersMissingHeartbeatsArgsForCall = append(fake.findWorkersMissingHeartbeatsArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.FindWorkersMissingHeartbeatsStub
+	fakeReturns := fake.findWorkersMissingHeartbeatsReturns
+	fake.recordInvocation("FindWorkersMissingHeartbeats", []interface{}{arg1, arg2})
+	fake.findWorkersMissingHeartbeatsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) FindWorkersMissingHeartbeatsCallCount() int {
+	fake.findWorkersMissingHeartbeatsMutex.RLock()
+	defer fake.findWorkersMissingHeartbeatsMutex.RUnlock()
+	return len(fake.findWorkersMissingHeartbeatsArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) FindWorkersMissingHeartbeatsCalls(stub func(context.Context, int) ([]string, error)) {
+	fake.findWorkersMissingHeartbeatsMutex.Lock()
+	defer fake.findWorkersMissingHeartbeatsMutex.Unlock()
+	fake.FindWorkersMissingHeartbeatsStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) FindWorkersMissingHeartbeatsArgsForCall(i int) (context.Context, int) {
+	fake.findWorkersMissingHeartbeatsMutex.RLock()
+	defer fake.findWorkersMissingHeartbeatsMutex.RUnlock()
+	argsForCall := fake.findWorkersMissingHeartbeatsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorkerLifecycle) FindWorkersMissingHeartbeatsReturns(result1 []string, result2 error) {
+	fake.findWorkersMissingHeartbeatsMutex.Lock()
+	defer fake.findWorkersMissingHeartbeatsMutex.Unlock()
+	fake.FindWorkersMissingHeartbeatsStub = nil
+	fake.findWorkersMissingHeartbeatsReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) FindWorkersMissingHeartbeatsReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.findWorkersMissingHeartbeatsMutex.Lock()
+	defer fake.findWorkersMissingHeartbeatsMutex.Unlock()
+	fake.FindWorkersMissingHeartbeatsStub = nil
+	if fake.findWorkersMissingHeartbeatsReturnsOnCall == nil {
+		fake.findWorkersMissingHeartbeatsReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.findWorkersMissingHeartbeatsReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) FindWorkersWithHeadroom(arg1 context.Context, arg2 int, arg3 int) ([]db.WorkerHeadroom, error) {
+	fake.findWorkersWithHeadroomMutex.Lock()
+	ret, specificReturn := fake.findWorkersWithHeadroomReturnsOnCall[len(fake.findWorkersWithHeadroomArgsForCall)]
+	fake.findWorkersWithHeadroomArgsForCall = append(fake.findWorkersWithHeadroomArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.FindWorkersWithHeadroomStub
+	fakeReturns := fake.findWorkersWithHeadroomReturns
+	fake.recordInvocation("FindWorkersWithHeadroom", []interface{}{arg1, arg2, arg3})
+	fake.findWorkersWithHeadroomMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) FindWorkersWithHeadroomCallCount() int {
+	fake.findWorkersWithHeadroomMutex.RLock()
+	defer fake.findWorkersWithHeadroomMutex.RUnlock()
+	return len(fake.findWorkersWithHeadroomArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) FindWorkersWithHeadroomCalls(stub func(context.Context, int, int) ([]db.WorkerHeadroom, error)) {
+	fake.findWorkersWithHeadroomMutex.Lock()
+	defer fake.findWorkersWithHeadroomMutex.Unlock()
+	fake.FindWorkersWithHeadroomStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) FindWorkersWithHeadroomArgsForCall(i int) (context.Context, int, int) {
+	fake.findWorkersWithHeadroomMutex.RLock()
+	defer fake.findWorkersWithHeadroomMutex.RUnlock()
+	argsForCall := fake.findWorkersWithHeadroomArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeWorkerLifecycle) FindWorkersWithHeadroomReturns(result1 []db.WorkerHeadroom, result2 error) {
+	fake.findWorkersWithHeadroomMutex.Lock()
+	defer fake.findWorkersWithHeadroomMutex.Unlock()
+	fake.FindWorkersWithHeadroomStub = nil
+	fake.findWorkersWithHeadroomReturns = struct {
+		result1 []db.WorkerHeadroom
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) FindWorkersWithHeadroomReturnsOnCall(i int, result1 []db.WorkerHeadroom, result2 error) {
+	fake.findWorkersWithHeadroomMutex.Lock()
+	defer fake.findWorkersWithHeadroomMutex.Unlock()
+	fake.FindWorkersWithHeadroomStub = nil
+	if fake.findWorkersWithHeadroomReturnsOnCall == nil {
+		fake.findWorkersWithHeadroomReturnsOnCall = make(map[int]struct {
+			result1 []db.WorkerHeadroom
+			result2 error
+		})
+	}
+	fake.findWorkersWithHeadroomReturnsOnCall[i] = struct {
+		result1 []db.WorkerHeadroom
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) FindWorkersWithOutdatedResourceTypes(arg1 context.Context, arg2 map[string]string) ([]string, error) {
+	fake.findWorkersWithOutdatedResourceTypesMutex.Lock()
+	ret, specificReturn := fake.findWorkersWithOutdatedResourceTypesReturnsOnCall[len(fake.findWorkersWithOutdatedResourceTypesArgsForCall)]
+	fake.findWorkersWithOutdatedResourceTypesArgsForCall = append(fake.findWorkersWithOutdatedResourceTypesArgsForCall, struct {
+		arg1 context.Context
+		arg2 map[string]string
+	}{arg1, arg2})
+	stub := fake.FindWorkersWithOutdatedResourceTypesStub
+	fakeReturns := fake.findWorkersWithOutdatedResourceTypesReturns
+	fake.recordInvocation("FindWorkersWithOutdatedResourceTypes", []interface{}{arg1, arg2})
+	fake.findWorkersWithOutdatedResourceTypesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) FindWorkersWithOutdatedResourceTypesCallCount() int {
+	fake.findWorkersWithOutdatedResourceTypesMutex.RLock()
+	defer fake.findWorkersWithOutdatedResourceTypesMutex.RUnlock()
+	return len(fake.findWorkersWithOutdatedResourceTypesArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) FindWorkersWithOutdatedResourceTypesCalls(stub func(context.Context, map[string]string) ([]string, error)) {
+	fake.findWorkersWithOutdatedResourceTypesMutex.Lock()
+	defer fake.findWorkersWithOutdatedResourceTypesMutex.Unlock()
+	fake.FindWorkersWithOutdatedResourceTypesStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) FindWorkersWithOutdatedResourceTypesArgsForCall(i int) (context.Context, map[string]string) {
+	fake.findWorkersWithOutdatedResourceTypesMutex.RLock()
+	defer fake.findWorkersWithOutdatedResourceTypesMutex.RUnlock()
+	argsForCall := fake.findWorkersWithOutdatedResourceTypesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorkerLifecycle) FindWorkersWithOutdatedResourceTypesReturns(result1 []string, result2 error) {
+	fake.findWorkersWithOutdatedResourceTypesMutex.Lock()
+	defer fake.findWorkersWithOutdatedResourceTypesMutex.Unlock()
+	fake.FindWorkersWithOutdatedResourceTypesStub = nil
+	fake.findWorkersWithOutdatedResourceTypesReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) FindWorkersWithOutdatedResourceTypesReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.findWorkersWithOutdatedResourceTypesMutex.Lock()
+	defer fake.findWorkersWithOutdatedResourceTypesMutex.Unlock()
+	fake.FindWorkersWithOutdatedResourceTypesStub = nil
+	if fake.findWorkersWithOutdatedResourceTypesReturnsOnCall == nil {
+		fake.findWorkersWithOutdatedResourceTypesReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.findWorkersWithOutdatedResourceTypesReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) ForceDeleteRetiringWorkers(arg1 context.Context) ([]string, error) {
+	fake.forceDeleteRetiringWorkersMutex.Lock()
+	ret, specificReturn := fake.forceDeleteRetiringWorkersReturnsOnCall[len(fake.forceDeleteRetiringWorkersArgsForCall)]
+	fake.forceDeleteRetiringWorkersArgsForCall = append(fake.forceDeleteRetiringWorkersArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.ForceDeleteRetiringWorkersStub
+	fakeReturns := fake.forceDeleteRetiringWorkersReturns
+	fake.recordInvocation("ForceDeleteRetiringWorkers", []interface{}{arg1})
+	fake.forceDeleteRetiringWorkersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) ForceDeleteRetiringWorkersCallCount() int {
+	fake.forceDeleteRetiringWorkersMutex.RLock()
+	defer fake.forceDeleteRetiringWorkersMutex.RUnlock()
+	return len(fake.forceDeleteRetiringWorkersArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) ForceDeleteRetiringWorkersCalls(stub func(context.Context) ([]string, error)) {
+	fake.forceDeleteRetiringWorkersMutex.Lock()
+	defer fake.forceDeleteRetiringWorkersMutex.Unlock()
+	fake.ForceDeleteRetiringWorkersStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) ForceDeleteRetiringWorkersArgsForCall(i int) context.Context {
+	fake.forceDeleteRetiringWorkersMutex.RLock()
+	defer fake.forceDeleteRetiringWorkersMutex.RUnlock()
+	argsForCall := fake.forceDeleteRetiringWorkersArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) ForceDeleteRetiringWorkersReturns(result1 []string, result2 error) {
+	fake.forceDeleteRetiringWorkersMutex.Lock()
+	defer fake.forceDeleteRetiringWorkersMutex.Unlock()
+	fake.ForceDeleteRetiringWorkersStub = nil
+	fake.forceDeleteRetiringWorkersReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) ForceDeleteRetiringWorkersReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.forceDeleteRetiringWorkersMutex.Lock()
+	defer fake.forceDeleteRetiringWorkersMutex.Unlock()
+	fake.ForceDeleteRetiringWorkersStub = nil
+	if fake.forceDeleteRetiringWorkersReturnsOnCall == nil {
+		fake.forceDeleteRetiringWorkersReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.forceDeleteRetiringWorkersReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) GetBuildsBlockingWorkerLanding(arg1 context.Context, arg2 string) ([]db.BlockingBuild, error) {
+	fake.getBuildsBlockingWorkerLandingMutex.Lock()
+	ret, specificReturn := fake.getBuildsBlockingWorkerLandingReturnsOnCall[len(fake.getBuildsBlockingWorkerLandingArgsForCall)]
+	fake.getBuildsBlockingWorkerLandingArgsForCall = append(fake.getBuildsBlockingWorkerLandingArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetBuildsBlockingWorkerLandingStub
+	fakeReturns := fake.getBuildsBlockingWorkerLandingReturns
+	fake.recordInvocation("GetBuildsBlockingWorkerLanding", []interface{}{arg1, arg2})
+	fake.getBuildsBlockingWorkerLandingMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) GetBuildsBlockingWorkerLandingCallCount() int {
+	fake.getBuildsBlockingWorkerLandingMutex.RLock()
+	defer fake.getBuildsBlockingWorkerLandingMutex.RUnlock()
+	return len(fake.getBuildsBlockingWorkerLandingArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) GetBuildsBlockingWorkerLandingCalls(stub func(context.Context, string) ([]db.BlockingBuild, error)) {
+	fake.getBuildsBlockingWorkerLandingMutex.Lock()
+	defer fake.getBuildsBlockingWorkerLandingMutex.Unlock()
+	fake.GetBuildsBlockingWorkerLandingStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) GetBuildsBlockingWorkerLandingArgsForCall(i int) (context.Context, string) {
+	fake.getBuildsBlockingWorkerLandingMutex.RLock()
+	defer fake.getBuildsBlockingWorkerLandingMutex.RUnlock()
+	argsForCall := fake.getBuildsBlockingWorkerLandingArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorkerLifecycle) GetBuildsBlockingWorkerLandingReturns(result1 []db.BlockingBuild, result2 error) {
+	fake.getBuildsBlockingWorkerLandingMutex.Lock()
+	defer fake.getBuildsBlockingWorkerLandingMutex.Unlock()
+	fake.GetBuildsBlockingWorkerLandingStub = nil
+	fake.getBuildsBlockingWorkerLandingReturns = struct {
+		result1 []db.BlockingBuild
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) GetBuildsBlockingWorkerLandingReturnsOnCall(i int, result1 []db.BlockingBuild, result2 error) {
+	fake.getBuildsBlockingWorkerLandingMutex.Lock()
+	defer fake.getBuildsBlockingWorkerLandingMutex.Unlock()
+	fake.GetBuildsBlockingWorkerLandingStub = nil
+	if fake.getBuildsBlockingWorkerLandingReturnsOnCall == nil {
+		fake.getBuildsBlockingWorkerLandingReturnsOnCall = make(map[int]struct {
+			result1 []db.BlockingBuild
+			result2 error
+		})
+	}
+	fake.getBuildsBlockingWorkerLandingReturnsOnCall[i] = struct {
+		result1 []db.BlockingBuild
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) GetChronicallyStalledWorkers(arg1 context.Context, arg2 int) ([]string, error) {
+	fake.getChronicallyStalledWorkersMutex.Lock()
+	ret, specificReturn := fake.getChronicallyStalledWorkersReturnsOnCall[len(fake.getChronicallyStalledWorkersArgsForCall)]
+	fake.getChronicallyStalledWorkersArgsForCall = append(fake.getChronicallyStalledWorkersArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.GetChronicallyStalledWorkersStub
+	fakeReturns := fake.getChronicallyStalledWorkersReturns
+	fake.recordInvocation("GetChronicallyStalledWorkers", []interface{}{arg1, arg2})
+	fake.getChronicallyStalledWorkersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) GetChronicallyStalledWorkersCallCount() int {
+	fake.getChronicallyStalledWorkersMutex.RLock()
+	defer fake.getChronicallyStalledWorkersMutex.RUnlock()
+	return len(fake.getChronicallyStalledWorkersArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) GetChronicallyStalledWorkersCalls(stub func(context.Context, int) ([]string, error)) {
+	fake.getChronicallyStalledWorkersMutex.Lock()
+	defer fake.getChronicallyStalledWorkersMutex.Unlock()
+	fake.GetChronicallyStalledWorkersStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) GetChronicallyStalledWorkersArgsForCall(i int) (context.Context, int) {
+	fake.getChronicallyStalledWorkersMutex.RLock()
+	defer fake.getChronicallyStalledWorkersMutex.RUnlock()
+	argsForCall := fake.getChronicallyStalledWorkersArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorkerLifecycle) GetChronicallyStalledWorkersReturns(result1 []string, result2 error) {
+	fake.getChronicallyStalledWorkersMutex.Lock()
+	defer fake.getChronicallyStalledWorkersMutex.Unlock()
+	fake.GetChronicallyStalledWorkersStub = nil
+	fake.getChronicallyStalledWorkersReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) GetChronicallyStalledWorkersReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.getChronicallyStalledWorkersMutex.Lock()
+	defer fake.getChronicallyStalledWorkersMutex.Unlock()
+	fake.GetChronicallyStalledWorkersStub = nil
+	if fake.getChronicallyStalledWorkersReturnsOnCall == nil {
+		fake.getChronicallyStalledWorkersReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.getChronicallyStalledWorkersReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) GetDeletableEphemeralWorkers(arg1 context.Context) ([]string, error) {
+	fake.getDeletableEphemeralWorkersMutex.Lock()
+	ret, specificReturn := fake.getDeletableEphemeralWorkersReturnsOnCall[len(fake.getDeletableEphemeralWorkersArgsForCall)]
+	fake.getDeletableEphemeralWorkersArgsForCall = append(fake.getDeletableEphemeralWorkersArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.GetDeletableEphemeralWorkersStub
+	fakeReturns := fake.getDeletableEphemeralWorkersReturns
+	fake.recordInvocation("GetDeletableEphemeralWorkers", []interface{}{arg1})
+	fake.getDeletableEphemeralWorkersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) GetDeletableEphemeralWorkersCallCount() int {
+	fake.getDeletableEphemeralWorkersMutex.RLock()
+	defer fake.getDeletableEphemeralWorkersMutex.RUnlock()
+	return len(fake.getDeletableEphemeralWorkersArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) GetDeletableEphemeralWorkersCalls(stub func(context.Context) ([]string, error)) {
+	fake.getDeletableEphemeralWorkersMutex.Lock()
+	defer fake.getDeletableEphemeralWorkersMutex.Unlock()
+	fake.GetDeletableEphemeralWorkersStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) GetDeletableEphemeralWorkersArgsForCall(i int) context.Context {
+	fake.getDeletableEphemeralWorkersMutex.RLock()
+	defer fake.getDeletableEphemeralWorkersMutex.RUnlock()
+	argsForCall := fake.getDeletableEphemeralWorkersArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) GetDeletableEphemeralWorkersReturns(result1 []string, result2 error) {
+	fake.getDeletableEphemeralWorkersMutex.Lock()
+	defer fake.getDeletableEphemeralWorkersMutex.Unlock()
+	fake.GetDeletableEphemeralWorkersStub = nil
+	fake.getDeletableEphemeralWorkersReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) GetDeletableEphemeralWorkersReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.getDeletableEphemeralWorkersMutex.Lock()
+	defer fake.getDeletableEphemeralWorkersMutex.Unlock()
+	fake.GetDeletableEphemeralWorkersStub = nil
+	if fake.getDeletableEphemeralWorkersReturnsOnCall == nil {
+		fake.getDeletableEphemeralWorkersReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.getDeletableEphemeralWorkersReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) GetPendingContainerDestroysByWorker(arg1 context.Context) (map[string]int, error) {
+	fake.getPendingContainerDestroysByWorkerMutex.Lock()
+	ret, specificReturn := fake.getPendingContainerDestroysByWorkerReturnsOnCall[len(fake.getPendingContainerDestroysByWorkerArgsForCall)]
+	fake.getPendingContainerDestroysByWorkerArgsForCall = append(fake.getPendingContainerDestroysByWorkerArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.GetPendingContainerDestroysByWorkerStub
+	fakeReturns := fake.getPendingContainerDestroysByWorkerReturns
+	fake.recordInvocation("GetPendingContainerDestroysByWorker", []interface{}{arg1})
+	fake.getPendingContainerDestroysByWorkerMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) GetPendingContainerDestroysByWorkerCallCount() int {
+	fake.getPendingContainerDestroysByWorkerMutex.RLock()
+	defer fake.getPendingContainerDestroysByWorkerMutex.RUnlock()
+	return len(fake.getPendingContainerDestroysByWorkerArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) GetPendingContainerDestroysByWorkerCalls(stub func(context.Context) (map[string]int, error)) {
+	fake.getPendingContainerDestroysByWorkerMutex.Lock()
+	defer fake.getPendingContainerDestroysByWorkerMutex.Unlock()
+	fake.GetPendingContainerDestroysByWorkerStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) GetPendingContainerDestroysByWorkerArgsForCall(i int) context.Context {
+	fake.getPendingContainerDestroysByWorkerMutex.RLock()
+	defer fake.getPendingContainerDestroysByWorkerMutex.RUnlock()
+	argsForCall := fake.getPendingContainerDestroysByWorkerArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) GetPendingContainerDestroysByWorkerReturns(result1 map[string]int, result2 error) {
+	fake.getPendingContainerDestroysByWorkerMutex.Lock()
+	defer fake.getPendingContainerDestroysByWorkerMutex.Unlock()
+	fake.GetPendingContainerDestroysByWorkerStub = nil
+	fake.getPendingContainerDestroysByWorkerReturns = struct {
+		result1 map[string]int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) GetPendingContainerDestroysByWorkerReturnsOnCall(i int, result1 map[string]int, result2 error) {
+	fake.getPendingContainerDestroysByWorkerMutex.Lock()
+	defer fake.getPendingContainerDestroysByWorkerMutex.Unlock()
+	fake.GetPendingContainerDestroysByWorkerStub = nil
+	if fake.getPendingContainerDestroysByWorkerReturnsOnCall == nil {
+		fake.getPendingContainerDestroysByWorkerReturnsOnCall = make(map[int]struct {
+			result1 map[string]int
+			result2 error
+		})
+	}
+	fake.getPendingContainerDestroysByWorkerReturnsOnCall[i] = struct {
+		result1 map[string]int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) GetRunningWorkerAddresses(arg1 context.Context) (map[string]string, error) {
+	fake.getRunningWorkerAddressesMutex.Lock()
+	ret, specificReturn := fake.getRunningWorkerAddressesReturnsOnCall[len(fake.getRunningWorkerAddressesArgsForCall)]
+	fake.getRunningWorkerAddressesArgsForCall = append(fake.getRunningWorkerAddressesArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.GetRunningWorkerAddressesStub
+	fakeReturns := fake.getRunningWorkerAddressesReturns
+	fake.recordInvocation("GetRunningWorkerAddresses", []interface{}{arg1})
+	fake.getRunningWorkerAddressesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) GetRunningWorkerAddressesCallCount() int {
+	fake.getRunningWorkerAddressesMutex.RLock()
+	defer fake.getRunningWorkerAddressesMutex.RUnlock()
+	return len(fake.getRunningWorkerAddressesArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) GetRunningWorkerAddressesCalls(stub func(context.Context) (map[string]string, error)) {
+	fake.getRunningWorkerAddressesMutex.Lock()
+	defer fake.getRunningWorkerAddressesMutex.Unlock()
+	fake.GetRunningWorkerAddressesStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) GetRunningWorkerAddressesArgsForCall(i int) context.Context {
+	fake.getRunningWorkerAddressesMutex.RLock()
+	defer fake.getRunningWorkerAddressesMutex.RUnlock()
+	argsForCall := fake.getRunningWorkerAddressesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) GetRunningWorkerAddressesReturns(result1 map[string]string, result2 error) {
+	fake.getRunningWorkerAddressesMutex.Lock()
+	defer fake.getRunningWorkerAddressesMutex.Unlock()
+	fake.GetRunningWorkerAddressesStub = nil
+	fake.getRunningWorkerAddressesReturns = struct {
+		result1 map[string]string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) GetRunningWorkerAddressesReturnsOnCall(i int, result1 map[string]string, result2 error) {
+	fake.getRunningWorkerAddressesMutex.Lock()
+	defer fake.getRunningWorkerAddressesMutex.Unlock()
+	fake.GetRunningWorkerAddressesStub = nil
+	if fake.getRunningWorkerAddressesReturnsOnCall == nil {
+		fake.getRunningWorkerAddressesReturnsOnCall = make(map[int]struct {
+			result1 map[string]string
+			result2 error
+		})
+	}
+	fake.getRunningWorkerAddressesReturnsOnCall[i] = struct {
+		result1 map[string]string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerHeartbeatAges(arg1 context.Context) (map[string]time.Duration, error) {
+	fake.getWorkerHeartbeatAgesMutex.Lock()
+	ret, specificReturn := fake.getWorkerHeartbeatAgesReturnsOnCall[len(fake.getWorkerHeartbeatAgesArgsForCall)]
+	fake.getWorkerHeartbeatAgesArgsForCall = append(fake.getWorkerHeartbeatAgesArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.GetWorkerHeartbeatAgesStub
+	fakeReturns := fake.getWorkerHeartbeatAgesReturns
+	fake.recordInvocation("GetWorkerHeartbeatAges", []interface{}{arg1})
+	fake.getWorkerHeartbeatAgesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerHeartbeatAgesCallCount() int {
+	fake.getWorkerHeartbeatAgesMutex.RLock()
+	defer fake.getWorkerHeartbeatAgesMutex.RUnlock()
+	return len(fake.getWorkerHeartbeatAgesArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerHeartbeatAgesCalls(stub func(context.Context) (map[string]time.Duration, error)) {
+	fake.getWorkerHeartbeatAgesMutex.Lock()
+	defer fake.getWorkerHeartbeatAgesMutex.Unlock()
+	fake.GetWorkerHeartbeatAgesStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerHeartbeatAgesArgsForCall(i int) context.Context {
+	fake.getWorkerHeartbeatAgesMutex.RLock()
+	defer fake.getWorkerHeartbeatAgesMutex.RUnlock()
+	argsForCall := fake.getWorkerHeartbeatAgesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerHeartbeatAgesReturns(result1 map[string]time.Duration, result2 error) {
+	fake.getWorkerHeartbeatAgesMutex.Lock()
+	defer fake.getWorkerHeartbeatAgesMutex.Unlock()
+	fake.GetWorkerHeartbeatAgesStub = nil
+	fake.getWorkerHeartbeatAgesReturns = struct {
+		result1 map[string]time.Duration
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerHeartbeatAgesReturnsOnCall(i int, result1 map[string]time.Duration, result2 error) {
+	fake.getWorkerHeartbeatAgesMutex.Lock()
+	defer fake.getWorkerHeartbeatAgesMutex.Unlock()
+	fake.GetWorkerHeartbeatAgesStub = nil
+	if fake.getWorkerHeartbeatAgesReturnsOnCall == nil {
+		fake.getWorkerHeartbeatAgesReturnsOnCall = make(map[int]struct {
+			result1 map[string]time.Duration
+			result2 error
+		})
+	}
+	fake.getWorkerHeartbeatAgesReturnsOnCall[i] = struct {
+		result1 map[string]time.Duration
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerProcessors(arg1 context.Context) (map[string]string, error) {
+	fake.getWorkerProcessorsMutex.Lock()
+	ret, specificReturn := fake.getWorkerProcessorsReturnsOnCall[len(fake.getWorkerProcessorsArgsForCall)]
+	fake.getWorkerProcessorsArgsForCall = append(fake.getWorkerProcessorsArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.GetWorkerProcessorsStub
+	fakeReturns := fake.getWorkerProcessorsReturns
+	fake.recordInvocation("GetWorkerProcessors", []interface{}{arg1})
+	fake.getWorkerProcessorsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerProcessorsCallCount() int {
+	fake.getWorkerProcessorsMutex.RLock()
+	defer fake.getWorkerProcessorsMutex.RUnlock()
+	return len(fake.getWorkerProcessorsArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerProcessorsCalls(stub func(context.Context) (map[string]string, error)) {
+	fake.getWorkerProcessorsMutex.Lock()
+	defer fake.getWorkerProcessorsMutex.Unlock()
+	fake.GetWorkerProcessorsStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerProcessorsArgsForCall(i int) context.Context {
+	fake.getWorkerProcessorsMutex.RLock()
+	defer fake.getWorkerProcessorsMutex.RUnlock()
+	argsForCall := fake.getWorkerProcessorsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerProcessorsReturns(result1 map[string]string, result2 error) {
+	fake.getWorkerProcessorsMutex.Lock()
+	defer fake.getWorkerProcessorsMutex.Unlock()
+	fake.GetWorkerProcessorsStub = nil
+	fake.getWorkerProcessorsReturns = struct {
+		result1 map[string]string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerProcessorsReturnsOnCall(i int, result1 map[string]string, result2 error) {
+	fake.getWorkerProcessorsMutex.Lock()
+	defer fake.getWorkerProcessorsMutex.Unlock()
+	fake.GetWorkerProcessorsStub = nil
+	if fake.getWorkerProcessorsReturnsOnCall == nil {
+		fake.getWorkerProcessorsReturnsOnCall = make(map[int]struct {
+			result1 map[string]string
+			result2 error
+		})
+	}
+	fake.getWorkerProcessorsReturnsOnCall[i] = struct {
+		result1 map[string]string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerStateByName(arg1 context.Context) (map[string]db.WorkerState, error) {
+	fake.getWorkerStateByNameMutex.Lock()
+	ret, specificReturn := fake.getWorkerStateByNameReturnsOnCall[len(fake.getWorkerStateByNameArgsForCall)]
+	fake.getWorkerStateByNameArgsForCall = append(fake.getWorkerStateByNameArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.GetWorkerStateByNameStub
+	fakeReturns := fake.getWorkerStateByNameReturns
+	fake.recordInvocation("GetWorkerStateByName", []interface{}{arg1})
+	fake.getWorkerStateByNameMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerStateByNameCallCount() int {
+	fake.getWorkerStateByNameMutex.RLock()
+	defer fake.getWorkerStateByNameMutex.RUnlock()
+	return len(fake.getWorkerStateByNameArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerStateByNameCalls(stub func(context.Context) (map[string]db.WorkerState, error)) {
+	fake.getWorkerStateByNameMutex.Lock()
+	defer fake.getWorkerStateByNameMutex.Unlock()
+	fake.GetWorkerStateByNameStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerStateByNameArgsForCall(i int) context.Context {
+	fake.getWorkerStateByNameMutex.RLock()
+	defer fake.getWorkerStateByNameMutex.RUnlock()
+	argsForCall := fake.getWorkerStateByNameArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerStateByNameReturns(result1 map[string]db.WorkerState, result2 error) {
+	fake.getWorkerStateByNameMutex.Lock()
+	defer fake.getWorkerStateByNameMutex.Unlock()
+	fake.GetWorkerStateByNameStub = nil
+	fake.getWorkerStateByNameReturns = struct {
+		result1 map[string]db.WorkerState
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerStateByNameReturnsOnCall(i int, result1 map[string]db.WorkerState, result2 error) {
+	fake.getWorkerStateByNameMutex.Lock()
+	defer fake.getWorkerStateByNameMutex.Unlock()
+	fake.GetWorkerStateByNameStub = nil
+	if fake.getWorkerStateByNameReturnsOnCall == nil {
+		fake.getWorkerStateByNameReturnsOnCall = make(map[int]struct {
+			result1 map[string]db.WorkerState
+			result2 error
+		})
+	}
+	fake.getWorkerStateByNameReturnsOnCall[i] = struct {
+		result1 map[string]db.WorkerState
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerStateByNameForTeam(arg1 context.Context, arg2 int) (map[string]db.WorkerState, error) {
+	fake.getWorkerStateByNameForTeamMutex.Lock()
+	ret, specificReturn := fake.getWorkerStateByNameForTeamReturnsOnCall[len(fake.getWorkerStateByNameForTeamArgsForCall)]
+	fake.getWorkerStateByNameForTeamArgsForCall = append(fake.getWorkerStateByNameForTeamArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.GetWorkerStateByNameForTeamStub
+	fakeReturns := fake.getWorkerStateByNameForTeamReturns
+	fake.recordInvocation("GetWorkerStateByNameForTeam", []interface{}{arg1, arg2})
+	fake.getWorkerStateByNameForTeamMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerStateByNameForTeamCallCount() int {
+	fake.getWorkerStateByNameForTeamMutex.RLock()
+	defer fake.getWorkerStateByNameForTeamMutex.RUnlock()
+	return len(fake.getWorkerStateByNameForTeamArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerStateByNameForTeamCalls(stub func(context.Context, int) (map[string]db.WorkerState, error)) {
+	fake.getWorkerStateByNameForTeamMutex.Lock()
+	defer fake.getWorkerStateByNameForTeamMutex.Unlock()
+	fake.GetWorkerStateByNameForTeamStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerStateByNameForTeamArgsForCall(i int) (context.Context, int) {
+	fake.getWorkerStateByNameForTeamMutex.RLock()
+	defer fake.getWorkerStateByNameForTeamMutex.RUnlock()
+	argsForCall := fake.getWorkerStateByNameForTeamArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerStateByNameForTeamReturns(result1 map[string]db.WorkerState, result2 error) {
+	fake.getWorkerStateByNameForTeamMutex.Lock()
+	defer fake.getWorkerStateByNameForTeamMutex.Unlock()
+	fake.GetWorkerStateByNameForTeamStub = nil
+	fake.getWorkerStateByNameForTeamReturns = struct {
+		result1 map[string]db.WorkerState
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerStateByNameForTeamReturnsOnCall(i int, result1 map[string]db.WorkerState, result2 error) {
+	fake.getWorkerStateByNameForTeamMutex.Lock()
+	defer fake.getWorkerStateByNameForTeamMutex.Unlock()
+	fake.GetWorkerStateByNameForTeamStub = nil
+	if fake.getWorkerStateByNameForTeamReturnsOnCall == nil {
+		fake.getWorkerStateByNameForTeamReturnsOnCall = make(map[int]struct {
+			result1 map[string]db.WorkerState
+			result2 error
+		})
+	}
+	fake.getWorkerStateByNameForTeamReturnsOnCall[i] = struct {
+		result1 map[string]db.WorkerState
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerStateHistory(arg1 context.Context, arg2 string) ([]db.StateTransition, error) {
+	fake.getWorkerStateHistoryMutex.Lock()
+	ret, specificReturn := fake.getWorkerStateHistoryReturnsOnCall[len(fake.getWorkerStateHistoryArgsForCall)]
+	fake.getWorkerStateHistoryArgsForCall = append(fake.getWorkerStateHistoryArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetWorkerStateHistoryStub
+	fakeReturns := fake.getWorkerStateHistoryReturns
+	fake.recordInvocation("GetWorkerStateHistory", []interface{}{arg1, arg2})
+	fake.getWorkerStateHistoryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerStateHistoryCallCount() int {
+	fake.getWorkerStateHistoryMutex.RLock()
+	defer fake.getWorkerStateHistoryMutex.RUnlock()
+	return len(fake.getWorkerStateHistoryArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerStateHistoryCalls(stub func(context.Context, string) ([]db.StateTransition, error)) {
+	fake.getWorkerStateHistoryMutex.Lock()
+	defer fake.getWorkerStateHistoryMutex.Unlock()
+	fake.GetWorkerStateHistoryStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerStateHistoryArgsForCall(i int) (context.Context, string) {
+	fake.getWorkerStateHistoryMutex.RLock()
+	defer fake.getWorkerStateHistoryMutex.RUnlock()
+	argsForCall := fake.getWorkerStateHistoryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerStateHistoryReturns(result1 []db.StateTransition, result2 error) {
+	fake.getWorkerStateHistoryMutex.Lock()
+	defer fake.getWorkerStateHistoryMutex.Unlock()
+	fake.GetWorkerStateHistoryStub = nil
+	fake.getWorkerStateHistoryReturns = struct {
+		result1 []db.StateTransition
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerStateHistoryReturnsOnCall(i int, result1 []db.StateTransition, result2 error) {
+	fake.getWorkerStateHistoryMutex.Lock()
+	defer fake.getWorkerStateHistoryMutex.Unlock()
+	fake.GetWorkerStateHistoryStub = nil
+	if fake.getWorkerStateHistoryReturnsOnCall == nil {
+		fake.getWorkerStateHistoryReturnsOnCall = make(map[int]struct {
+			result1 []db.StateTransition
+			result2 error
+		})
+	}
+	fake.getWorkerStateHistoryReturnsOnCall[i] = struct {
+		result1 []db.StateTransition
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerStatesPaged(arg1 context.Context, arg2 int, arg3 int) (map[string]db.WorkerState, error) {
+	fake.getWorkerStatesPagedMutex.Lock()
+	ret, specificReturn := fake.getWorkerStatesPagedReturnsOnCall[len(fake.getWorkerStatesPagedArgsForCall)]
+	fake.getWorkerStatesPagedArgsForCall = append(fake.getWorkerStatesPagedArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.GetWorkerStatesPagedStub
+	fakeReturns := fake.getWorkerStatesPagedReturns
+	fake.recordInvocation("GetWorkerStatesPaged", []interface{}{arg1, arg2, arg3})
+	fake.getWorkerStatesPagedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerStatesPagedCallCount() int {
+	fake.getWorkerStatesPagedMutex.RLock()
+	defer fake.getWorkerStatesPagedMutex.RUnlock()
+	return len(fake.getWorkerStatesPagedArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerStatesPagedCalls(stub func(context.Context, int, int) (map[string]db.WorkerState, error)) {
+	fake.getWorkerStatesPagedMutex.Lock()
+	defer fake.getWorkerStatesPagedMutex.Unlock()
+	fake.GetWorkerStatesPagedStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerStatesPagedArgsForCall(i int) (context.Context, int, int) {
+	fake.getWorkerStatesPagedMutex.RLock()
+	defer fake.getWorkerStatesPagedMutex.RUnlock()
+	argsForCall := fake.getWorkerStatesPagedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerStatesPagedReturns(result1 map[string]db.WorkerState, result2 error) {
+	fake.getWorkerStatesPagedMutex.Lock()
+	defer fake.getWorkerStatesPagedMutex.Unlock()
+	fake.GetWorkerStatesPagedStub = nil
+	fake.getWorkerStatesPagedReturns = struct {
+		result1 map[string]db.WorkerState
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerStatesPagedReturnsOnCall(i int, result1 map[string]db.WorkerState, result2 error) {
+	fake.getWorkerStatesPagedMutex.Lock()
+	defer fake.getWorkerStatesPagedMutex.Unlock()
+	fake.GetWorkerStatesPagedStub = nil
+	if fake.getWorkerStatesPagedReturnsOnCall == nil {
+		fake.getWorkerStatesPagedReturnsOnCall = make(map[int]struct {
+			result1 map[string]db.WorkerState
+			result2 error
+		})
+	}
+	fake.getWorkerStatesPagedReturnsOnCall[i] = struct {
+		result1 map[string]db.WorkerState
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerStatesWithCapacity(arg1 context.Context) (map[string]db.WorkerCapacity, error) {
+	fake.getWorkerStatesWithCapacityMutex.Lock()
+	ret, specificReturn := fake.getWorkerStatesWithCapacityReturnsOnCall[len(fake.getWorkerStatesWithCapacityArgsForCall)]
+	fake.getWorkerStatesWithCapacityArgsForCall = append(fake.getWorkerStatesWithCapacityArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.GetWorkerStatesWithCapacityStub
+	fakeReturns := fake.getWorkerStatesWithCapacityReturns
+	fake.recordInvocation("GetWorkerStatesWithCapacity", []interface{}{arg1})
+	fake.getWorkerStatesWithCapacityMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerStatesWithCapacityCallCount() int {
+	fake.getWorkerStatesWithCapacityMutex.RLock()
+	defer fake.getWorkerStatesWithCapacityMutex.RUnlock()
+	return len(fake.getWorkerStatesWithCapacityArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerStatesWithCapacityCalls(stub func(context.Context) (map[string]db.WorkerCapacity, error)) {
+	fake.getWorkerStatesWithCapacityMutex.Lock()
+	defer fake.getWorkerStatesWithCapacityMutex.Unlock()
+	fake.GetWorkerStatesWithCapacityStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerStatesWithCapacityArgsForCall(i int) context.Context {
+	fake.getWorkerStatesWithCapacityMutex.RLock()
+	defer fake.getWorkerStatesWithCapacityMutex.RUnlock()
+	argsForCall := fake.getWorkerStatesWithCapacityArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerStatesWithCapacityReturns(result1 map[string]db.WorkerCapacity, result2 error) {
+	fake.getWorkerStatesWithCapacityMutex.Lock()
+	defer fake.getWorkerStatesWithCapacityMutex.Unlock()
+	fake.GetWorkerStatesWithCapacityStub = nil
+	fake.getWorkerStatesWithCapacityReturns = struct {
+		result1 map[string]db.WorkerCapacity
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerStatesWithCapacityReturnsOnCall(i int, result1 map[string]db.WorkerCapacity, result2 error) {
+	fake.getWorkerStatesWithCapacityMutex.Lock()
+	defer fake.getWorkerStatesWithCapacityMutex.Unlock()
+	fake.GetWorkerStatesWithCapacityStub = nil
+	if fake.getWorkerStatesWithCapacityReturnsOnCall == nil {
+		fake.getWorkerStatesWithCapacityReturnsOnCall = make(map[int]struct {
+			result1 map[string]db.WorkerCapacity
+			result2 error
+		})
+	}
+	fake.getWorkerStatesWithCapacityReturnsOnCall[i] = struct {
+		result1 map[string]db.WorkerCapacity
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerStatesWithTags(arg1 context.Context) (map[string]db.WorkerStateTags, error) {
+	fake.getWorkerStatesWithTagsMutex.Lock()
+	ret, specificReturn := fake.getWorkerStatesWithTagsReturnsOnCall[len(fake.getWorkerStatesWithTagsArgsForCall)]
+	fake.getWorkerStatesWithTagsArgsForCall = append(fake.getWorkerStatesWithTagsArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.GetWorkerStatesWithTagsStub
+	fakeReturns := fake.getWorkerStatesWithTagsReturns
+	fake.recordInvocation("GetWorkerStatesWithTags", []interface{}{arg1})
+	fake.getWorkerStatesWithTagsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerStatesWithTagsCallCount() int {
+	fake.getWorkerStatesWithTagsMutex.RLock()
+	defer fake.getWorkerStatesWithTagsMutex.RUnlock()
+	return len(fake.getWorkerStatesWithTagsArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerStatesWithTagsCalls(stub func(context.Context) (map[string]db.WorkerStateTags, error)) {
+	fake.getWorkerStatesWithTagsMutex.Lock()
+	defer fake.getWorkerStatesWithTagsMutex.Unlock()
+	fake.GetWorkerStatesWithTagsStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerStatesWithTagsArgsForCall(i int) context.Context {
+	fake.getWorkerStatesWithTagsMutex.RLock()
+	defer fake.getWorkerStatesWithTagsMutex.RUnlock()
+	argsForCall := fake.getWorkerStatesWithTagsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerStatesWithTagsReturns(result1 map[string]db.WorkerStateTags, result2 error) {
+	fake.getWorkerStatesWithTagsMutex.Lock()
+	defer fake.getWorkerStatesWithTagsMutex.Unlock()
+	fake.GetWorkerStatesWithTagsStub = nil
+	fake.getWorkerStatesWithTagsReturns = struct {
+		result1 map[string]db.WorkerStateTags
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerStatesWithTagsReturnsOnCall(i int, result1 map[string]db.WorkerStateTags, result2 error) {
+	fake.getWorkerStatesWithTagsMutex.Lock()
+	defer fake.getWorkerStatesWithTagsMutex.Unlock()
+	fake.GetWorkerStatesWithTagsStub = nil
+	if fake.getWorkerStatesWithTagsReturnsOnCall == nil {
+		fake.getWorkerStatesWithTagsReturnsOnCall = make(map[int]struct {
+			result1 map[string]db.WorkerStateTags
+			result2 error
+		})
+	}
+	fake.getWorkerStatesWithTagsReturnsOnCall[i] = struct {
+		result1 map[string]db.WorkerStateTags
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerStatesWithTeam(arg1 context.Context) (map[string]db.WorkerStateInfo, error) {
+	fake.getWorkerStatesWithTeamMutex.Lock()
+	ret, specificReturn := fake.getWorkerStatesWithTeamReturnsOnCall[len(fake.getWorkerStatesWithTeamArgsForCall)]
+	fake.getWorkerStatesWithTeamArgsForCall = append(fake.getWorkerStatesWithTeamArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.GetWorkerStatesWithTeamStub
+	fakeReturns := fake.getWorkerStatesWithTeamReturns
+	fake.recordInvocation("GetWorkerStatesWithTeam", []interface{}{arg1})
+	fake.getWorkerStatesWithTeamMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerStatesWithTeamCallCount() int {
+	fake.getWorkerStatesWithTeamMutex.RLock()
+	defer fake.getWorkerStatesWithTeamMutex.RUnlock()
+	return len(fake.getWorkerStatesWithTeamArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerStatesWithTeamCalls(stub func(context.Context) (map[string]db.WorkerStateInfo, error)) {
+	fake.getWorkerStatesWithTeamMutex.Lock()
+	defer fake.getWorkerStatesWithTeamMutex.Unlock()
+	fake.GetWorkerStatesWithTeamStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerStatesWithTeamArgsForCall(i int) context.Context {
+	fake.getWorkerStatesWithTeamMutex.RLock()
+	defer fake.getWorkerStatesWithTeamMutex.RUnlock()
+	argsForCall := fake.getWorkerStatesWithTeamArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerStatesWithTeamReturns(result1 map[string]db.WorkerStateInfo, result2 error) {
+	fake.getWorkerStatesWithTeamMutex.Lock()
+	defer fake.getWorkerStatesWithTeamMutex.Unlock()
+	fake.GetWorkerStatesWithTeamStub = nil
+	fake.getWorkerStatesWithTeamReturns = struct {
+		result1 map[string]db.WorkerStateInfo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerStatesWithTeamReturnsOnCall(i int, result1 map[string]db.WorkerStateInfo, result2 error) {
+	fake.getWorkerStatesWithTeamMutex.Lock()
+	defer fake.getWorkerStatesWithTeamMutex.Unlock()
+	fake.GetWorkerStatesWithTeamStub = nil
+	if fake.getWorkerStatesWithTeamReturnsOnCall == nil {
+		fake.getWorkerStatesWithTeamReturnsOnCall = make(map[int]struct {
+			result1 map[string]db.WorkerStateInfo
+			result2 error
+		})
+	}
+	fake.getWorkerStatesWithTeamReturnsOnCall[i] = struct {
+		result1 map[string]db.WorkerStateInfo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerUptimes(arg1 context.Context) (map[string]time.Duration, error) {
+	fake.getWorkerUptimesMutex.Lock()
+	ret, specificReturn := fake.getWorkerUptimesReturnsOnCall[len(fake.getWorkerUptimesArgsForCall)]
+	fake.getWorkerUptimesArgsForCall = append(fake.getWorkerUptimesArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.GetWorkerUptimesStub
+	fakeReturns := fake.getWorkerUptimesReturns
+	fake.recordInvocation("GetWorkerUptimes", []interface{}{arg1})
+	fake.getWorkerUptimesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerUptimesCallCount() int {
+	fake.getWorkerUptimesMutex.RLock()
+	defer fake.getWorkerUptimesMutex.RUnlock()
+	return len(fake.getWorkerUptimesArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerUptimesCalls(stub func(context.Context) (map[string]time.Duration, error)) {
+	fake.getWorkerUptimesMutex.Lock()
+	defer fake.getWorkerUptimesMutex.Unlock()
+	fake.GetWorkerUptimesStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerUptimesArgsForCall(i int) context.Context {
+	fake.getWorkerUptimesMutex.RLock()
+	defer fake.getWorkerUptimesMutex.RUnlock()
+	argsForCall := fake.getWorkerUptimesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerUptimesReturns(result1 map[string]time.Duration, result2 error) {
+	fake.getWorkerUptimesMutex.Lock()
+	defer fake.getWorkerUptimesMutex.Unlock()
+	fake.GetWorkerUptimesStub = nil
+	fake.getWorkerUptimesReturns = struct {
+		result1 map[string]time.Duration
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkerUptimesReturnsOnCall(i int, result1 map[string]time.Duration, result2 error) {
+	fake.getWorkerUptimesMutex.Lock()
+	defer fake.getWorkerUptimesMutex.Unlock()
+	fake.GetWorkerUptimesStub = nil
+	if fake.getWorkerUptimesReturnsOnCall == nil {
+		fake.getWorkerUptimesReturnsOnCall = make(map[int]struct {
+			result1 map[string]time.Duration
+			result2 error
+		})
+	}
+	fake.getWorkerUptimesReturnsOnCall[i] = struct {
+		result1 map[string]time.Duration
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkersInState(arg1 context.Context, arg2 db.WorkerState) ([]string, error) {
+	fake.getWorkersInStateMutex.Lock()
+	ret, specificReturn := fake.getWorkersInStateReturnsOnCall[len(fake.getWorkersInStateArgsForCall)]
+	fake.getWorkersInStateArgsForCall = append(fake.getWorkersInStateArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.WorkerState
+	}{arg1, arg2})
+	stub := fake.GetWorkersInStateStub
+	fakeReturns := fake.getWorkersInStateReturns
+	fake.recordInvocation("GetWorkersInState", []interface{}{arg1, arg2})
+	fake.getWorkersInStateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkersInStateCallCount() int {
+	fake.getWorkersInStateMutex.RLock()
+	defer fake.getWorkersInStateMutex.RUnlock()
+	return len(fake.getWorkersInStateArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkersInStateCalls(stub func(context.Context, db.WorkerState) ([]string, error)) {
+	fake.getWorkersInStateMutex.Lock()
+	defer fake.getWorkersInStateMutex.Unlock()
+	fake.GetWorkersInStateStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkersInStateArgsForCall(i int) (context.Context, db.WorkerState) {
+	fake.getWorkersInStateMutex.RLock()
+	defer fake.getWorkersInStateMutex.RUnlock()
+	argsForCall := fake.getWorkersInStateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkersInStateReturns(result1 []string, result2 error) {
+	fake.getWorkersInStateMutex.Lock()
+	defer fake.getWorkersInStateMutex.Unlock()
+	fake.GetWorkersInStateStub = nil
+	fake.getWorkersInStateReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) GetWorkersInStateReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.getWorkersInStateMutex.Lock()
+	defer fake.getWorkersInStateMutex.Unlock()
+	fake.GetWorkersInStateStub = nil
+	if fake.getWorkersInStateReturnsOnCall == nil {
+		fake.getWorkersInStateReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.getWorkersInStateReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) HeartbeatWorker(arg1 context.Context, arg2 string, arg3 time.Duration) (bool, error) {
+	fake.heartbeatWorkerMutex.Lock()
+	ret, specificReturn := fake.heartbeatWorkerReturnsOnCall[len(fake.heartbeatWorkerArgsForCall)]
+	fake.heartbeatWorkerArgsForCall = append(fake.heartbeatWorkerArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 time.Duration
+	}{arg1, arg2, arg3})
+	stub := fake.HeartbeatWorkerStub
+	fakeReturns := fake.heartbeatWorkerReturns
+	fake.recordInvocation("HeartbeatWorker", []interface{}{arg1, arg2, arg3})
+	fake.heartbeatWorkerMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) HeartbeatWorkerCallCount() int {
+	fake.heartbeatWorkerMutex.RLock()
+	defer fake.heartbeatWorkerMutex.RUnlock()
+	return len(fake.heartbeatWorkerArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) HeartbeatWorkerCalls(stub func(context.Context, string, time.Duration) (bool, error)) {
+	fake.heartbeatWorkerMutex.Lock()
+	defer fake.heartbeatWorkerMutex.Unlock()
+	fake.HeartbeatWorkerStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) HeartbeatWorkerArgsForCall(i int) (context.Context, string, time.Duration) {
+	fake.heartbeatWorkerMutex.RLock()
+	defer fake.heartbeatWorkerMutex.RUnlock()
+	argsForCall := fake.heartbeatWorkerArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeWorkerLifecycle) HeartbeatWorkerReturns(result1 bool, result2 error) {
+	fake.heartbeatWorkerMutex.Lock()
+	defer fake.heartbeatWorkerMutex.Unlock()
+	fake.HeartbeatWorkerStub = nil
+	fake.heartbeatWorkerReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) HeartbeatWorkerReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.heartbeatWorkerMutex.Lock()
+	defer fake.heartbeatWorkerMutex.Unlock()
+	fake.HeartbeatWorkerStub = nil
+	if fake.heartbeatWorkerReturnsOnCall == nil {
+		fake.heartbeatWorkerReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.heartbeatWorkerReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) HeartbeatWorkers(arg1 context.Context, arg2 []string, arg3 time.Duration) ([]string, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.heartbeatWorkersMutex.Lock()
+	ret, specificReturn := fake.heartbeatWorkersReturnsOnCall[len(fake.heartbeatWorkersArgsForCall)]
+	fake.heartbeatWorkersArgsForCall = append(fake.heartbeatWorkersArgsForCall, struct {
+		arg1 context.Context
+		arg2 []string
+		arg3 time.Duration
+	}{arg1, arg2Copy, arg3})
+	stub := fake.HeartbeatWorkersStub
+	fakeReturns := fake.heartbeatWorkersReturns
+	fake.recordInvocation("HeartbeatWorkers", []interface{}{arg1, arg2Copy, arg3})
+	fake.heartbeatWorkersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) HeartbeatWorkersCallCount() int {
+	fake.heartbeatWorkersMutex.RLock()
+	defer fake.heartbeatWorkersMutex.RUnlock()
+	return len(fake.heartbeatWorkersArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) HeartbeatWorkersCalls(stub func(context.Context, []string, time.Duration) ([]string, error)) {
+	fake.heartbeatWorkersMutex.Lock()
+	defer fake.heartbeatWorkersMutex.Unlock()
+	fake.HeartbeatWorkersStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) HeartbeatWorkersArgsForCall(i int) (context.Context, []string, time.Duration) {
+	fake.heartbeatWorkersMutex.RLock()
+	defer fake.heartbeatWorkersMutex.RUnlock()
+	argsForCall := fake.heartbeatWorkersArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeWorkerLifecycle) HeartbeatWorkersReturns(result1 []string, result2 error) {
+	fake.heartbeatWorkersMutex.Lock()
+	defer fake.heartbeatWorkersMutex.Unlock()
+	fake.HeartbeatWorkersStub = nil
+	fake.heartbeatWorkersReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) HeartbeatWorkersReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.heartbeatWorkersMutex.Lock()
+	defer fake.heartbeatWorkersMutex.Unlock()
+	fake.HeartbeatWorkersStub = nil
+	if fake.heartbeatWorkersReturnsOnCall == nil {
+		fake.heartbeatWorkersReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.heartbeatWorkersReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) LandAllWorkers(arg1 context.Context) ([]string, error) {
+	fake.landAllWorkersMutex.Lock()
+	ret, specificReturn := fake.landAllWorkersReturnsOnCall[len(fake.landAllWorkersArgsForCall)]
+	fake.landAllWorkersArgsForCall = append(fake.landAllWorkersArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.LandAllWorkersStub
+	fakeReturns := fake.landAllWorkersReturns
+	fake.recordInvocation("LandAllWorkers", []interface{}{arg1})
+	fake.landAllWorkersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) LandAllWorkersCallCount() int {
+	fake.landAllWorkersMutex.RLock()
+	defer fake.landAllWorkersMutex.RUnlock()
+	return len(fake.landAllWorkersArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) LandAllWorkersCalls(stub func(context.Context) ([]string, error)) {
+	fake.landAllWorkersMutex.Lock()
+	defer fake.landAllWorkersMutex.Unlock()
+	fake.LandAllWorkersStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) LandAllWorkersArgsForCall(i int) context.Context {
+	fake.landAllWorkersMutex.RLock()
+	defer fake.landAllWorkersMutex.RUnlock()
+	argsForCall := fake.landAllWorkersArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) LandAllWorkersReturns(result1 []string, result2 error) {
+	fake.landAllWorkersMutex.Lock()
+	defer fake.landAllWorkersMutex.Unlock()
+	fake.LandAllWorkersStub = nil
+	fake.landAllWorkersReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) LandAllWorkersReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.landAllWorkersMutex.Lock()
+	defer fake.landAllWorkersMutex.Unlock()
+	fake.LandAllWorkersStub = nil
+	if fake.landAllWorkersReturnsOnCall == nil {
+		fake.landAllWorkersReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.landAllWorkersReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkers(arg1 context.Context) ([]string, error) {
+	fake.landFinishedLandingWorkersMutex.Lock()
+	ret, specificReturn := fake.landFinishedLandingWorkersReturnsOnCall[len(fake.landFinishedLandingWorkersArgsForCall)]
+	fake.landFinishedLandingWorkersArgsForCall = append(fake.landFinishedLandingWorkersArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.LandFinishedLandingWorkersStub
+	fakeReturns := fake.landFinishedLandingWorkersReturns
+	fake.recordInvocation("LandFinishedLandingWorkers", []interface{}{arg1})
+	fake.landFinishedLandingWorkersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersCallCount() int {
+	fake.landFinishedLandingWorkersMutex.RLock()
+	defer fake.landFinishedLandingWorkersMutex.RUnlock()
+	return len(fake.landFinishedLandingWorkersArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersCalls(stub func(context.Context) ([]string, error)) {
+	fake.landFinishedLandingWorkersMutex.Lock()
+	defer fake.landFinishedLandingWorkersMutex.Unlock()
+	fake.LandFinishedLandingWorkersStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersArgsForCall(i int) context.Context {
+	fake.landFinishedLandingWorkersMutex.RLock()
+	defer fake.landFinishedLandingWorkersMutex.RUnlock()
+	argsForCall := fake.landFinishedLandingWorkersArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersReturns(result1 []string, result2 error) {
+	fake.landFinishedLandingWorkersMutex.Lock()
+	defer fake.landFinishedLandingWorkersMutex.Unlock()
+	fake.LandFinishedLandingWorkersStub = nil
+	fake.landFinishedLandingWorkersReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.landFinishedLandingWorkersMutex.Lock()
+	defer fake.landFinishedLandingWorkersMutex.Unlock()
+	fake.LandFinishedLandingWorkersStub = nil
+	if fake.landFinishedLandingWorkersReturnsOnCall == nil {
+		fake.landFinishedLandingWorkersReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.landFinishedLandingWorkersReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersCount(arg1 context.Context) (int, error) {
+	fake.landFinishedLandingWorkersCountMutex.Lock()
+	ret, specificReturn := fake.landFinishedLandingWorkersCountReturnsOnCall[len(fake.landFinishedLandingWorkersCountArgsForCall)]
+	fake.landFinishedLandingWorkersCountArgsForCall = append(fake.landFinishedLandingWorkersCountArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.LandFinishedLandingWorkersCountStub
+	fakeReturns := fake.landFinishedLandingWorkersCountReturns
+	fake.recordInvocation("LandFinishedLandingWorkersCount", []interface{}{arg1})
+	fake.landFinishedLandingWorkersCountMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersCountCallCount() int {
+	fake.landFinishedLandingWorkersCountMutex.RLock()
+	defer fake.landFinishedLandingWorkersCountMutex.RUnlock()
+	return len(fake.landFinishedLandingWorkersCountArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersCountCalls(stub func(context.Context) (int, error)) {
+	fake.landFinishedLandingWorkersCountMutex.Lock()
+	defer fake.landFinishedLandingWorkersCountMutex.Unlock()
+	fake.LandFinishedLandingWorkersCountStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersCountArgsForCall(i int) context.Context {
+	fake.landFinishedLandingWorkersCountMutex.RLock()
+	defer fake.landFinishedLandingWorkersCountMutex.RUnlock()
+	argsForCall := fake.landFinishedLandingWorkersCountArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersCountReturns(result1 int, result2 error) {
+	fake.landFinishedLandingWorkersCountMutex.Lock()
+	defer fake.landFinishedLandingWorkersCountMutex.Unlock()
+	fake.LandFinishedLandingWorkersCountStub = nil
+	fake.landFinishedLandingWorkersCountReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersCountReturnsOnCall(i int, result1 int, result2 error) {
+	fake.landFinishedLandingWorkersCountMutex.Lock()
+	defer fake.landFinishedLandingWorkersCountMutex.Unlock()
+	fake.LandFinishedLandingWorkersCountStub = nil
+	if fake.landFinishedLandingWorkersCountReturnsOnCall == nil {
+		fake.landFinishedLandingWorkersCountReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.landFinishedLandingWorkersCountReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersDetailed(arg1 context.Context) ([]db.WorkerAffected, error) {
+	fake.landFinishedLandingWorkersDetailedMutex.Lock()
+	ret, specificReturn := fake.landFinishedLandingWorkersDetailedReturnsOnCall[len(fake.landFinishedLandingWorkersDetailedArgsForCall)]
+	fake.landFinishedLandingWorkersDetailedArgsForCall = append(fake.landFinishedLandingWorkersDetailedArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.LandFinishedLandingWorkersDetailedStub
+	fakeReturns := fake.landFinishedLandingWorkersDetailedReturns
+	fake.recordInvocation("LandFinishedLandingWorkersDetailed", []interface{}{arg1})
+	fake.landFinishedLandingWorkersDetailedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersDetailedCallCount() int {
+	fake.landFinishedLandingWorkersDetailedMutex.RLock()
+	defer fake.landFinishedLandingWorkersDetailedMutex.RUnlock()
+	return len(fake.landFinishedLandingWorkersDetailedArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersDetailedCalls(stub func(context.Context) ([]db.WorkerAffected, error)) {
+	fake.landFinishedLandingWorkersDetailedMutex.Lock()
+	defer fake.landFinishedLandingWorkersDetailedMutex.Unlock()
+	fake.LandFinishedLandingWorkersDetailedStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersDetailedArgsForCall(i int) context.Context {
+	fake.landFinishedLandingWorkersDetailedMutex.RLock()
+	defer fake.landFinishedLandingWorkersDetailedMutex.RUnlock()
+	argsForCall := fake.landFinishedLandingWorkersDetailedArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersDetailedReturns(result1 []db.WorkerAffected, result2 error) {
+	fake.landFinishedLandingWorkersDetailedMutex.Lock()
+	defer fake.landFinishedLandingWorkersDetailedMutex.Unlock()
+	fake.LandFinishedLandingWorkersDetailedStub = nil
+	fake.landFinishedLandingWorkersDetailedReturns = struct {
+		result1 []db.WorkerAffected
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersDetailedReturnsOnCall(i int, result1 []db.WorkerAffected, result2 error) {
+	fake.landFinishedLandingWorkersDetailedMutex.Lock()
+	defer fake.landFinishedLandingWorkersDetailedMutex.Unlock()
+	fake.LandFinishedLandingWorkersDetailedStub = nil
+	if fake.landFinishedLandingWorkersDetailedReturnsOnCall == nil {
+		fake.landFinishedLandingWorkersDetailedReturnsOnCall = make(map[int]struct {
+			result1 []db.WorkerAffected
+			result2 error
+		})
+	}
+	fake.landFinishedLandingWorkersDetailedReturnsOnCall[i] = struct {
+		result1 []db.WorkerAffected
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersForPlatform(arg1 context.Context, arg2 string) ([]string, error) {
+	fake.landFinishedLandingWorkersForPlatformMutex.Lock()
+	ret, specificReturn := fake.landFinishedLandingWorkersForPlatformReturnsOnCall[len(fake.landFinishedLandingWorkersForPlatformArgsForCall)]
+	fake.landFinishedLandingWorkersForPlatformArgsForCall = append(fake.landFinishedLandingWorkersForPlatformArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.LandFinishedLandingWorkersForPlatformStub
+	fakeReturns := fake.landFinishedLandingWorkersForPlatformReturns
+	fake.recordInvocation("LandFinishedLandingWorkersForPlatform", []interface{}{arg1, arg2})
+	fake.landFinishedLandingWorkersForPlatformMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersForPlatformCallCount() int {
+	fake.landFinishedLandingWorkersForPlatformMutex.RLock()
+	defer fake.landFinishedLandingWorkersForPlatformMutex.RUnlock()
+	return len(fake.landFinishedLandingWorkersForPlatformArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersForPlatformCalls(stub func(context.Context, string) ([]string, error)) {
+	fake.landFinishedLandingWorkersForPlatformMutex.Lock()
+	defer fake.landFinishedLandingWorkersForPlatformMutex.Unlock()
+	fake.LandFinishedLandingWorkersForPlatformStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersForPlatformArgsForCall(i int) (context.Context, string) {
+	fake.landFinishedLandingWorkersForPlatformMutex.RLock()
+	defer fake.landFinishedLandingWorkersForPlatformMutex.RUnlock()
+	argsForCall := fake.landFinishedLandingWorkersForPlatformArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersForPlatformReturns(result1 []string, result2 error) {
+	fake.landFinishedLandingWorkersForPlatformMutex.Lock()
+	defer fake.landFinishedLandingWorkersForPlatformMutex.Unlock()
+	fake.LandFinishedLandingWorkersForPlatformStub = nil
+	fake.landFinishedLandingWorkersForPlatformReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersForPlatformReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.landFinishedLandingWorkersForPlatformMutex.Lock()
+	defer fake.landFinishedLandingWorkersForPlatformMutex.Unlock()
+	fake.LandFinishedLandingWorkersForPlatformStub = nil
+	if fake.landFinishedLandingWorkersForPlatformReturnsOnCall == nil {
+		fake.landFinishedLandingWorkersForPlatformReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.landFinishedLandingWorkersForPlatformReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersForWorkers(arg1 context.Context, arg2 []string) ([]string, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.landFinishedLandingWorkersForWorkersMutex.Lock()
+	ret, specificReturn := fake.landFinishedLandingWorkersForWorkersReturnsOnCall[len(fake.landFinishedLandingWorkersForWorkersArgsForCall)]
+	fake.landFinishedLandingWorkersForWorkersArgsForCall = append(fake.landFinishedLandingWorkersForWorkersArgsForCall, struct {
+		arg1 context.Context
+		arg2 []string
+	}{arg1, arg2Copy})
+	stub := fake.LandFinishedLandingWorkersForWorkersStub
+	fakeReturns := fake.landFinishedLandingWorkersForWorkersReturns
+	fake.recordInvocation("LandFinishedLandingWorkersForWorkers", []interface{}{arg1, arg2Copy})
+	fake.landFinishedLandingWorkersForWorkersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersForWorkersCallCount() int {
+	fake.landFinishedLandingWorkersForWorkersMutex.RLock()
+	defer fake.landFinishedLandingWorkersForWorkersMutex.RUnlock()
+	return len(fake.landFinishedLandingWorkersForWorkersArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersForWorkersCalls(stub func(context.Context, []string) ([]string, error)) {
+	fake.landFinishedLandingWorkersForWorkersMutex.Lock()
+	defer fake.landFinishedLandingWorkersForWorkersMutex.Unlock()
+	fake.LandFinishedLandingWorkersForWorkersStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersForWorkersArgsForCall(i int) (context.Context, []string) {
+	fake.landFinishedLandingWorkersForWorkersMutex.RLock()
+	defer fake.landFinishedLandingWorkersForWorkersMutex.RUnlock()
+	argsForCall := fake.landFinishedLandingWorkersForWorkersArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersForWorkersReturns(result1 []string, result2 error) {
+	fake.landFinishedLandingWorkersForWorkersMutex.Lock()
+	defer fake.landFinishedLandingWorkersForWorkersMutex.Unlock()
+	fake.LandFinishedLandingWorkersForWorkersStub = nil
+	fake.landFinishedLandingWorkersForWorkersReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersForWorkersReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.landFinishedLandingWorkersForWorkersMutex.Lock()
+	defer fake.landFinishedLandingWorkersForWorkersMutex.Unlock()
+	fake.LandFinishedLandingWorkersForWorkersStub = nil
+	if fake.landFinishedLandingWorkersForWorkersReturnsOnCall == nil {
+		fake.landFinishedLandingWorkersForWorkersReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.landFinishedLandingWorkersForWorkersReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersKeepMinimum(arg1 context.Context, arg2 int) ([]string, error) {
+	fake.landFinishedLandingWorkersKeepMinimumMutex.Lock()
+	ret, specificReturn := fake.landFinishedLandingWorkersKeepMinimumReturnsOnCall[len(fake.landFinishedLandingWorkersKeepMinimumArgsForCall)]
+	fake.landFinishedLandingWorkersKeepMinimumArgsForCall = append(fake.landFinishedLandingWorkersKeepMinimumArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.LandFinishedLandingWorkersKeepMinimumStub
+	fakeReturns := fake.landFinishedLandingWorkersKeepMinimumReturns
+	fake.recordInvocation("LandFinishedLandingWorkersKeepMinimum", []interface{}{arg1, arg2})
+	fake.landFinishedLandingWorkersKeepMinimumMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersKeepMinimumCallCount() int {
+	fake.landFinishedLandingWorkersKeepMinimumMutex.RLock()
+	defer fake.landFinishedLandingWorkersKeepMinimumMutex.RUnlock()
+	return len(fake.landFinishedLandingWorkersKeepMinimumArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersKeepMinimumCalls(stub func(context.Context, int) ([]string, error)) {
+	fake.landFinishedLandingWorkersKeepMinimumMutex.Lock()
+	defer fake.landFinishedLandingWorkersKeepMinimumMutex.Unlock()
+	fake.LandFinishedLandingWorkersKeepMinimumStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersKeepMinimumArgsForCall(i int) (context.Context, int) {
+	fake.landFinishedLandingWorkersKeepMinimumMutex.RLock()
+	defer fake.landFinishedLandingWorkersKeepMinimumMutex.RUnlock()
+	argsForCall := fake.landFinishedLandingWorkersKeepMinimumArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersKeepMinimumReturns(result1 []string, result2 error) {
+	fake.landFinishedLandingWorkersKeepMinimumMutex.Lock()
+	defer fake.landFinishedLandingWorkersKeepMinimumMutex.Unlock()
+	fake.LandFinishedLandingWorkersKeepMinimumStub = nil
+	fake.landFinishedLandingWorkersKeepMinimumReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersKeepMinimumReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.landFinishedLandingWorkersKeepMinimumMutex.Lock()
+	defer fake.landFinishedLandingWorkersKeepMinimumMutex.Unlock()
+	fake.LandFinishedLandingWorkersKeepMinimumStub = nil
+	if fake.landFinishedLandingWorkersKeepMinimumReturnsOnCall == nil {
+		fake.landFinishedLandingWorkersKeepMinimumReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.landFinishedLandingWorkersKeepMinimumReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersSQL() (string, []any, error) {
+	fake.landFinishedLandingWorkersSQLMutex.Lock()
+	ret, specificReturn := fake.landFinishedLandingWorkersSQLReturnsOnCall[len(fake.landFinishedLandingWorkersSQLArgsForCall)]
+	fake.landFinishedLandingWorkersSQLArgsForCall = append(fake.landFinishedLandingWorkersSQLArgsForCall, struct {
+	}{})
+	stub := fake.LandFinishedLandingWorkersSQLStub
+	fakeReturns := fake.landFinishedLandingWorkersSQLReturns
+	fake.recordInvocation("LandFinishedLandingWorkersSQL", []interface{}{})
+	fake.landFinishedLandingWorkersSQLMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersSQLCallCount() int {
+	fake.landFinishedLandingWorkersSQLMutex.RLock()
+	defer fake.landFinishedLandingWorkersSQLMutex.RUnlock()
+	return len(fake.landFinishedLandingWorkersSQLArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersSQLCalls(stub func() (string, []any, error)) {
+	fake.landFinishedLandingWorkersSQLMutex.Lock()
+	defer fake.landFinishedLandingWorkersSQLMutex.Unlock()
+	fake.LandFinishedLandingWorkersSQLStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersSQLReturns(result1 string, result2 []any, result3 error) {
+	fake.landFinishedLandingWorkersSQLMutex.Lock()
+	defer fake.landFinishedLandingWorkersSQLMutex.Unlock()
+	fake.LandFinishedLandingWorkersSQLStub = nil
+	fake.landFinishedLandingWorkersSQLReturns = struct {
+		result1 string
+		result2 []any
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersSQLReturnsOnCall(i int, result1 string, result2 []any, result3 error) {
+	fake.landFinishedLandingWorkersSQLMutex.Lock()
+	defer fake.landFinishedLandingWorkersSQLMutex.Unlock()
+	fake.LandFinishedLandingWorkersSQLStub = nil
+	if fake.landFinishedLandingWorkersSQLReturnsOnCall == nil {
+		fake.landFinishedLandingWorkersSQLReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 []any
+			result3 error
+		})
+	}
+	fake.landFinishedLandingWorkersSQLReturnsOnCall[i] = struct {
+		result1 string
+		result2 []any
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersWithDuration(arg1 context.Context) ([]db.LandedWorker, error) {
+	fake.landFinishedLandingWorkersWithDurationMutex.Lock()
+	ret, specificReturn := fake.landFinishedLandingWorkersWithDurationReturnsOnCall[len(fake.landFinishedLandingWorkersWithDurationArgsForCall)]
+	fake.landFinishedLandingWorkersWithDurationArgsForCall = append(fake.landFinishedLandingWorkersWithDurationArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.LandFinishedLandingWorkersWithDurationStub
+	fakeReturns := fake.landFinishedLandingWorkersWithDurationReturns
+	fake.recordInvocation("LandFinishedLandingWorkersWithDuration", []interface{}{arg1})
+	fake.landFinishedLandingWorkersWithDurationMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersWithDurationCallCount() int {
+	fake.landFinishedLandingWorkersWithDurationMutex.RLock()
+	defer fake.landFinishedLandingWorkersWithDurationMutex.RUnlock()
+	return len(fake.landFinishedLandingWorkersWithDurationArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersWithDurationCalls(stub func(context.Context) ([]db.LandedWorker, error)) {
+	fake.landFinishedLandingWorkersWithDurationMutex.Lock()
+	defer fake.landFinishedLandingWorkersWithDurationMutex.Unlock()
+	fake.LandFinishedLandingWorkersWithDurationStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersWithDurationArgsForCall(i int) context.Context {
+	fake.landFinishedLandingWorkersWithDurationMutex.RLock()
+	defer fake.landFinishedLandingWorkersWithDurationMutex.RUnlock()
+	argsForCall := fake.landFinishedLandingWorkersWithDurationArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersWithDurationReturns(result1 []db.LandedWorker, result2 error) {
+	fake.landFinishedLandingWorkersWithDurationMutex.Lock()
+	defer fake.landFinishedLandingWorkersWithDurationMutex.Unlock()
+	fake.LandFinishedLandingWorkersWithDurationStub = nil
+	fake.landFinishedLandingWorkersWithDurationReturns = struct {
+		result1 []db.LandedWorker
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersWithDurationReturnsOnCall(i int, result1 []db.LandedWorker, result2 error) {
+	fake.landFinishedLandingWorkersWithDurationMutex.Lock()
+	defer fake.landFinishedLandingWorkersWithDurationMutex.Unlock()
+	fake.LandFinishedLandingWorkersWithDurationStub = nil
+	if fake.landFinishedLandingWorkersWithDurationReturnsOnCall == nil {
+		fake.landFinishedLandingWorkersWithDurationReturnsOnCall = make(map[int]struct {
+			result1 []db.LandedWorker
+			result2 error
+		})
+	}
+	fake.landFinishedLandingWorkersWithDurationReturnsOnCall[i] = struct {
+		result1 []db.LandedWorker
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) LandUnreachableWorkers(arg1 context.Context, arg2 time.Duration) ([]string, error) {
+	fake.landUnreachableWorkersMutex.Lock()
+	ret, specificReturn := fake.landUnreachableWorkersReturnsOnCall[len(fake.landUnreachableWorkersArgsForCall)]
+	fake.landUnreachableWorkersArgsForCall = append(fake.landUnreachableWorkersArgsForCall, struct {
+		arg1 context.Context
+		arg2 time.Duration
+	}{arg1, arg2})
+	stub := fake.LandUnreachableWorkersStub
+	fakeReturns := fake.landUnreachableWorkersReturns
+	fake.recordInvocation("LandUnreachableWorkers", []interface{}{arg1, arg2})
+	fake.landUnreachableWorkersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) LandUnreachableWorkersCallCount() int {
+	fake.landUnreachableWorkersMutex.RLock()
+	defer fake.landUnreachableWorkersMutex.RUnlock()
+	return len(fake.landUnreachableWorkersArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) LandUnreachableWorkersCalls(stub func(context.Context, time.Duration) ([]string, error)) {
+	fake.landUnreachableWorkersMutex.Lock()
+	defer fake.landUnreachableWorkersMutex.Unlock()
+	fake.LandUnreachableWorkersStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) LandUnreachableWorkersArgsForCall(i int) (context.Context, time.Duration) {
+	fake.landUnreachableWorkersMutex.RLock()
+	defer fake.landUnreachableWorkersMutex.RUnlock()
+	argsForCall := fake.landUnreachableWorkersArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorkerLifecycle) LandUnreachableWorkersReturns(result1 []string, result2 error) {
+	fake.landUnreachableWorkersMutex.Lock()
+	defer fake.landUnreachableWorkersMutex.Unlock()
+	fake.LandUnreachableWorkersStub = nil
+	fake.landUnreachableWorkersReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) LandUnreachableWorkersReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.landUnreachableWorkersMutex.Lock()
+	defer fake.landUnreachableWorkersMutex.Unlock()
+	fake.LandUnreachableWorkersStub = nil
+	if fake.landUnreachableWorkersReturnsOnCall == nil {
+		fake.landUnreachableWorkersReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.landUnreachableWorkersReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) LandWorkersInterruptingBuilds(arg1 context.Context) ([]string, error) {
+	fake.landWorkersInterruptingBuildsMutex.Lock()
+	ret, specificReturn := fake.landWorkersInterruptingBuildsReturnsOnCall[len(fake.landWorkersInterruptingBuildsArgsForCall)]
+	fake.landWorkersInterruptingBuildsArgsForCall = append(fake.landWorkersInterruptingBuildsArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.LandWorkersInterruptingBuildsStub
+	fakeReturns := fake.landWorkersInterruptingBuildsReturns
+	fake.recordInvocation("LandWorkersInterruptingBuilds", []interface{}{arg1})
+	fake.landWorkersInterruptingBuildsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) LandWorkersInterruptingBuildsCallCount() int {
+	fake.landWorkersInterruptingBuildsMutex.RLock()
+	defer fake.landWorkersInterruptingBuildsMutex.RUnlock()
+	return len(fake.landWorkersInterruptingBuildsArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) LandWorkersInterruptingBuildsCalls(stub func(context.Context) ([]string, error)) {
+	fake.landWorkersInterruptingBuildsMutex.Lock()
+	defer fake.landWorkersInterruptingBuildsMutex.Unlock()
+	fake.LandWorkersInterruptingBuildsStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) LandWorkersInterruptingBuildsArgsForCall(i int) context.Context {
+	fake.landWorkersInterruptingBuildsMutex.RLock()
+	defer fake.landWorkersInterruptingBuildsMutex.RUnlock()
+	argsForCall := fake.landWorkersInterruptingBuildsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) LandWorkersInterruptingBuildsReturns(result1 []string, result2 error) {
+	fake.landWorkersInterruptingBuildsMutex.Lock()
+	defer fake.landWorkersInterruptingBuildsMutex.Unlock()
+	fake.LandWorkersInterruptingBuildsStub = nil
+	fake.landWorkersInterruptingBuildsReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) LandWorkersInterruptingBuildsReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.landWorkersInterruptingBuildsMutex.Lock()
+	defer fake.landWorkersInterruptingBuildsMutex.Unlock()
+	fake.LandWorkersInterruptingBuildsStub = nil
+	if fake.landWorkersInterruptingBuildsReturnsOnCall == nil {
+		fake.landWorkersInterruptingBuildsReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.landWorkersInterruptingBuildsReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) LandWorkersWithTag(arg1 context.Context, arg2 string) ([]string, error) {
+	fake.landWorkersWithTagMutex.Lock()
+	ret, specificReturn := fake.landWorkersWithTagReturnsOnCall[len(fake.landWorkersWithTagArgsForCall)]
+	fake.landWorkersWithTagArgsForCall = append(fake.landWorkersWithTagArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.LandWorkersWithTagStub
+	fakeReturns := fake.landWorkersWithTagReturns
+	fake.recordInvocation("LandWorkersWithTag", []interface{}{arg1, arg2})
+	fake.landWorkersWithTagMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) LandWorkersWithTagCallCount() int {
+	fake.landWorkersWithTagMutex.RLock()
+	defer fake.landWorkersWithTagMutex.RUnlock()
+	return len(fake.landWorkersWithTagArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) LandWorkersWithTagCalls(stub func(context.Context, string) ([]string, error)) {
+	fake.landWorkersWithTagMutex.Lock()
+	defer fake.landWorkersWithTagMutex.Unlock()
+	fake.LandWorkersWithTagStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) LandWorkersWithTagArgsForCall(i int) (context.Context, string) {
+	fake.landWorkersWithTagMutex.RLock()
+	defer fake.landWorkersWithTagMutex.RUnlock()
+	argsForCall := fake.landWorkersWithTagArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorkerLifecycle) LandWorkersWithTagReturns(result1 []string, result2 error) {
+	fake.landWorkersWithTagMutex.Lock()
+	defer fake.landWorkersWithTagMutex.Unlock()
+	fake.LandWorkersWithTagStub = nil
+	fake.landWorkersWithTagReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) LandWorkersWithTagReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.landWorkersWithTagMutex.Lock()
+	defer fake.landWorkersWithTagMutex.Unlock()
+	fake.LandWorkersWithTagStub = nil
+	if fake.landWorkersWithTagReturnsOnCall == nil {
+		fake.landWorkersWithTagReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.landWorkersWithTagReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) LifecycleStats() db.LifecycleCounters {
+	fake.lifecycleStatsMutex.Lock()
+	ret, specificReturn := fake.lifecycleStatsReturnsOnCall[len(fake.lifecycleStatsArgsForCall)]
+	fake.lifecycleStatsArgsForCall = append(fake.lifecycleStatsArgsForCall, struct {
+	}{})
+	stub := fake.LifecycleStatsStub
+	fakeReturns := fake.lifecycleStatsReturns
+	fake.recordInvocation("LifecycleStats", []interface{}{})
+	fake.lifecycleStatsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorkerLifecycle) LifecycleStatsCallCount() int {
+	fake.lifecycleStatsMutex.RLock()
+	defer fake.lifecycleStatsMutex.RUnlock()
+	return len(fake.lifecycleStatsArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) LifecycleStatsCalls(stub func() db.LifecycleCounters) {
+	fake.lifecycleStatsMutex.Lock()
+	defer fake.lifecycleStatsMutex.Unlock()
+	fake.LifecycleStatsStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) LifecycleStatsReturns(result1 db.LifecycleCounters) {
+	fake.lifecycleStatsMutex.Lock()
+	defer fake.lifecycleStatsMutex.Unlock()
+	fake.LifecycleStatsStub = nil
+	fake.lifecycleStatsReturns = struct {
+		result1 db.LifecycleCounters
+	}{result1}
+}
+
+func (fake *FakeWorkerLifecycle) LifecycleStatsReturnsOnCall(i int, result1 db.LifecycleCounters) {
+	fake.lifecycleStatsMutex.Lock()
+	defer fake.lifecycleStatsMutex.Unlock()
+	fake.LifecycleStatsStub = nil
+	if fake.lifecycleStatsReturnsOnCall == nil {
+		fake.lifecycleStatsReturnsOnCall = make(map[int]struct {
+			result1 db.LifecycleCounters
+		})
+	}
+	fake.lifecycleStatsReturnsOnCall[i] = struct {
+		result1 db.LifecycleCounters
+	}{result1}
+}
+
+func (fake *FakeWorkerLifecycle) MarkBaggageclaimHealth(arg1 context.Context, arg2 string, arg3 bool) error {
+	fake.markBaggageclaimHealthMutex.Lock()
+	ret, specificReturn := fake.markBaggageclaimHealthReturnsOnCall[len(fake.markBaggageclaimHealthArgsForCall)]
+	fake.markBaggageclaimHealthArgsForCall = append(fake.markBaggageclaimHealthArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 bool
+	}{arg1, arg2, arg3})
+	stub := fake.MarkBaggageclaimHealthStub
+	fakeReturns := fake.markBaggageclaimHealthReturns
+	fake.recordInvocation("MarkBaggageclaimHealth", []interface{}{arg1, arg2, arg3})
+	fake.markBaggageclaimHealthMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorkerLifecycle) MarkBaggageclaimHealthCallCount() int {
+	fake.markBaggageclaimHealthMutex.RLock()
+	defer fake.markBaggageclaimHealthMutex.RUnlock()
+	return len(fake.markBaggageclaimHealthArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) MarkBaggageclaimHealthCalls(stub func(context.Context, string, bool) error) {
+	fake.markBaggageclaimHealthMutex.Lock()
+	defer fake.markBaggageclaimHealthMutex.Unlock()
+	fake.MarkBaggageclaimHealthStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) MarkBaggageclaimHealthArgsForCall(i int) (context.Context, string, bool) {
+	fake.markBaggageclaimHealthMutex.RLock()
+	defer fake.markBaggageclaimHealthMutex.RUnlock()
+	argsForCall := fake.markBaggageclaimHealthArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeWorkerLifecycle) MarkBaggageclaimHealthReturns(result1 error) {
+	fake.markBaggageclaimHealthMutex.Lock()
+	defer fake.markBaggageclaimHealthMutex.Unlock()
+	fake.MarkBaggageclaimHealthStub = nil
+	fake.markBaggageclaimHealthReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorkerLifecycle) MarkBaggageclaimHealthReturnsOnCall(i int, result1 error) {
+	fake.markBaggageclaimHealthMutex.Lock()
+	defer fake.markBaggageclaimHealthMutex.Unlock()
+	fake.MarkBaggageclaimHealthStub = nil
+	if fake.markBaggageclaimHealthReturnsOnCall == nil {
+		fake.markBaggageclaimHealthReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.markBaggageclaimHealthReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorkerLifecycle) MarkWorkerForUpgrade(arg1 context.Context, arg2 string) error {
+	fake.markWorkerForUpgradeMutex.Lock()
+	ret, specificReturn := fake.markWorkerForUpgradeReturnsOnCall[len(fake.markWorkerForUpgradeArgsForCall)]
+	fake.markWorkerForUpgradeArgsForCall = append(fake.markWorkerForUpgradeArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.MarkWorkerForUpgradeStub
+	fakeReturns := fake.markWorkerForUpgradeReturns
+	fake.recordInvocation("MarkWorkerForUpgrade", []interface{}{arg1, arg2})
+	fake.markWorkerForUpgradeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorkerLifecycle) MarkWorkerForUpgradeCallCount() int {
+	fake.markWorkerForUpgradeMutex.RLock()
+	defer fake.markWorkerForUpgradeMutex.RUnlock()
+	return len(fake.markWorkerForUpgradeArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) MarkWorkerForUpgradeCalls(stub func(context.Context, string) error) {
+	fake.markWorkerForUpgradeMutex.Lock()
+	defer fake.markWorkerForUpgradeMutex.Unlock()
+	fake.MarkWorkerForUpgradeStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) MarkWorkerForUpgradeArgsForCall(i int) (context.Context, string) {
+	fake.markWorkerForUpgradeMutex.RLock()
+	defer fake.markWorkerForUpgradeMutex.RUnlock()
+	argsForCall := fake.markWorkerForUpgradeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorkerLifecycle) MarkWorkerForUpgradeReturns(result1 error) {
+	fake.markWorkerForUpgradeMutex.Lock()
+	defer fake.markWorkerForUpgradeMutex.Unlock()
+	fake.MarkWorkerForUpgradeStub = nil
+	fake.markWorkerForUpgradeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorkerLifecycle) MarkWorkerForUpgradeReturnsOnCall(i int, result1 error) {
+	fake.markWorkerForUpgradeMutex.Lock()
+	defer fake.markWorkerForUpgradeMutex.Unlock()
+	fake.MarkWorkerForUpgradeStub = nil
+	if fake.markWorkerForUpgradeReturnsOnCall == nil {
+		fake.markWorkerForUpgradeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.markWorkerForUpgradeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorkerLifecycle) OldestExpiredWorkerAge(arg1 context.Context) (time.Duration, bool, error) {
+	fake.oldestExpiredWorkerAgeMutex.Lock()
+	ret, specificReturn := fake.oldestExpiredWorkerAgeReturnsOnCall[len(fake.oldestExpiredWorkerAgeArgsForCall)]
+	fake.oldestExpiredWorkerAgeArgsForCall = append(fake.oldestExpiredWorkerAgeArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.OldestExpiredWorkerAgeStub
+	fakeReturns := fake.oldestExpiredWorkerAgeReturns
+	fake.recordInvocation("OldestExpiredWorkerAge", []interface{}{arg1})
+	fake.oldestExpiredWorkerAgeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeWorkerLifecycle) OldestExpiredWorkerAgeCallCount() int {
+	fake.oldestExpiredWorkerAgeMutex.RLock()
+	defer fake.oldestExpiredWorkerAgeMutex.RUnlock()
+	return len(fake.oldestExpiredWorkerAgeArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) OldestExpiredWorkerAgeCalls(stub func(context.Context) (time.Duration, bool, error)) {
+	fake.oldestExpiredWorkerAgeMutex.Lock()
+	defer fake.oldestExpiredWorkerAgeMutex.Unlock()
+	fake.OldestExpiredWorkerAgeStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) OldestExpiredWorkerAgeArgsForCall(i int) context.Context {
+	fake.oldestExpiredWorkerAgeMutex.RLock()
+	defer fake.oldestExpiredWorkerAgeMutex.RUnlock()
+	argsForCall := fake.oldestExpiredWorkerAgeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) OldestExpiredWorkerAgeReturns(result1 time.Duration, result2 bool, result3 error) {
+	fake.oldestExpiredWorkerAgeMutex.Lock()
+	defer fake.oldestExpiredWorkerAgeMutex.Unlock()
+	fake.OldestExpiredWorkerAgeStub = nil
+	fake.oldestExpiredWorkerAgeReturns = struct {
+		result1 time.Duration
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeWorkerLifecycle) OldestExpiredWorkerAgeReturnsOnCall(i int, result1 time.Duration, result2 bool, result3 error) {
+	fake.oldestExpiredWorkerAgeMutex.Lock()
+	defer fake.oldestExpiredWorkerAgeMutex.Unlock()
+	fake.OldestExpiredWorkerAgeStub = nil
+	if fake.oldestExpiredWorkerAgeReturnsOnCall == nil {
+		fake.oldestExpiredWorkerAgeReturnsOnCall = make(map[int]struct {
+			result1 time.Duration
+			result2 bool
+			result3 error
+		})
+	}
+	fake.oldestExpiredWorkerAgeReturnsOnCall[i] = struct {
+		result1 time.Duration
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeWorkerLifecycle) OrphanContainersForWorker(arg1 context.Context, arg2 string) (int, error) {
+	fake.orphanContainersForWorkerMutex.Lock()
+	ret, specificReturn := fake.orphanContainersForWorkerReturnsOnCall[len(fake.orphanContainersForWorkerArgsForCall)]
+	fake.orphanContainersForWorkerArgsForCall = append(fake.orphanContainersForWorkerArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.OrphanContainersForWorkerStub
+	fakeReturns := fake.orphanContainersForWorkerReturns
+	fake.recordInvocation("OrphanContainersForWorker", []interface{}{arg1, arg2})
+	fake.orphanContainersForWorkerMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) OrphanContainersForWorkerCallCount() int {
+	fake.orphanContainersForWorkerMutex.RLock()
+	defer fake.orphanContainersForWorkerMutex.RUnlock()
+	return len(fake.orphanContainersForWorkerArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) OrphanContainersForWorkerCalls(stub func(context.Context, string) (int, error)) {
+	fake.orphanContainersForWorkerMutex.Lock()
+	defer fake.orphanContainersForWorkerMutex.Unlock()
+	fake.OrphanContainersForWorkerStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) OrphanContainersForWorkerArgsForCall(i int) (context.Context, string) {
+	fake.orphanContainersForWorkerMutex.RLock()
+	defer fake.orphanContainersForWorkerMutex.RUnlock()
+	argsForCall := fake.orphanContainersForWorkerArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorkerLifecycle) OrphanContainersForWorkerReturns(result1 int, result2 error) {
+	fake.orphanContainersForWorkerMutex.Lock()
+	defer fake.orphanContainersForWorkerMutex.Unlock()
+	fake.OrphanContainersForWorkerStub = nil
+	fake.orphanContainersForWorkerReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) OrphanContainersForWorkerReturnsOnCall(i int, result1 int, result2 error) {
+	fake.orphanContainersForWorkerMutex.Lock()
+	defer fake.orphanContainersForWorkerMutex.Unlock()
+	fake.OrphanContainersForWorkerStub = nil
+	if fake.orphanContainersForWorkerReturnsOnCall == nil {
+		fake.orphanContainersForWorkerReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.orphanContainersForWorkerReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) ProcessFinishedWorkers(arg1 context.Context) ([]string, []string, error) {
+	fake.processFinishedWorkersMutex.Lock()
+	ret, specificReturn := fake.processFinishedWorkersReturnsOnCall[len(fake.processFinishedWorkersArgsForCall)]
+	fake.processFinishedWorkersArgsForCall = append(fake.processFinishedWorkersArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.ProcessFinishedWorkersStub
+	fakeReturns := fake.processFinishedWorkersReturns
+	fake.recordInvocation("ProcessFinishedWorkers", []interface{}{arg1})
+	fake.processFinishedWorkersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeWorkerLifecycle) ProcessFinishedWorkersCallCount() int {
+	fake.processFinishedWorkersMutex.RLock()
+	defer fake.processFinishedWorkersMutex.RUnlock()
+	return len(fake.processFinishedWorkersArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) ProcessFinishedWorkersCalls(stub func(context.Context) ([]string, []string, error)) {
+	fake.processFinishedWorkersMutex.Lock()
+	defer fake.processFinishedWorkersMutex.Unlock()
+	fake.ProcessFinishedWorkersStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) ProcessFinishedWorkersArgsForCall(i int) context.Context {
+	fake.processFinishedWorkersMutex.RLock()
+	defer fake.processFinishedWorkersMutex.RUnlock()
+	argsForCall := fake.processFinishedWorkersArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) ProcessFinishedWorkersReturns(result1 []string, result2 []string, result3 error) {
+	fake.processFinishedWorkersMutex.Lock()
+	defer fake.processFinishedWorkersMutex.Unlock()
+	fake.ProcessFinishedWorkersStub = nil
+	fake.processFinishedWorkersReturns = struct {
+		result1 []string
+		result2 []string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeWorkerLifecycle) ProcessFinishedWorkersReturnsOnCall(i int, result1 []string, result2 []string, result3 error) {
+	fake.processFinishedWorkersMutex.Lock()
+	defer fake.processFinishedWorkersMutex.Unlock()
+	fake.ProcessFinishedWorkersStub = nil
+	if fake.processFinishedWorkersReturnsOnCall == nil {
+		fake.processFinishedWorkersReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 []string
+			result3 error
+		})
+	}
+	fake.processFinishedWorkersReturnsOnCall[i] = struct {
+		result1 []string
+		result2 []string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeWorkerLifecycle) PruneWorkerStateHistory(arg1 context.Context, arg2 time.Duration) (int, error) {
+	fake.pruneWorkerStateHistoryMutex.Lock()
+	ret, specificReturn := fake.pruneWorkerStateHistoryReturnsOnCall[len(fake.pruneWorkerStateHistoryArgsForCall)]
+	fake.pruneWorkerStateHistoryArgsForCall = append(fake.pruneWorkerStateHistoryArgsForCall, struct {
+		arg1 context.Context
+		arg2 time.Duration
+	}{arg1, arg2})
+	stub := fake.PruneWorkerStateHistoryStub
+	fakeReturns := fake.pruneWorkerStateHistoryReturns
+	fake.recordInvocation("PruneWorkerStateHistory", []interface{}{arg1, arg2})
+	fake.pruneWorkerStateHistoryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) PruneWorkerStateHistoryCallCount() int {
+	fake.pruneWorkerStateHistoryMutex.RLock()
+	defer fake.pruneWorkerStateHistoryMutex.RUnlock()
+	return len(fake.pruneWorkerStateHistoryArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) PruneWorkerStateHistoryCalls(stub func(context.Context, time.Duration) (int, error)) {
+	fake.pruneWorkerStateHistoryMutex.Lock()
+	defer fake.pruneWorkerStateHistoryMutex.Unlock()
+	fake.PruneWorkerStateHistoryStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) PruneWorkerStateHistoryArgsForCall(i int) (context.Context, time.Duration) {
+	fake.pruneWorkerStateHistoryMutex.RLock()
+	defer fake.pruneWorkerStateHistoryMutex.RUnlock()
+	argsForCall := fake.pruneWorkerStateHistoryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorkerLifecycle) PruneWorkerStateHistoryReturns(result1 int, result2 error) {
+	fake.pruneWorkerStateHistoryMutex.Lock()
+	defer fake.pruneWorkerStateHistoryMutex.Unlock()
+	fake.PruneWorkerStateHistoryStub = nil
+	fake.pruneWorkerStateHistoryReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) PruneWorkerStateHistoryReturnsOnCall(i int, result1 int, result2 error) {
+	fake.pruneWorkerStateHistoryMutex.Lock()
+	defer fake.pruneWorkerStateHistoryMutex.Unlock()
+	fake.PruneWorkerStateHistoryStub = nil
+	if fake.pruneWorkerStateHistoryReturnsOnCall == nil {
+		fake.pruneWorkerStateHistoryReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.pruneWorkerStateHistoryReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) PurgeDeletedWorkers(arg1 context.Context, arg2 time.Duration) ([]string, error) {
+	fake.purgeDeletedWorkersMutex.Lock()
+	ret, specificReturn := fake.purgeDeletedWorkersReturnsOnCall[len(fake.purgeDeletedWorkersArgsForCall)]
+	fake.purgeDeletedWorkersArgsForCall = append(fake.purgeDeletedWorkersArgsForCall, struct {
+		arg1 context.Context
+		arg2 time.Duration
+	}{arg1, arg2})
+	stub := fake.PurgeDeletedWorkersStub
+	fakeReturns := fake.purgeDeletedWorkersReturns
+	fake.recordInvocation("PurgeDeletedWorkers", []interface{}{arg1, arg2})
+	fake.purgeDeletedWorkersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) PurgeDeletedWorkersCallCount() int {
+	fake.purgeDeletedWorkersMutex.RLock()
+	defer fake.purgeDeletedWorkersMutex.RUnlock()
+	return len(fake.purgeDeletedWorkersArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) PurgeDeletedWorkersCalls(stub func(context.Context, time.Duration) ([]string, error)) {
+	fake.purgeDeletedWorkersMutex.Lock()
+	defer fake.purgeDeletedWorkersMutex.Unlock()
+	fake.PurgeDeletedWorkersStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) PurgeDeletedWorkersArgsForCall(i int) (context.Context, time.Duration) {
+	fake.purgeDeletedWorkersMutex.RLock()
+	defer fake.purgeDeletedWorkersMutex.RUnlock()
+	argsForCall := fake.purgeDeletedWorkersArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorkerLifecycle) PurgeDeletedWorkersReturns(result1 []string, result2 error) {
+	fake.purgeDeletedWorkersMutex.Lock()
+	defer fake.purgeDeletedWorkersMutex.Unlock()
+	fake.PurgeDeletedWorkersStub = nil
+	fake.purgeDeletedWorkersReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) PurgeDeletedWorkersReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.purgeDeletedWorkersMutex.Lock()
+	defer fake.purgeDeletedWorkersMutex.Unlock()
+	fake.PurgeDeletedWorkersStub = nil
+	if fake.purgeDeletedWorkersReturnsOnCall == nil {
+		fake.purgeDeletedWorkersReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.purgeDeletedWorkersReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) QuarantineWorker(arg1 context.Context, arg2 string) error {
+	fake.quarantineWorkerMutex.Lock()
+	ret, specificReturn := fake.quarantineWorkerReturnsOnCall[len(fake.quarantineWorkerArgsForCall)]
+	fake.quarantineWorkerArgsForCall = append(fake.quarantineWorkerArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.QuarantineWorkerStub
+	fakeReturns := fake.quarantineWorkerReturns
+	fake.recordInvocation("QuarantineWorker", []interface{}{arg1, arg2})
+	fake.quarantineWorkerMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorkerLifecycle) QuarantineWorkerCallCount() int {
+	fake.quarantineWorkerMutex.RLock()
+	defer fake.quarantineWorkerMutex.RUnlock()
+	return len(fake.quarantineWorkerArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) QuarantineWorkerCalls(stub func(context.Context, string) error) {
+	fake.quarantineWorkerMutex.Lock()
+	defer fake.quarantineWorkerMutex.Unlock()
+	fake.QuarantineWorkerStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) QuarantineWorkerArgsForCall(i int) (context.Context, string) {
+	fake.quarantineWorkerMutex.RLock()
+	defer fake.quarantineWorkerMutex.RUnlock()
+	argsForCall := fake.quarantineWorkerArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorkerLifecycle) QuarantineWorkerReturns(result1 error) {
+	fake.quarantineWorkerMutex.Lock()
+	defer fake.quarantineWorkerMutex.Unlock()
+	fake.QuarantineWorkerStub = nil
+	fake.quarantineWorkerReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorkerLifecycle) QuarantineWorkerReturnsOnCall(i int, result1 error) {
+	fake.quarantineWorkerMutex.Lock()
+	defer fake.quarantineWorkerMutex.Unlock()
+	fake.QuarantineWorkerStub = nil
+	if fake.quarantineWorkerReturnsOnCall == nil {
+		fake.quarantineWorkerReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.quarantineWorkerReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorkerLifecycle) ReconcileWorkers(arg1 context.Context, arg2 []string) ([]string, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.reconcileWorkersMutex.Lock()
+	ret, specificReturn := fake.reconcileWorkersReturnsOnCall[len(fake.reconcileWorkersArgsForCall)]
+	fake.reconcileWorkersArgsForCall = append(fake.reconcileWorkersArgsForCall, struct {
+		arg1 context.Context
+		arg2 []string
+	}{arg1, arg2Copy})
+	stub := fake.ReconcileWorkersStub
+	fakeReturns := fake.reconcileWorkersReturns
+	fake.recordInvocation("ReconcileWorkers", []interface{}{arg1, arg2Copy})
+	fake.reconcileWorkersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) ReconcileWorkersCallCount() int {
+	fake.reconcileWorkersMutex.RLock()
+	defer fake.reconcileWorkersMutex.RUnlock()
+	return len(fake.reconcileWorkersArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) ReconcileWorkersCalls(stub func(context.Context, []string) ([]string, error)) {
+	fake.reconcileWorkersMutex.Lock()
+	defer fake.reconcileWorkersMutex.Unlock()
+	fake.ReconcileWorkersStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) ReconcileWorkersArgsForCall(i int) (context.Context, []string) {
+	fake.reconcileWorkersMutex.RLock()
+	defer fake.reconcileWorkersMutex.RUnlock()
+	argsForCall := fake.reconcileWorkersArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorkerLifecycle) ReconcileWorkersReturns(result1 []string, result2 error) {
+	fake.reconcileWorkersMutex.Lock()
+	defer fake.reconcileWorkersMutex.Unlock()
+	fake.ReconcileWorkersStub = nil
+	fake.reconcileWorkersReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) ReconcileWorkersReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.reconcileWorkersMutex.Lock()
+	defer fake.reconcileWorkersMutex.Unlock()
+	fake.ReconcileWorkersStub = nil
+	if fake.reconcileWorkersReturnsOnCall == nil {
+		fake.reconcileWorkersReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.reconcileWorkersReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) RestoreUpgradedWorkers(arg1 context.Context, arg2 []string) ([]string, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.restoreUpgradedWorkersMutex.Lock()
+	ret, specificReturn := fake.restoreUpgradedWorkersReturnsOnCall[len(fake.restoreUpgradedWorkersArgsForCall)]
+	fake.restoreUpgradedWorkersArgsForCall = append(fake.restoreUpgradedWorkersArgsForCall, struct {
+		arg1 context.Context
+		arg2 []string
+	}{arg1, arg2Copy})
+	stub := fake.RestoreUpgradedWorkersStub
+	fakeReturns := fake.restoreUpgradedWorkersReturns
+	fake.recordInvocation("RestoreUpgradedWorkers", []interface{}{arg1, arg2Copy})
+	fake.restoreUpgradedWorkersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) RestoreUpgradedWorkersCallCount() int {
+	fake.restoreUpgradedWorkersMutex.RLock()
+	defer fake.restoreUpgradedWorkersMutex.RUnlock()
+	return len(fake.restoreUpgradedWorkersArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) RestoreUpgradedWorkersCalls(stub func(context.Context, []string) ([]string, error)) {
+	fake.restoreUpgradedWorkersMutex.Lock()
+	defer fake.restoreUpgradedWorkersMutex.Unlock()
+	fake.RestoreUpgradedWorkersStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) RestoreUpgradedWorkersArgsForCall(i int) (context.Context, []string) {
+	fake.restoreUpgradedWorkersMutex.RLock()
+	defer fake.restoreUpgradedWorkersMutex.RUnlock()
+	argsForCall := fake.restoreUpgradedWorkersArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorkerLifecycle) RestoreUpgradedWorkersReturns(result1 []string, result2 error) {
+	fake.restoreUpgradedWorkersMutex.Lock()
+	defer fake.restoreUpgradedWorkersMutex.Unlock()
+	fake.RestoreUpgradedWorkersStub = nil
+	fake.restoreUpgradedWorkersReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) RestoreUpgradedWorkersReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.restoreUpgradedWorkersMutex.Lock()
+	defer fake.restoreUpgradedWorkersMutex.Unlock()
+	fake.RestoreUpgradedWorkersStub = nil
+	if fake.restoreUpgradedWorkersReturnsOnCall == nil {
+		fake.restoreUpgradedWorkersReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.restoreUpgradedWorkersReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) ResurrectWorker(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 time.Duration) error {
+	fake.resurrectWorkerMutex.Lock()
+	ret, specificReturn := fake.resurrectWorkerReturnsOnCall[len(fake.resurrectWorkerArgsForCall)]
+	fake.resurrectWorkerArgsForCall = append(fake.resurrectWorkerArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 time.Duration
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.ResurrectWorkerStub
+	fakeReturns := fake.resurrectWorkerReturns
+	fake.recordInvocation("ResurrectWorker", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.resurrectWorkerMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorkerLifecycle) ResurrectWorkerCallCount() int {
+	fake.resurrectWorkerMutex.RLock()
+	defer fake.resurrectWorkerMutex.RUnlock()
+	return len(fake.resurrectWorkerArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) ResurrectWorkerCalls(stub func(context.Context, string, string, string, time.Duration) error) {
+	fake.resurrectWorkerMutex.Lock()
+	defer fake.resurrectWorkerMutex.Unlock()
+	fake.ResurrectWorkerStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) ResurrectWorkerArgsForCall(i int) (context.Context, string, string, string, time.Duration) {
+	fake.resurrectWorkerMutex.RLock()
+	defer fake.resurrectWorkerMutex.RUnlock()
+	argsForCall := fake.resurrectWorkerArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeWorkerLifecycle) ResurrectWorkerReturns(result1 error) {
+	fake.resurrectWorkerMutex.Lock()
+	defer fake.resurrectWorkerMutex.Unlock()
+	fake.ResurrectWorkerStub = nil
+	fake.resurrectWorkerReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorkerLifecycle) ResurrectWorkerReturnsOnCall(i int, result1 error) {
+	fake.resurrectWorkerMutex.Lock()
+	defer fake.resurrectWorkerMutex.Unlock()
+	fake.ResurrectWorkerStub = nil
+	if fake.resurrectWorkerReturnsOnCall == nil {
+		fake.resurrectWorkerReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.resurrectWorkerReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorkerLifecycle) SetWorkerStates(arg1 context.Context, arg2 []string, arg3 db.WorkerState) ([]string, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.setWorkerStatesMutex.Lock()
+	ret, specificReturn := fake.setWorkerStatesReturnsOnCall[len(fake.setWorkerStatesArgsForCall)]
+	fake.setWorkerStatesArgsForCall = append(fake.setWorkerStatesArgsForCall, struct {
+		arg1 context.Context
+		arg2 []string
+		arg3 db.WorkerState
+	}{arg1, arg2Copy, arg3})
+	stub := fake.SetWorkerStatesStub
+	fakeReturns := fake.setWorkerStatesReturns
+	fake.recordInvocation("SetWorkerStates", []interface{}{arg1, arg2Copy, arg3})
+	fake.setWorkerStatesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) SetWorkerStatesCallCount() int {
+	fake.setWorkerStatesMutex.RLock()
+	defer fake.setWorkerStatesMutex.RUnlock()
+	return len(fake.setWorkerStatesArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) SetWorkerStatesCalls(stub func(context.Context, []string, db.WorkerState) ([]string, error)) {
+	fake.setWorkerStatesMutex.Lock()
+	defer fake.setWorkerStatesMutex.Unlock()
+	fake.SetWorkerStatesStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) SetWorkerStatesArgsForCall(i int) (context.Context, []string, db.WorkerState) {
+	fake.setWorkerStatesMutex.RLock()
+	defer fake.setWorkerStatesMutex.RUnlock()
+	argsForCall := fake.setWorkerStatesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeWorkerLifecycle) SetWorkerStatesReturns(result1 []string, result2 error) {
+	fake.setWorkerStatesMutex.Lock()
+	defer fake.setWorkerStatesMutex.Unlock()
+	fake.SetWorkerStatesStub = nil
+	fake.setWorkerStatesReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) SetWorkerStatesReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.setWorkerStatesMutex.Lock()
+	defer fake.setWorkerStatesMutex.Unlock()
+	fake.SetWorkerStatesStub = nil
+	if fake.setWorkerStatesReturnsOnCall == nil {
+		fake.setWorkerStatesReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.setWorkerStatesReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkers(arg1 context.Context) ([]string, error) {
+	fake.stallUnresponsiveWorkersMutex.Lock()
+	ret, specificReturn := fake.stallUnresponsiveWorkersReturnsOnCall[len(fake.stallUnresponsiveWorkersArgsForCall)]
+	fake.stallUnresponsiveWorkersArgsForCall = append(fake.stallUnresponsiveWorkersArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.StallUnresponsiveWorkersStub
+	fakeReturns := fake.stallUnresponsiveWorkersReturns
+	fake.recordInvocation("StallUnresponsiveWorkers", []interface{}{arg1})
+	fake.stallUnresponsiveWorkersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersCallCount() int {
+	fake.stallUnresponsiveWorkersMutex.RLock()
+	defer fake.stallUnresponsiveWorkersMutex.RUnlock()
+	return len(fake.stallUnresponsiveWorkersArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersCalls(stub func(context.Context) ([]string, error)) {
+	fake.stallUnresponsiveWorkersMutex.Lock()
+	defer fake.stallUnresponsiveWorkersMutex.Unlock()
+	fake.StallUnresponsiveWorkersStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersArgsForCall(i int) context.Context {
+	fake.stallUnresponsiveWorkersMutex.RLock()
+	defer fake.stallUnresponsiveWorkersMutex.RUnlock()
+	argsForCall := fake.stallUnresponsiveWorkersArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersReturns(result1 []string, result2 error) {
+	fake.stallUnresponsiveWorkersMutex.Lock()
+	defer fake.stallUnresponsiveWorkersMutex.Unlock()
+	fake.StallUnresponsiveWorkersStub = nil
+	fake.stallUnresponsiveWorkersReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.stallUnresponsiveWorkersMutex.Lock()
+	defer fake.stallUnresponsiveWorkersMutex.Unlock()
+	fake.StallUnresponsiveWorkersStub = nil
+	if fake.stallUnresponsiveWorkersReturnsOnCall == nil {
+		fake.stallUnresponsiveWorkersReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.stallUnresponsiveWorkersReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersCount(arg1 context.Context) (int, error) {
+	fake.stallUnresponsiveWorkersCountMutex.Lock()
+	ret, specificReturn := fake.stallUnresponsiveWorkersCountReturnsOnCall[len(fake.stallUnresponsiveWorkersCountArgsForCall)]
+	fake.stallUnresponsiveWorkersCountArgsForCall = append(fake.stallUnresponsiveWorkersCountArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.StallUnresponsiveWorkersCountStub
+	fakeReturns := fake.stallUnresponsiveWorkersCountReturns
+	fake.recordInvocation("StallUnresponsiveWorkersCount", []interface{}{arg1})
+	fake.stallUnresponsiveWorkersCountMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersCountCallCount() int {
+	fake.stallUnresponsiveWorkersCountMutex.RLock()
+	defer fake.stallUnresponsiveWorkersCountMutex.RUnlock()
+	return len(fake.stallUnresponsiveWorkersCountArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersCountCalls(stub func(context.Context) (int, error)) {
+	fake.stallUnresponsiveWorkersCountMutex.Lock()
+	defer fake.stallUnresponsiveWorkersCountMutex.Unlock()
+	fake.StallUnresponsiveWorkersCountStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersCountArgsForCall(i int) context.Context {
+	fake.stallUnresponsiveWorkersCountMutex.RLock()
+	defer fake.stallUnresponsiveWorkersCountMutex.RUnlock()
+	argsForCall := fake.stallUnresponsiveWorkersCountArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersCountReturns(result1 int, result2 error) {
+	fake.stallUnresponsiveWorkersCountMutex.Lock()
+	defer fake.stallUnresponsiveWorkersCountMutex.Unlock()
+	fake.StallUnresponsiveWorkersCountStub = nil
+	fake.stallUnresponsiveWorkersCountReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersCountReturnsOnCall(i int, result1 int, result2 error) {
+	fake.stallUnresponsiveWorkersCountMutex.Lock()
+	defer fake.stallUnresponsiveWorkersCountMutex.Unlock()
+	fake.StallUnresponsiveWorkersCountStub = nil
+	if fake.stallUnresponsiveWorkersCountReturnsOnCall == nil {
+		fake.stallUnresponsiveWorkersCountReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.stallUnresponsiveWorkersCountReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersDetailed(arg1 context.Context) ([]db.WorkerAffected, error) {
+	fake.stallUnresponsiveWorkersDetailedMutex.Lock()
+	ret, specificReturn := fake.stallUnresponsiveWorkersDetailedReturnsOnCall[len(fake.stallUnresponsiveWorkersDetailedArgsForCall)]
+	fake.stallUnresponsiveWorkersDetailedArgsForCall = append(fake.stallUnresponsiveWorkersDetailedArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.StallUnresponsiveWorkersDetailedStub
+	fakeReturns := fake.stallUnresponsiveWorkersDetailedReturns
+	fake.recordInvocation("StallUnresponsiveWorkersDetailed", []interface{}{arg1})
+	fake.stallUnresponsiveWorkersDetailedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersDetailedCallCount() int {
+	fake.stallUnresponsiveWorkersDetailedMutex.RLock()
+	defer fake.stallUnresponsiveWorkersDetailedMutex.RUnlock()
+	return len(fake.stallUnresponsiveWorkersDetailedArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersDetailedCalls(stub func(context.Context) ([]db.WorkerAffected, error)) {
+	fake.stallUnresponsiveWorkersDetailedMutex.Lock()
+	defer fake.stallUnresponsiveWorkersDetailedMutex.Unlock()
+	fake.StallUnresponsiveWorkersDetailedStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersDetailedArgsForCall(i int) context.Context {
+	fake.stallUnresponsiveWorkersDetailedMutex.RLock()
+	defer fake.stallUnresponsiveWorkersDetailedMutex.RUnlock()
+	argsForCall := fake.stallUnresponsiveWorkersDetailedArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersDetailedReturns(result1 []db.WorkerAffected, result2 error) {
+	fake.stallUnresponsiveWorkersDetailedMutex.Lock()
+	defer fake.stallUnresponsiveWorkersDetailedMutex.Unlock()
+	fake.StallUnresponsiveWorkersDetailedStub = nil
+	fake.stallUnresponsiveWorkersDetailedReturns = struct {
+		result1 []db.WorkerAffected
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersDetailedReturnsOnCall(i int, result1 []db.WorkerAffected, result2 error) {
+	fake.stallUnresponsiveWorkersDetailedMutex.Lock()
+	defer fake.stallUnresponsiveWorkersDetailedMutex.Unlock()
+	fake.StallUnresponsiveWorkersDetailedStub = nil
+	if fake.stallUnresponsiveWorkersDetailedReturnsOnCall == nil {
+		fake.stallUnresponsiveWorkersDetailedReturnsOnCall = make(map[int]struct {
+			result1 []db.WorkerAffected
+			result2 error
+		})
+	}
+	fake.stallUnresponsiveWorkersDetailedReturnsOnCall[i] = struct {
+		result1 []db.WorkerAffected
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersOfKind(arg1 context.Context, arg2 db.WorkerKind) ([]string, error) {
+	fake.stallUnresponsiveWorkersOfKindMutex.Lock()
+	ret, specificReturn := fake.stallUnresponsiveWorkersOfKindReturnsOnCall[len(fake.stallUnresponsiveWorkersOfKindArgsForCall)]
+	fake.stallUnresponsiveWorkersOfKindArgsForCall = append(fake.stallUnresponsiveWorkersOfKindArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.WorkerKind
+	}{arg1, arg2})
+	stub := fake.StallUnresponsiveWorkersOfKindStub
+	fakeReturns := fake.stallUnresponsiveWorkersOfKindReturns
+	fake.recordInvocation("StallUnresponsiveWorkersOfKind", []interface{}{arg1, arg2})
+	fake.stallUnresponsiveWorkersOfKindMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersOfKindCallCount() int {
+	fake.stallUnresponsiveWorkersOfKindMutex.RLock()
+	defer fake.stallUnresponsiveWorkersOfKindMutex.RUnlock()
+	return len(fake.stallUnresponsiveWorkersOfKindArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersOfKindCalls(stub func(context.Context, db.WorkerKind) ([]string, error)) {
+	fake.stallUnresponsiveWorkersOfKindMutex.Lock()
+	defer fake.stallUnresponsiveWorkersOfKindMutex.Unlock()
+	fake.StallUnresponsiveWorkersOfKindStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersOfKindArgsForCall(i int) (context.Context, db.WorkerKind) {
+	fake.stallUnresponsiveWorkersOfKindMutex.RLock()
+	defer fake.stallUnresponsiveWorkersOfKindMutex.RUnlock()
+	argsForCall := fake.stallUnresponsiveWorkersOfKindArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersOfKindReturns(result1 []string, result2 error) {
+	fake.stallUnresponsiveWorkersOfKindMutex.Lock()
+	defer fake.stallUnresponsiveWorkersOfKindMutex.Unlock()
+	fake.StallUnresponsiveWorkersOfKindStub = nil
+	fake.stallUnresponsiveWorkersOfKindReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersOfKindReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.stallUnresponsiveWorkersOfKindMutex.Lock()
+	defer fake.stallUnresponsiveWorkersOfKindMutex.Unlock()
+	fake.StallUnresponsiveWorkersOfKindStub = nil
+	if fake.stallUnresponsiveWorkersOfKindReturnsOnCall == nil {
+		fake.stallUnresponsiveWorkersOfKindReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.stallUnresponsiveWorkersOfKindReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersSQL(arg1 time.Duration) (string, []any, error) {
+	fake.stallUnresponsiveWorkersSQLMutex.Lock()
+	ret, specificReturn := fake.stallUnresponsiveWorkersSQLReturnsOnCall[len(fake.stallUnresponsiveWorkersSQLArgsForCall)]
+	fake.stallUnresponsiveWorkersSQLArgsForCall = append(fake.stallUnresponsiveWorkersSQLArgsForCall, struct {
+		arg1 time.Duration
+	}{arg1})
+	stub := fake.StallUnresponsiveWorkersSQLStub
+	fakeReturns := fake.stallUnresponsiveWorkersSQLReturns
+	fake.recordInvocation("StallUnresponsiveWorkersSQL", []interface{}{arg1})
+	fake.stallUnresponsiveWorkersSQLMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersSQLCallCount() int {
+	fake.stallUnresponsiveWorkersSQLMutex.RLock()
+	defer fake.stallUnresponsiveWorkersSQLMutex.RUnlock()
+	return len(fake.stallUnresponsiveWorkersSQLArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersSQLCalls(stub func(time.Duration) (string, []any, error)) {
+	fake.stallUnresponsiveWorkersSQLMutex.Lock()
+	defer fake.stallUnresponsiveWorkersSQLMutex.Unlock()
+	fake.StallUnresponsiveWorkersSQLStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersSQLArgsForCall(i int) time.Duration {
+	fake.stallUnresponsiveWorkersSQLMutex.RLock()
+	defer fake.stallUnresponsiveWorkersSQLMutex.RUnlock()
+	argsForCall := fake.stallUnresponsiveWorkersSQLArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersSQLReturns(result1 string, result2 []any, result3 error) {
+	fake.stallUnresponsiveWorkersSQLMutex.Lock()
+	defer fake.stallUnresponsiveWorkersSQLMutex.Unlock()
+	fake.StallUnresponsiveWorkersSQLStub = nil
+	fake.stallUnresponsiveWorkersSQLReturns = struct {
+		result1 string
+		result2 []any
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersSQLReturnsOnCall(i int, result1 string, result2 []any, result3 error) {
+	fake.stallUnresponsiveWorkersSQLMutex.Lock()
+	defer fake.stallUnresponsiveWorkersSQLMutex.Unlock()
+	fake.StallUnresponsiveWorkersSQLStub = nil
+	if fake.stallUnresponsiveWorkersSQLReturnsOnCall == nil {
+		fake.stallUnresponsiveWorkersSQLReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 []any
+			result3 error
+		})
+	}
+	fake.stallUnresponsiveWorkersSQLReturnsOnCall[i] = struct {
+		result1 string
+		result2 []any
+		result3 error
+	}{result1, result2, result3}
 }
 
-func (fake *FakeWorkerLifecycle) DeleteFinishedRetiringWorkers() ([]string, error) {
-	fake.deleteFinishedRetiringWorkersMutex.Lock()
-	ret, specificReturn := fake.deleteFinishedRetiringWorkersReturnsOnCall[len(fake.deleteFinishedRetiringWorkersArgsForCall)]
-	fake.deleteFinishedRetiringWorkersArgsForCall = append(fake.deleteFinishedRetiringWorkersArgsForCall, struct {
-	}{})
-	stub := fake.DeleteFinishedRetiringWorkersStub
-	fakeReturns := fake.deleteFinishedRetiringWorkersReturns
-	fake.recordInvocation("DeleteFinishedRetiringWorkers", []interface{}{})
-	fake.deleteFinishedRetiringWorkersMutex.Unlock()
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersTx(arg1 context.Context, arg2 db.Tx) ([]string, error) {
+	fake.stallUnresponsiveWorkersTxMutex.Lock()
+	ret, specificReturn := fake.stallUnresponsiveWorkersTxReturnsOnCall[len(fake.stallUnresponsiveWorkersTxArgsForCall)]
+	fake.stallUnresponsiveWorkersTxArgsForCall = append(fake.stallUnresponsiveWorkersTxArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.Tx
+	}{arg1, arg2})
+	stub := fake.StallUnresponsiveWorkersTxStub
+	fakeReturns := fake.stallUnresponsiveWorkersTxReturns
+	fake.recordInvocation("StallUnresponsiveWorkersTx", []interface{}{arg1, arg2})
+	fake.stallUnresponsiveWorkersTxMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -104,56 +6959,64 @@ func (fake *FakeWorkerLifecycle) DeleteFinishedRetiringWorkers() ([]string, erro
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeWorkerLifecycle) DeleteFinishedRetiringWorkersCallCount() int {
-	fake.deleteFinishedRetiringWorkersMutex.RLock()
-	defer fake.deleteFinishedRetiringWorkersMutex.RUnlock()
-	return len(fake.deleteFinishedRetiringWorkersArgsForCall)
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersTxCallCount() int {
+	fake.stallUnresponsiveWorkersTxMutex.RLock()
+	defer fake.stallUnresponsiveWorkersTxMutex.RUnlock()
+	return len(fake.stallUnresponsiveWorkersTxArgsForCall)
 }
 
-func (fake *FakeWorkerLifecycle) DeleteFinishedRetiringWorkersCalls(stub func() ([]string, error)) {
-	fake.deleteFinishedRetiringWorkersMutex.Lock()
-	defer fake.deleteFinishedRetiringWorkersMutex.Unlock()
-	fake.DeleteFinishedRetiringWorkersStub = stub
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersTxCalls(stub func(context.Context, db.Tx) ([]string, error)) {
+	fake.stallUnresponsiveWorkersTxMutex.Lock()
+	defer fake.stallUnresponsiveWorkersTxMutex.Unlock()
+	fake.StallUnresponsiveWorkersTxStub = stub
 }
 
-func (fake *FakeWorkerLifecycle) DeleteFinishedRetiringWorkersReturns(result1 []string, result2 error) {
-	fake.deleteFinishedRetiringWorkersMutex.Lock()
-	defer fake.deleteFinishedRetiringWorkersMutex.Unlock()
-	fake.DeleteFinishedRetiringWorkersStub = nil
-	fake.deleteFinishedRetiringWorkersReturns = struct {
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersTxArgsForCall(i int) (context.Context, db.Tx) {
+	fake.stallUnresponsiveWorkersTxMutex.RLock()
+	defer fake.stallUnresponsiveWorkersTxMutex.RUnlock()
+	argsForCall := fake.stallUnresponsiveWorkersTxArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersTxReturns(result1 []string, result2 error) {
+	fake.stallUnresponsiveWorkersTxMutex.Lock()
+	defer fake.stallUnresponsiveWorkersTxMutex.Unlock()
+	fake.StallUnresponsiveWorkersTxStub = nil
+	fake.stallUnresponsiveWorkersTxReturns = struct {
 		result1 []string
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeWorkerLifecycle) DeleteFinishedRetiringWorkersReturnsOnCall(i int, result1 []string, result2 error) {
-	fake.deleteFinishedRetiringWorkersMutex.Lock()
-	defer fake.deleteFinishedRetiringWorkersMutex.Unlock()
-	fake.DeleteFinishedRetiringWorkersStub = nil
-	if fake.deleteFinishedRetiringWorkersReturnsOnCall == nil {
-		fake.deleteFinishedRetiringWorkersReturnsOnCall = make(map[int]struct {
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersTxReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.stallUnresponsiveWorkersTxMutex.Lock()
+	defer fake.stallUnresponsiveWorkersTxMutex.Unlock()
+	fake.StallUnresponsiveWorkersTxStub = nil
+	if fake.stallUnresponsiveWorkersTxReturnsOnCall == nil {
+		fake.stallUnresponsiveWorkersTxReturnsOnCall = make(map[int]struct {
 			result1 []string
 			result2 error
 		})
 	}
-	fake.deleteFinishedRetiringWorkersReturnsOnCall[i] = struct {
+	fake.stallUnresponsiveWorkersTxReturnsOnCall[i] = struct {
 		result1 []string
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeWorkerLifecycle) DeleteStalledWorkers(arg1 time.Duration) ([]string, error) {
-	fake.deleteStalledWorkersMutex.Lock()
-	ret, specificReturn := fake.deleteStalledWorkersReturnsOnCall[len(fake.deleteStalledWorkersArgsForCall)]
-	fake.deleteStalledWorkersArgsForCall = append(fake.deleteStalledWorkersArgsForCall, struct {
-		arg1 time.Duration
-	}{arg1})
-	stub := fake.DeleteStalledWorkersStub
-	fakeReturns := fake.deleteStalledWorkersReturns
-	fake.recordInvocation("DeleteStalledWorkers", []interface{}{arg1})
-	fake.deleteStalledWorkersMutex.Unlock()
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersWithGrace(arg1 context.Context, arg2 time.Duration) ([]string, error) {
+	fake.stallUnresponsiveWorkersWithGraceMutex.Lock()
+	ret, specificReturn := fake.stallUnresponsiveWorkersWithGraceReturnsOnCall[len(fake.stallUnresponsiveWorkersWithGraceArgsForCall)]
+	fake.stallUnresponsiveWorkersWithGraceArgsForCall = append(fake.stallUnresponsiveWorkersWithGraceArgsForCall, struct {
+		arg1 context.Context
+		arg2 time.Duration
+	}{arg1, arg2})
+	stub := fake.StallUnresponsiveWorkersWithGraceStub
+	fakeReturns := fake.stallUnresponsiveWorkersWithGraceReturns
+	fake.recordInvocation("StallUnresponsiveWorkersWithGrace", []interface{}{arg1, arg2})
+	fake.stallUnresponsiveWorkersWithGraceMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -161,62 +7024,63 @@ func (fake *FakeWorkerLifecycle) DeleteStalledWorkers(arg1 time.Duration) ([]str
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeWorkerLifecycle) DeleteStalledWorkersCallCount() int {
-	fake.deleteStalledWorkersMutex.RLock()
-	defer fake.deleteStalledWorkersMutex.RUnlock()
-	return len(fake.deleteStalledWorkersArgsForCall)
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersWithGraceCallCount() int {
+	fake.stallUnresponsiveWorkersWithGraceMutex.RLock()
+	defer fake.stallUnresponsiveWorkersWithGraceMutex.RUnlock()
+	return len(fake.stallUnresponsiveWorkersWithGraceArgsForCall)
 }
 
-func (fake *FakeWorkerLifecycle) DeleteStalledWorkersCalls(stub func(time.Duration) ([]string, error)) {
-	fake.deleteStalledWorkersMutex.Lock()
-	defer fake.deleteStalledWorkersMutex.Unlock()
-	fake.DeleteStalledWorkersStub = stub
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersWithGraceCalls(stub func(context.Context, time.Duration) ([]string, error)) {
+	fake.stallUnresponsiveWorkersWithGraceMutex.Lock()
+	defer fake.stallUnresponsiveWorkersWithGraceMutex.Unlock()
+	fake.StallUnresponsiveWorkersWithGraceStub = stub
 }
 
-func (fake *FakeWorkerLifecycle) DeleteStalledWorkersArgsForCall(i int) time.Duration {
-	fake.deleteStalledWorkersMutex.RLock()
-	defer fake.deleteStalledWorkersMutex.RUnlock()
-	argsForCall := fake.deleteStalledWorkersArgsForCall[i]
-	return argsForCall.arg1
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersWithGraceArgsForCall(i int) (context.Context, time.Duration) {
+	fake.stallUnresponsiveWorkersWithGraceMutex.RLock()
+	defer fake.stallUnresponsiveWorkersWithGraceMutex.RUnlock()
+	argsForCall := fake.stallUnresponsiveWorkersWithGraceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeWorkerLifecycle) DeleteStalledWorkersReturns(result1 []string, result2 error) {
-	fake.deleteStalledWorkersMutex.Lock()
-	defer fake.deleteStalledWorkersMutex.Unlock()
-	fake.DeleteStalledWorkersStub = nil
-	fake.deleteStalledWorkersReturns = struct {
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersWithGraceReturns(result1 []string, result2 error) {
+	fake.stallUnresponsiveWorkersWithGraceMutex.Lock()
+	defer fake.stallUnresponsiveWorkersWithGraceMutex.Unlock()
+	fake.StallUnresponsiveWorkersWithGraceStub = nil
+	fake.stallUnresponsiveWorkersWithGraceReturns = struct {
 		result1 []string
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeWorkerLifecycle) DeleteStalledWorkersReturnsOnCall(i int, result1 []string, result2 error) {
-	fake.deleteStalledWorkersMutex.Lock()
-	defer fake.deleteStalledWorkersMutex.Unlock()
-	fake.DeleteStalledWorkersStub = nil
-	if fake.deleteStalledWorkersReturnsOnCall == nil {
-		fake.deleteStalledWorkersReturnsOnCall = make(map[int]struct {
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersWithGraceReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.stallUnresponsiveWorkersWithGraceMutex.Lock()
+	defer fake.stallUnresponsiveWorkersWithGraceMutex.Unlock()
+	fake.StallUnresponsiveWorkersWithGraceStub = nil
+	if fake.stallUnresponsiveWorkersWithGraceReturnsOnCall == nil {
+		fake.stallUnresponsiveWorkersWithGraceReturnsOnCall = make(map[int]struct {
 			result1 []string
 			result2 error
 		})
 	}
-	fake.deleteStalledWorkersReturnsOnCall[i] = struct {
+	fake.stallUnresponsiveWorkersWithGraceReturnsOnCall[i] = struct {
 		result1 []string
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkers() ([]string, error) {
-	fake.deleteUnresponsiveEphemeralWorkersMutex.Lock()
-	ret, specificReturn := fake.deleteUnresponsiveEphemeralWorkersReturnsOnCall[len(fake.deleteUnresponsiveEphemeralWorkersArgsForCall)]
-	fake.deleteUnresponsiveEphemeralWorkersArgsForCall = append(fake.deleteUnresponsiveEphemeralWorkersArgsForCall, struct {
-	}{})
-	stub := fake.DeleteUnresponsiveEphemeralWorkersStub
-	fakeReturns := fake.deleteUnresponsiveEphemeralWorkersReturns
-	fake.recordInvocation("DeleteUnresponsiveEphemeralWorkers", []interface{}{})
-	fake.deleteUnresponsiveEphemeralWorkersMutex.Unlock()
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersWithPreviousState(arg1 context.Context) ([]db.WorkerTransition, error) {
+	fake.stallUnresponsiveWorkersWithPreviousStateMutex.Lock()
+	ret, specificReturn := fake.stallUnresponsiveWorkersWithPreviousStateReturnsOnCall[len(fake.stallUnresponsiveWorkersWithPreviousStateArgsForCall)]
+	fake.stallUnresponsiveWorkersWithPreviousStateArgsForCall = append(fake.stallUnresponsiveWorkersWithPreviousStateArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.StallUnresponsiveWorkersWithPreviousStateStub
+	fakeReturns := fake.stallUnresponsiveWorkersWithPreviousStateReturns
+	fake.recordInvocation("StallUnresponsiveWorkersWithPreviousState", []interface{}{arg1})
+	fake.stallUnresponsiveWorkersWithPreviousStateMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -224,55 +7088,125 @@ func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkers() ([]string,
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkersCallCount() int {
-	fake.deleteUnresponsiveEphemeralWorkersMutex.RLock()
-	defer fake.deleteUnresponsiveEphemeralWorkersMutex.RUnlock()
-	return len(fake.deleteUnresponsiveEphemeralWorkersArgsForCall)
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersWithPreviousStateCallCount() int {
+	fake.stallUnresponsiveWorkersWithPreviousStateMutex.RLock()
+	defer fake.stallUnresponsiveWorkersWithPreviousStateMutex.RUnlock()
+	return len(fake.stallUnresponsiveWorkersWithPreviousStateArgsForCall)
 }
 
-func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkersCalls(stub func() ([]string, error)) {
-	fake.deleteUnresponsiveEphemeralWorkersMutex.Lock()
-	defer fake.deleteUnresponsiveEphemeralWorkersMutex.Unlock()
-	fake.DeleteUnresponsiveEphemeralWorkersStub = stub
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersWithPreviousStateCalls(stub func(context.Context) ([]db.WorkerTransition, error)) {
+	fake.stallUnresponsiveWorkersWithPreviousStateMutex.Lock()
+	defer fake.stallUnresponsiveWorkersWithPreviousStateMutex.Unlock()
+	fake.StallUnresponsiveWorkersWithPreviousStateStub = stub
 }
 
-func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkersReturns(result1 []string, result2 error) {
-	fake.deleteUnresponsiveEphemeralWorkersMutex.Lock()
-	defer fake.deleteUnresponsiveEphemeralWorkersMutex.Unlock()
-	fake.DeleteUnresponsiveEphemeralWorkersStub = nil
-	fake.deleteUnresponsiveEphemeralWorkersReturns = struct {
-		result1 []string
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersWithPreviousStateArgsForCall(i int) context.Context {
+	fake.stallUnresponsiveWorkersWithPreviousStateMutex.RLock()
+	defer fake.stallUnresponsiveWorkersWithPreviousStateMutex.RUnlock()
+	argsForCall := fake.stallUnresponsiveWorkersWithPreviousStateArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersWithPreviousStateReturns(result1 []db.WorkerTransition, result2 error) {
+	fake.stallUnresponsiveWorkersWithPreviousStateMutex.Lock()
+	defer fake.stallUnresponsiveWorkersWithPreviousStateMutex.Unlock()
+	fake.StallUnresponsiveWorkersWithPreviousStateStub = nil
+	fake.stallUnresponsiveWorkersWithPreviousStateReturns = struct {
+		result1 []db.WorkerTransition
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkersReturnsOnCall(i int, result1 []string, result2 error) {
-	fake.deleteUnresponsiveEphemeralWorkersMutex.Lock()
-	defer fake.deleteUnresponsiveEphemeralWorkersMutex.Unlock()
-	fake.DeleteUnresponsiveEphemeralWorkersStub = nil
-	if fake.deleteUnresponsiveEphemeralWorkersReturnsOnCall == nil {
-		fake.deleteUnresponsiveEphemeralWorkersReturnsOnCall = make(map[int]struct {
-			result1 []string
+func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersWithPreviousStateReturnsOnCall(i int, result1 []db.WorkerTransition, result2 error) {
+	fake.stallUnresponsiveWorkersWithPreviousStateMutex.Lock()
+	defer fake.stallUnresponsiveWorkersWithPreviousStateMutex.Unlock()
+	fake.StallUnresponsiveWorkersWithPreviousStateStub = nil
+	if fake.stallUnresponsiveWorkersWithPreviousStateReturnsOnCall == nil {
+		fake.stallUnresponsiveWorkersWithPreviousStateReturnsOnCall = make(map[int]struct {
+			result1 []db.WorkerTransition
 			result2 error
 		})
 	}
-	fake.deleteUnresponsiveEphemeralWorkersReturnsOnCall[i] = struct {
-		result1 []string
+	fake.stallUnresponsiveWorkersWithPreviousStateReturnsOnCall[i] = struct {
+		result1 []db.WorkerTransition
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeWorkerLifecycle) GetWorkerStateByName() (map[string]db.WorkerState, error) {
-	fake.getWorkerStateByNameMutex.Lock()
-	ret, specificReturn := fake.getWorkerStateByNameReturnsOnCall[len(fake.getWorkerStateByNameArgsForCall)]
-	fake.getWorkerStateByNameArgsForCall = append(fake.getWorkerStateByNameArgsForCall, struct {
-	}{})
-	stub := fake.GetWorkerStateByNameStub
-	fakeReturns := fake.getWorkerStateByNameReturns
-	fake.recordInvocation("GetWorkerStateByName", []interface{}{})
-	fake.getWorkerStateByNameMutex.Unlock()
+func (fake *FakeWorkerLifecycle) StreamWorkerStates(arg1 context.Context, arg2 func(name string, state db.WorkerState) error) error {
+	fake.streamWorkerStatesMutex.Lock()
+	ret, specificReturn := fake.streamWorkerStatesReturnsOnCall[len(fake.streamWorkerStatesArgsForCall)]
+	fake.streamWorkerStatesArgsForCall = append(fake.streamWorkerStatesArgsForCall, struct {
+		arg1 context.Context
+		arg2 func(name string, state db.WorkerState) error
+	}{arg1, arg2})
+	stub := fake.StreamWorkerStatesStub
+	fakeReturns := fake.streamWorkerStatesReturns
+	fake.recordInvocation("StreamWorkerStates", []interface{}{arg1, arg2})
+	fake.streamWorkerStatesMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorkerLifecycle) StreamWorkerStatesCallCount() int {
+	fake.streamWorkerStatesMutex.RLock()
+	defer fake.streamWorkerStatesMutex.RUnlock()
+	return len(fake.streamWorkerStatesArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) StreamWorkerStatesCalls(stub func(context.Context, func(name string, state db.WorkerState) error) error) {
+	fake.streamWorkerStatesMutex.Lock()
+	defer fake.streamWorkerStatesMutex.Unlock()
+	fake.StreamWorkerStatesStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) StreamWorkerStatesArgsForCall(i int) (context.Context, func(name string, state db.WorkerState) error) {
+	fake.streamWorkerStatesMutex.RLock()
+	defer fake.streamWorkerStatesMutex.RUnlock()
+	argsForCall := fake.streamWorkerStatesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorkerLifecycle) StreamWorkerStatesReturns(result1 error) {
+	fake.streamWorkerStatesMutex.Lock()
+	defer fake.streamWorkerStatesMutex.Unlock()
+	fake.StreamWorkerStatesStub = nil
+	fake.streamWorkerStatesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorkerLifecycle) StreamWorkerStatesReturnsOnCall(i int, result1 error) {
+	fake.streamWorkerStatesMutex.Lock()
+	defer fake.streamWorkerStatesMutex.Unlock()
+	fake.StreamWorkerStatesStub = nil
+	if fake.streamWorkerStatesReturnsOnCall == nil {
+		fake.streamWorkerStatesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.streamWorkerStatesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorkerLifecycle) TotalActiveContainers(arg1 context.Context) (int, error) {
+	fake.totalActiveContainersMutex.Lock()
+	ret, specificReturn := fake.totalActiveContainersReturnsOnCall[len(fake.totalActiveContainersArgsForCall)]
+	fake.totalActiveContainersArgsForCall = append(fake.totalActiveContainersArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.TotalActiveContainersStub
+	fakeReturns := fake.totalActiveContainersReturns
+	fake.recordInvocation("TotalActiveContainers", []interface{}{arg1})
+	fake.totalActiveContainersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -280,55 +7214,66 @@ func (fake *FakeWorkerLifecycle) GetWorkerStateByName() (map[string]db.WorkerSta
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeWorkerLifecycle) GetWorkerStateByNameCallCount() int {
-	fake.getWorkerStateByNameMutex.RLock()
-	defer fake.getWorkerStateByNameMutex.RUnlock()
-	return len(fake.getWorkerStateByNameArgsForCall)
+func (fake *FakeWorkerLifecycle) TotalActiveContainersCallCount() int {
+	fake.totalActiveContainersMutex.RLock()
+	defer fake.totalActiveContainersMutex.RUnlock()
+	return len(fake.totalActiveContainersArgsForCall)
 }
 
-func (fake *FakeWorkerLifecycle) GetWorkerStateByNameCalls(stub func() (map[string]db.WorkerState, error)) {
-	fake.getWorkerStateByNameMutex.Lock()
-	defer fake.getWorkerStateByNameMutex.Unlock()
-	fake.GetWorkerStateByNameStub = stub
+func (fake *FakeWorkerLifecycle) TotalActiveContainersCalls(stub func(context.Context) (int, error)) {
+	fake.totalActiveContainersMutex.Lock()
+	defer fake.totalActiveContainersMutex.Unlock()
+	fake.TotalActiveContainersStub = stub
 }
 
-func (fake *FakeWorkerLifecycle) GetWorkerStateByNameReturns(result1 map[string]db.WorkerState, result2 error) {
-	fake.getWorkerStateByNameMutex.Lock()
-	defer fake.getWorkerStateByNameMutex.Unlock()
-	fake.GetWorkerStateByNameStub = nil
-	fake.getWorkerStateByNameReturns = struct {
-		result1 map[string]db.WorkerState
+func (fake *FakeWorkerLifecycle) TotalActiveContainersArgsForCall(i int) context.Context {
+	fake.totalActiveContainersMutex.RLock()
+	defer fake.totalActiveContainersMutex.RUnlock()
+	argsForCall := fake.totalActiveContainersArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) TotalActiveContainersReturns(result1 int, result2 error) {
+	fake.totalActiveContainersMutex.Lock()
+	defer fake.totalActiveContainersMutex.Unlock()
+	fake.TotalActiveContainersStub = nil
+	fake.totalActiveContainersReturns = struct {
+		result1 int
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeWorkerLifecycle) GetWorkerStateByNameReturnsOnCall(i int, result1 map[string]db.WorkerState, result2 error) {
-	fake.getWorkerStateByNameMutex.Lock()
-	defer fake.getWorkerStateByNameMutex.Unlock()
-	fake.GetWorkerStateByNameStub = nil
-	if fake.getWorkerStateByNameReturnsOnCall == nil {
-		fake.getWorkerStateByNameReturnsOnCall = make(map[int]struct {
-			result1 map[string]db.WorkerState
+func (fake *FakeWorkerLifecycle) TotalActiveContainersReturnsOnCall(i int, result1 int, result2 error) {
+	fake.totalActiveContainersMutex.Lock()
+	defer fake.totalActiveContainersMutex.Unlock()
+	fake.TotalActiveContainersStub = nil
+	if fake.totalActiveContainersReturnsOnCall == nil {
+		fake.totalActiveContainersReturnsOnCall = make(map[int]struct {
+			result1 int
 			result2 error
 		})
 	}
-	fake.getWorkerStateByNameReturnsOnCall[i] = struct {
-		result1 map[string]db.WorkerState
+	fake.totalActiveContainersReturnsOnCall[i] = struct {
+		result1 int
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkers() ([]string, error) {
-	fake.landFinishedLandingWorkersMutex.Lock()
-	ret, specificReturn := fake.landFinishedLandingWorkersReturnsOnCall[len(fake.landFinishedLandingWorkersArgsForCall)]
-	fake.landFinishedLandingWorkersArgsForCall = append(fake.landFinishedLandingWorkersArgsForCall, struct {
-	}{})
-	stub := fake.LandFinishedLandingWorkersStub
-	fakeReturns := fake.landFinishedLandingWorkersReturns
-	fake.recordInvocation("LandFinishedLandingWorkers", []interface{}{})
-	fake.landFinishedLandingWorkersMutex.Unlock()
+func (fake *FakeWorkerLifecycle) TransitionWorker(arg1 context.Context, arg2 string, arg3 db.WorkerState, arg4 db.WorkerState) (int, error) {
+	fake.transitionWorkerMutex.Lock()
+	ret, specificReturn := fake.transitionWorkerReturnsOnCall[len(fake.transitionWorkerArgsForCall)]
+	fake.transitionWorkerArgsForCall = append(fake.transitionWorkerArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 db.WorkerState
+		arg4 db.WorkerState
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.TransitionWorkerStub
+	fakeReturns := fake.transitionWorkerReturns
+	fake.recordInvocation("TransitionWorker", []interface{}{arg1, arg2, arg3, arg4})
+	fake.transitionWorkerMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -336,55 +7281,187 @@ func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkers() ([]string, error)
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersCallCount() int {
-	fake.landFinishedLandingWorkersMutex.RLock()
-	defer fake.landFinishedLandingWorkersMutex.RUnlock()
-	return len(fake.landFinishedLandingWorkersArgsForCall)
+func (fake *FakeWorkerLifecycle) TransitionWorkerCallCount() int {
+	fake.transitionWorkerMutex.RLock()
+	defer fake.transitionWorkerMutex.RUnlock()
+	return len(fake.transitionWorkerArgsForCall)
 }
 
-func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersCalls(stub func() ([]string, error)) {
-	fake.landFinishedLandingWorkersMutex.Lock()
-	defer fake.landFinishedLandingWorkersMutex.Unlock()
-	fake.LandFinishedLandingWorkersStub = stub
+func (fake *FakeWorkerLifecycle) TransitionWorkerCalls(stub func(context.Context, string, db.WorkerState, db.WorkerState) (int, error)) {
+	fake.transitionWorkerMutex.Lock()
+	defer fake.transitionWorkerMutex.Unlock()
+	fake.TransitionWorkerStub = stub
 }
 
-func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersReturns(result1 []string, result2 error) {
-	fake.landFinishedLandingWorkersMutex.Lock()
-	defer fake.landFinishedLandingWorkersMutex.Unlock()
-	fake.LandFinishedLandingWorkersStub = nil
-	fake.landFinishedLandingWorkersReturns = struct {
-		result1 []string
+func (fake *FakeWorkerLifecycle) TransitionWorkerArgsForCall(i int) (context.Context, string, db.WorkerState, db.WorkerState) {
+	fake.transitionWorkerMutex.RLock()
+	defer fake.transitionWorkerMutex.RUnlock()
+	argsForCall := fake.transitionWorkerArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeWorkerLifecycle) TransitionWorkerReturns(result1 int, result2 error) {
+	fake.transitionWorkerMutex.Lock()
+	defer fake.transitionWorkerMutex.Unlock()
+	fake.TransitionWorkerStub = nil
+	fake.transitionWorkerReturns = struct {
+		result1 int
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersReturnsOnCall(i int, result1 []string, result2 error) {
-	fake.landFinishedLandingWorkersMutex.Lock()
-	defer fake.landFinishedLandingWorkersMutex.Unlock()
-	fake.LandFinishedLandingWorkersStub = nil
-	if fake.landFinishedLandingWorkersReturnsOnCall == nil {
-		fake.landFinishedLandingWorkersReturnsOnCall = make(map[int]struct {
-			result1 []string
+func (fake *FakeWorkerLifecycle) TransitionWorkerReturnsOnCall(i int, result1 int, result2 error) {
+	fake.transitionWorkerMutex.Lock()
+	defer fake.transitionWorkerMutex.Unlock()
+	fake.TransitionWorkerStub = nil
+	if fake.transitionWorkerReturnsOnCall == nil {
+		fake.transitionWorkerReturnsOnCall = make(map[int]struct {
+			result1 int
 			result2 error
 		})
 	}
-	fake.landFinishedLandingWorkersReturnsOnCall[i] = struct {
-		result1 []string
+	fake.transitionWorkerReturnsOnCall[i] = struct {
+		result1 int
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkers() ([]string, error) {
-	fake.stallUnresponsiveWorkersMutex.Lock()
-	ret, specificReturn := fake.stallUnresponsiveWorkersReturnsOnCall[len(fake.stallUnresponsiveWorkersArgsForCall)]
-	fake.stallUnresponsiveWorkersArgsForCall = append(fake.stallUnresponsiveWorkersArgsForCall, struct {
-	}{})
-	stub := fake.StallUnresponsiveWorkersStub
-	fakeReturns := fake.stallUnresponsiveWorkersReturns
-	fake.recordInvocation("StallUnresponsiveWorkers", []interface{}{})
-	fake.stallUnresponsiveWorkersMutex.Unlock()
+func (fake *FakeWorkerLifecycle) UnquarantineWorker(arg1 context.Context, arg2 string) error {
+	fake.unquarantineWorkerMutex.Lock()
+	ret, specificReturn := fake.unquarantineWorkerReturnsOnCall[len(fake.unquarantineWorkerArgsForCall)]
+	fake.unquarantineWorkerArgsForCall = append(fake.unquarantineWorkerArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.UnquarantineWorkerStub
+	fakeReturns := fake.unquarantineWorkerReturns
+	fake.recordInvocation("UnquarantineWorker", []interface{}{arg1, arg2})
+	fake.unquarantineWorkerMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorkerLifecycle) UnquarantineWorkerCallCount() int {
+	fake.unquarantineWorkerMutex.RLock()
+	defer fake.unquarantineWorkerMutex.RUnlock()
+	return len(fake.unquarantineWorkerArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) UnquarantineWorkerCalls(stub func(context.Context, string) error) {
+	fake.unquarantineWorkerMutex.Lock()
+	defer fake.unquarantineWorkerMutex.Unlock()
+	fake.UnquarantineWorkerStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) UnquarantineWorkerArgsForCall(i int) (context.Context, string) {
+	fake.unquarantineWorkerMutex.RLock()
+	defer fake.unquarantineWorkerMutex.RUnlock()
+	argsForCall := fake.unquarantineWorkerArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorkerLifecycle) UnquarantineWorkerReturns(result1 error) {
+	fake.unquarantineWorkerMutex.Lock()
+	defer fake.unquarantineWorkerMutex.Unlock()
+	fake.UnquarantineWorkerStub = nil
+	fake.unquarantineWorkerReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorkerLifecycle) UnquarantineWorkerReturnsOnCall(i int, result1 error) {
+	fake.unquarantineWorkerMutex.Lock()
+	defer fake.unquarantineWorkerMutex.Unlock()
+	fake.UnquarantineWorkerStub = nil
+	if fake.unquarantineWorkerReturnsOnCall == nil {
+		fake.unquarantineWorkerReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.unquarantineWorkerReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorkerLifecycle) WithLifecycleLock(arg1 context.Context, arg2 func() error) error {
+	fake.withLifecycleLockMutex.Lock()
+	ret, specificReturn := fake.withLifecycleLockReturnsOnCall[len(fake.withLifecycleLockArgsForCall)]
+	fake.withLifecycleLockArgsForCall = append(fake.withLifecycleLockArgsForCall, struct {
+		arg1 context.Context
+		arg2 func() error
+	}{arg1, arg2})
+	stub := fake.WithLifecycleLockStub
+	fakeReturns := fake.withLifecycleLockReturns
+	fake.recordInvocation("WithLifecycleLock", []interface{}{arg1, arg2})
+	fake.withLifecycleLockMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorkerLifecycle) WithLifecycleLockCallCount() int {
+	fake.withLifecycleLockMutex.RLock()
+	defer fake.withLifecycleLockMutex.RUnlock()
+	return len(fake.withLifecycleLockArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) WithLifecycleLockCalls(stub func(context.Context, func() error) error) {
+	fake.withLifecycleLockMutex.Lock()
+	defer fake.withLifecycleLockMutex.Unlock()
+	fake.WithLifecycleLockStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) WithLifecycleLockArgsForCall(i int) (context.Context, func() error) {
+	fake.withLifecycleLockMutex.RLock()
+	defer fake.withLifecycleLockMutex.RUnlock()
+	argsForCall := fake.withLifecycleLockArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorkerLifecycle) WithLifecycleLockReturns(result1 error) {
+	fake.withLifecycleLockMutex.Lock()
+	defer fake.withLifecycleLockMutex.Unlock()
+	fake.WithLifecycleLockStub = nil
+	fake.withLifecycleLockReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorkerLifecycle) WithLifecycleLockReturnsOnCall(i int, result1 error) {
+	fake.withLifecycleLockMutex.Lock()
+	defer fake.withLifecycleLockMutex.Unlock()
+	fake.WithLifecycleLockStub = nil
+	if fake.withLifecycleLockReturnsOnCall == nil {
+		fake.withLifecycleLockReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.withLifecycleLockReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorkerLifecycle) WorkerAgeStatsByState(arg1 context.Context) (map[db.WorkerState]db.AgeStats, error) {
+	fake.workerAgeStatsByStateMutex.Lock()
+	ret, specificReturn := fake.workerAgeStatsByStateReturnsOnCall[len(fake.workerAgeStatsByStateArgsForCall)]
+	fake.workerAgeStatsByStateArgsForCall = append(fake.workerAgeStatsByStateArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.WorkerAgeStatsByStateStub
+	fakeReturns := fake.workerAgeStatsByStateReturns
+	fake.recordInvocation("WorkerAgeStatsByState", []interface{}{arg1})
+	fake.workerAgeStatsByStateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -392,40 +7469,47 @@ func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkers() ([]string, error) {
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersCallCount() int {
-	fake.stallUnresponsiveWorkersMutex.RLock()
-	defer fake.stallUnresponsiveWorkersMutex.RUnlock()
-	return len(fake.stallUnresponsiveWorkersArgsForCall)
+func (fake *FakeWorkerLifecycle) WorkerAgeStatsByStateCallCount() int {
+	fake.workerAgeStatsByStateMutex.RLock()
+	defer fake.workerAgeStatsByStateMutex.RUnlock()
+	return len(fake.workerAgeStatsByStateArgsForCall)
 }
 
-func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersCalls(stub func() ([]string, error)) {
-	fake.stallUnresponsiveWorkersMutex.Lock()
-	defer fake.stallUnresponsiveWorkersMutex.Unlock()
-	fake.StallUnresponsiveWorkersStub = stub
+func (fake *FakeWorkerLifecycle) WorkerAgeStatsByStateCalls(stub func(context.Context) (map[db.WorkerState]db.AgeStats, error)) {
+	fake.workerAgeStatsByStateMutex.Lock()
+	defer fake.workerAgeStatsByStateMutex.Unlock()
+	fake.WorkerAgeStatsByStateStub = stub
 }
 
-func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersReturns(result1 []string, result2 error) {
-	fake.stallUnresponsiveWorkersMutex.Lock()
-	defer fake.stallUnresponsiveWorkersMutex.Unlock()
-	fake.StallUnresponsiveWorkersStub = nil
-	fake.stallUnresponsiveWorkersReturns = struct {
-		result1 []string
+func (fake *FakeWorkerLifecycle) WorkerAgeStatsByStateArgsForCall(i int) context.Context {
+	fake.workerAgeStatsByStateMutex.RLock()
+	defer fake.workerAgeStatsByStateMutex.RUnlock()
+	argsForCall := fake.workerAgeStatsByStateArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerLifecycle) WorkerAgeStatsByStateReturns(result1 map[db.WorkerState]db.AgeStats, result2 error) {
+	fake.workerAgeStatsByStateMutex.Lock()
+	defer fake.workerAgeStatsByStateMutex.Unlock()
+	fake.WorkerAgeStatsByStateStub = nil
+	fake.workerAgeStatsByStateReturns = struct {
+		result1 map[db.WorkerState]db.AgeStats
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersReturnsOnCall(i int, result1 []string, result2 error) {
-	fake.stallUnresponsiveWorkersMutex.Lock()
-	defer fake.stallUnresponsiveWorkersMutex.Unlock()
-	fake.StallUnresponsiveWorkersStub = nil
-	if fake.stallUnresponsiveWorkersReturnsOnCall == nil {
-		fake.stallUnresponsiveWorkersReturnsOnCall = make(map[int]struct {
-			result1 []string
+func (fake *FakeWorkerLifecycle) WorkerAgeStatsByStateReturnsOnCall(i int, result1 map[db.WorkerState]db.AgeStats, result2 error) {
+	fake.workerAgeStatsByStateMutex.Lock()
+	defer fake.workerAgeStatsByStateMutex.Unlock()
+	fake.WorkerAgeStatsByStateStub = nil
+	if fake.workerAgeStatsByStateReturnsOnCall == nil {
+		fake.workerAgeStatsByStateReturnsOnCall = make(map[int]struct {
+			result1 map[db.WorkerState]db.AgeStats
 			result2 error
 		})
 	}
-	fake.stallUnresponsiveWorkersReturnsOnCall[i] = struct {
-		result1 []string
+	fake.workerAgeStatsByStateReturnsOnCall[i] = struct {
+		result1 map[db.WorkerState]db.AgeStats
 		result2 error
 	}{result1, result2}
 }
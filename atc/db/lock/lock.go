@@ -27,6 +27,7 @@ const (
 	LockTypeInMemoryCheckBuildTracking
 	LockTypeResourceGet
 	LockTypeVolumeStreaming
+	LockTypeWorkerLifecycle
 )
 
 const (
@@ -92,6 +93,10 @@ func NewResourceScanningLockID() LockID {
 	return LockID{LockTypeResourceScanning}
 }
 
+func NewWorkerLifecycleLockID() LockID {
+	return LockID{LockTypeWorkerLifecycle}
+}
+
 func NewJobSchedulingLockID(jobID int) LockID {
 	return LockID{LockTypeJobScheduling, jobID}
 }
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS workers_state_changed_at_trigger ON workers;
+DROP FUNCTION IF EXISTS set_worker_state_changed_at();
+ALTER TABLE workers DROP COLUMN state_changed_at;
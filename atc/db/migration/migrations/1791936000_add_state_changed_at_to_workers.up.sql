@@ -0,0 +1,16 @@
+ALTER TABLE workers ADD COLUMN state_changed_at timestamp with time zone NOT NULL DEFAULT now();
+
+-- Keep state_changed_at up to date no matter which code path changes a
+-- worker's state.
+CREATE OR REPLACE FUNCTION set_worker_state_changed_at() RETURNS trigger AS $trigger$
+BEGIN
+  IF NEW.state IS DISTINCT FROM OLD.state THEN
+    NEW.state_changed_at := now();
+  END IF;
+
+  RETURN NEW;
+END;
+$trigger$ LANGUAGE plpgsql;
+
+CREATE TRIGGER workers_state_changed_at_trigger BEFORE UPDATE ON workers
+  FOR EACH ROW EXECUTE PROCEDURE set_worker_state_changed_at();
@@ -0,0 +1 @@
+ALTER TABLE workers ADD COLUMN stall_count integer NOT NULL DEFAULT 0;
@@ -0,0 +1,9 @@
+-- Values cannot be removed from an enum, so 'deleted' is left in worker_state
+-- and only the tombstones using it are removed.
+DELETE FROM workers WHERE state = 'deleted';
+
+ALTER TABLE workers DROP COLUMN deleted_at;
+
+ALTER TABLE "workers"
+DROP CONSTRAINT IF EXISTS "addr_when_running",
+ADD CONSTRAINT "addr_when_running" CHECK (((state <> 'stalled'::worker_state) AND (state <> 'landed'::worker_state) AND ((addr IS NOT NULL) OR (baggageclaim_url IS NOT NULL))) OR (state = 'stalled'::worker_state) OR (state = 'landed'::worker_state));
@@ -0,0 +1,10 @@
+ALTER TYPE worker_state ADD VALUE IF NOT EXISTS 'deleted';
+
+ALTER TABLE workers ADD COLUMN deleted_at timestamp with time zone;
+
+-- The tombstones left behind by SoftDeleteEphemeralWorkers have no address,
+-- like stalled and landed workers. The new value cannot be used in the
+-- transaction adding it, so the state is compared as text.
+ALTER TABLE "workers"
+DROP CONSTRAINT IF EXISTS "addr_when_running",
+ADD CONSTRAINT "addr_when_running" CHECK (((state <> 'stalled'::worker_state) AND (state <> 'landed'::worker_state) AND (state::text <> 'deleted') AND ((addr IS NOT NULL) OR (baggageclaim_url IS NOT NULL))) OR (state = 'stalled'::worker_state) OR (state = 'landed'::worker_state) OR (state::text = 'deleted'));
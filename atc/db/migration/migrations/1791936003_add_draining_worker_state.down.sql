@@ -0,0 +1,3 @@
+-- Values cannot be removed from an enum, so 'draining' is left in
+-- worker_state and draining workers go back to landing instead.
+UPDATE workers SET state = 'landing' WHERE state = 'draining';
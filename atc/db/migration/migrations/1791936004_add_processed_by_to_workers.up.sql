@@ -0,0 +1 @@
+ALTER TABLE workers ADD COLUMN processed_by text;
@@ -0,0 +1,9 @@
+-- Values cannot be removed from an enum, so 'quarantined' is left in
+-- worker_state and quarantined workers go back to running instead, or to
+-- stalled if they were quarantined without an address.
+UPDATE workers
+SET state = CASE
+  WHEN addr IS NULL AND baggageclaim_url IS NULL THEN 'stalled'::worker_state
+  ELSE 'running'::worker_state
+END
+WHERE state = 'quarantined';
@@ -0,0 +1 @@
+ALTER TYPE worker_state ADD VALUE IF NOT EXISTS 'quarantined';
@@ -0,0 +1 @@
+ALTER TABLE workers ADD COLUMN baggageclaim_healthy boolean;
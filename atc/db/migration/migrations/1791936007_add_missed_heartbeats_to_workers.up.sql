@@ -0,0 +1 @@
+ALTER TABLE workers ADD COLUMN missed_heartbeats integer NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+ALTER TABLE workers ADD COLUMN ever_landed boolean NOT NULL DEFAULT false;
+
+UPDATE workers SET ever_landed = true WHERE state = 'landed';
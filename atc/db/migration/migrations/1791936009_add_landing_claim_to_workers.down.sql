@@ -0,0 +1,3 @@
+ALTER TABLE workers
+  DROP COLUMN landing_owner,
+  DROP COLUMN landing_lease_expires;
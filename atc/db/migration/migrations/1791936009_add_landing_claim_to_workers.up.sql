@@ -0,0 +1,3 @@
+ALTER TABLE workers
+  ADD COLUMN landing_owner text,
+  ADD COLUMN landing_lease_expires timestamp with time zone;
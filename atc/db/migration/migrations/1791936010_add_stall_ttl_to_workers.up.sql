@@ -0,0 +1 @@
+ALTER TABLE workers ADD COLUMN stall_ttl interval;
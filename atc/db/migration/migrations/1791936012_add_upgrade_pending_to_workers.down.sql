@@ -0,0 +1 @@
+ALTER TABLE workers DROP COLUMN upgrade_pending;
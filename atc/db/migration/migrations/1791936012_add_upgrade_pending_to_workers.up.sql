@@ -0,0 +1 @@
+ALTER TABLE workers ADD COLUMN upgrade_pending boolean NOT NULL DEFAULT false;
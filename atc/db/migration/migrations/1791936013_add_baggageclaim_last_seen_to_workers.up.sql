@@ -0,0 +1 @@
+ALTER TABLE workers ADD COLUMN baggageclaim_last_seen timestamptz;
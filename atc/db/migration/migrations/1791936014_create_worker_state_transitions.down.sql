@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS workers_state_transition_trigger ON workers;
+DROP FUNCTION IF EXISTS record_worker_state_transition();
+DROP TABLE IF EXISTS worker_state_transitions;
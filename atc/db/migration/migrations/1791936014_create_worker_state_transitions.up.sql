@@ -0,0 +1,35 @@
+CREATE TABLE worker_state_transitions (
+  id bigserial PRIMARY KEY,
+  worker_name text NOT NULL,
+  from_state text,
+  to_state text,
+  processed_by text,
+  transitioned_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX worker_state_transitions_worker_name_idx ON worker_state_transitions (worker_name, transitioned_at);
+
+-- Record every change of a worker's state, in the same transaction as the
+-- change, no matter which code path makes it. A registered worker has no from
+-- state and a deleted worker has no to state.
+CREATE OR REPLACE FUNCTION record_worker_state_transition() RETURNS trigger AS $trigger$
+BEGIN
+  IF TG_OP = 'INSERT' THEN
+    INSERT INTO worker_state_transitions (worker_name, from_state, to_state, processed_by)
+    VALUES (NEW.name, NULL, NEW.state, NEW.processed_by);
+  ELSIF TG_OP = 'UPDATE' THEN
+    IF NEW.state IS DISTINCT FROM OLD.state THEN
+      INSERT INTO worker_state_transitions (worker_name, from_state, to_state, processed_by)
+      VALUES (NEW.name, OLD.state, NEW.state, NEW.processed_by);
+    END IF;
+  ELSIF TG_OP = 'DELETE' THEN
+    INSERT INTO worker_state_transitions (worker_name, from_state, to_state, processed_by)
+    VALUES (OLD.name, OLD.state, NULL, OLD.processed_by);
+  END IF;
+
+  RETURN NULL;
+END;
+$trigger$ LANGUAGE plpgsql;
+
+CREATE TRIGGER workers_state_transition_trigger AFTER INSERT OR UPDATE OR DELETE ON workers
+  FOR EACH ROW EXECUTE PROCEDURE record_worker_state_transition();
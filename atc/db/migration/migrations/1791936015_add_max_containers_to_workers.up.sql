@@ -0,0 +1 @@
+ALTER TABLE workers ADD COLUMN max_containers integer;
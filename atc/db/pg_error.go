@@ -14,3 +14,13 @@ func isForeignKeyOrRestrictViolation(err error) bool {
 	return pgErr.Code == pgerrcode.ForeignKeyViolation || // Returned by Postgresql <= 17
 		pgErr.Code == pgerrcode.RestrictViolation // Returned by Postgresql >= 18
 }
+
+func isDeadlockOrSerializationFailure(err error) bool {
+	pgErr, ok := err.(*pgconn.PgError)
+	if !ok {
+		return false
+	}
+
+	return pgErr.Code == pgerrcode.DeadlockDetected ||
+		pgErr.Code == pgerrcode.SerializationFailure
+}
@@ -1,6 +1,7 @@
 package db_test
 
 import (
+	"context"
 	"database/sql"
 	"time"
 
@@ -135,7 +136,7 @@ var _ = Describe("VolumeRepository", func() {
 					var err error
 					defaultWorker, err = workerFactory.SaveWorker(defaultWorkerPayload, -10*time.Minute)
 					Expect(err).NotTo(HaveOccurred())
-					stalledWorkers, err := workerLifecycle.StallUnresponsiveWorkers()
+					stalledWorkers, err := workerLifecycle.StallUnresponsiveWorkers(context.Background())
 					Expect(err).NotTo(HaveOccurred())
 					Expect(stalledWorkers).To(ContainElement(defaultWorker.Name()))
 				})
@@ -311,7 +312,7 @@ var _ = Describe("VolumeRepository", func() {
 				var err error
 				defaultWorker, err = workerFactory.SaveWorker(defaultWorkerPayload, -11*time.Minute)
 				Expect(err).NotTo(HaveOccurred())
-				stalledWorkers, err := workerLifecycle.StallUnresponsiveWorkers()
+				stalledWorkers, err := workerLifecycle.StallUnresponsiveWorkers(context.Background())
 				Expect(err).NotTo(HaveOccurred())
 				Expect(stalledWorkers).To(ContainElement(defaultWorker.Name()))
 			})
@@ -330,7 +331,7 @@ var _ = Describe("VolumeRepository", func() {
 			BeforeEach(func() {
 				err := defaultWorker.Land()
 				Expect(err).NotTo(HaveOccurred())
-				landedWorkers, err := workerLifecycle.LandFinishedLandingWorkers()
+				landedWorkers, err := workerLifecycle.LandFinishedLandingWorkers(context.Background())
 				Expect(err).NotTo(HaveOccurred())
 				Expect(landedWorkers).To(ContainElement(defaultWorker.Name()))
 			})
@@ -2,9 +2,12 @@ package db
 
 import (
 	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"maps"
+	"slices"
 	"strings"
 	"time"
 
@@ -19,6 +22,7 @@ var (
 	ErrWorkerNotPresent         = errors.New("worker not present in db")
 	ErrTooManyActiveTasks       = errors.New("worker has too many active tasks")
 	ErrCannotPruneRunningWorker = errors.New("worker not stalled for pruning")
+	ErrUnknownWorkerState       = errors.New("unknown worker state")
 )
 
 type ContainerOwnerDisappearedError struct {
@@ -32,11 +36,14 @@ func (e ContainerOwnerDisappearedError) Error() string {
 type WorkerState string
 
 const (
-	WorkerStateRunning  = WorkerState("running")
-	WorkerStateStalled  = WorkerState("stalled")
-	WorkerStateLanding  = WorkerState("landing")
-	WorkerStateLanded   = WorkerState("landed")
-	WorkerStateRetiring = WorkerState("retiring")
+	WorkerStateRunning     = WorkerState("running")
+	WorkerStateStalled     = WorkerState("stalled")
+	WorkerStateLanding     = WorkerState("landing")
+	WorkerStateLanded      = WorkerState("landed")
+	WorkerStateDraining    = WorkerState("draining")
+	WorkerStateRetiring    = WorkerState("retiring")
+	WorkerStateDeleted     = WorkerState("deleted")
+	WorkerStateQuarantined = WorkerState("quarantined")
 )
 
 func AllWorkerStates() []WorkerState {
@@ -45,10 +52,120 @@ func AllWorkerStates() []WorkerState {
 		WorkerStateStalled,
 		WorkerStateLanding,
 		WorkerStateLanded,
+		WorkerStateDraining,
 		WorkerStateRetiring,
+		WorkerStateDeleted,
+		WorkerStateQuarantined,
 	}
 }
 
+// IsActive reports whether a worker in the state is up, even if it is on its
+// way out or held back from taking on work. A stalled worker is not active,
+// but may still come back.
+func (state WorkerState) IsActive() bool {
+	switch state {
+	case WorkerStateRunning, WorkerStateLanding, WorkerStateDraining, WorkerStateRetiring, WorkerStateQuarantined:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsTerminal reports whether a worker in the state has landed or has been
+// deleted. A landed worker may still register again.
+func (state WorkerState) IsTerminal() bool {
+	switch state {
+	case WorkerStateLanded, WorkerStateDeleted:
+		return true
+	default:
+		return false
+	}
+}
+
+// CanAcceptWork reports whether new containers and volumes may be placed on a
+// worker in the state, which only running workers accept.
+func (state WorkerState) CanAcceptWork() bool {
+	return state == WorkerStateRunning
+}
+
+// WorkerStateTransitions lists, for every worker state, the states a worker
+// may move to from it. Workers are deleted rather than moved out of the
+// retiring state, and deleted workers are tombstones which are only ever
+// purged. Quarantined workers are left alone by the lifecycle until they are
+// released back to running. No state lists itself, so the bulk transitions
+// only ever return the workers they actually moved.
+var WorkerStateTransitions = map[WorkerState][]WorkerState{
+	WorkerStateRunning:     {WorkerStateStalled, WorkerStateLanding, WorkerStateDraining, WorkerStateRetiring, WorkerStateQuarantined},
+	WorkerStateStalled:     {WorkerStateRunning, WorkerStateQuarantined},
+	WorkerStateLanding:     {WorkerStateLanded, WorkerStateRetiring, WorkerStateQuarantined},
+	WorkerStateLanded:      {WorkerStateRunning},
+	WorkerStateDraining:    {WorkerStateLanded, WorkerStateQuarantined},
+	WorkerStateRetiring:    {},
+	WorkerStateDeleted:     {},
+	WorkerStateQuarantined: {WorkerStateRunning},
+}
+
+// ValidWorkerTransition returns whether a worker may move from one state to
+// another according to WorkerStateTransitions.
+func ValidWorkerTransition(from, to WorkerState) bool {
+	return slices.Contains(WorkerStateTransitions[from], to)
+}
+
+// Scan implements sql.Scanner. It fails with ErrUnknownWorkerState rather
+// than letting a state this ATC does not know about reach the scheduler.
+func (state *WorkerState) Scan(src any) error {
+	var value string
+	switch src := src.(type) {
+	case string:
+		value = src
+	case []byte:
+		value = string(src)
+	default:
+		return fmt.Errorf("cannot scan %T into a worker state", src)
+	}
+
+	if !slices.Contains(AllWorkerStates(), WorkerState(value)) {
+		return fmt.Errorf("%w: %q", ErrUnknownWorkerState, value)
+	}
+
+	*state = WorkerState(value)
+
+	return nil
+}
+
+// Value implements driver.Valuer, refusing to write unknown states.
+func (state WorkerState) Value() (driver.Value, error) {
+	if !slices.Contains(AllWorkerStates(), state) {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownWorkerState, string(state))
+	}
+
+	return string(state), nil
+}
+
+// MarshalJSON implements json.Marshaler, encoding the state as its plain
+// string form, which the API relies on.
+func (state WorkerState) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(state))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It fails with
+// ErrUnknownWorkerState for states this ATC does not know about.
+func (state *WorkerState) UnmarshalJSON(data []byte) error {
+	var value string
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+
+	if !slices.Contains(AllWorkerStates(), WorkerState(value)) {
+		return fmt.Errorf("%w: %q", ErrUnknownWorkerState, value)
+	}
+
+	*state = WorkerState(value)
+
+	return nil
+}
+
 //counterfeiter:generate . Worker
 type Worker interface {
 	Name() string
@@ -265,7 +265,9 @@ func (f *workerFactory) HeartbeatWorker(atcWorker atc.Worker, ttl time.Duration)
 	cSQL, _, err := sq.Case("state").
 		When("'landing'::worker_state", "'landing'::worker_state").
 		When("'landed'::worker_state", "'landed'::worker_state").
+		When("'draining'::worker_state", "'draining'::worker_state").
 		When("'retiring'::worker_state", "'retiring'::worker_state").
+		When("'quarantined'::worker_state", "'quarantined'::worker_state").
 		Else("'running'::worker_state").
 		ToSql()
 
@@ -282,7 +284,11 @@ func (f *workerFactory) HeartbeatWorker(atcWorker atc.Worker, ttl time.Duration)
 		// considered stalled. Clearing stalled_since ensures a worker that
 		// recovers from a transient disconnect resets its stall grace period.
 		Set("stalled_since", nil).
+		Set("missed_heartbeats", 0).
 		Where(sq.Eq{"name": atcWorker.Name}).
+		// Deleted workers are only kept around as tombstones, so they have to
+		// register again rather than come back through a heartbeat.
+		Where(sq.NotEq{"state": string(WorkerStateDeleted)}).
 		RunWith(tx).
 		Exec()
 	if err != nil {
@@ -293,6 +299,7 @@ func (f *workerFactory) HeartbeatWorker(atcWorker atc.Worker, ttl time.Duration)
 	}
 
 	row := workersQuery.Where(sq.Eq{"w.name": atcWorker.Name}).
+		Where(sq.NotEq{"w.state": string(WorkerStateDeleted)}).
 		RunWith(tx).
 		QueryRow()
 
@@ -421,7 +428,9 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		conflictValues = append(conflictValues, *teamID)
 	}
 
-	rows, err := psql.Insert("workers").
+	// A quarantined worker stays quarantined when it registers again, as it
+	// does when it heartbeats.
+	err = psql.Insert("workers").
 		Columns(
 			"expires",
 			"start_time",
@@ -463,27 +472,27 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 				no_proxy = ?,
 				name = ?,
 				version = ?,
-				state = ?,
+				state = CASE workers.state WHEN 'quarantined'::worker_state THEN workers.state ELSE ?::worker_state END,
 				team_id = ?,
-				ephemeral = ?
-			WHERE `+matchTeamUpsert,
+				ephemeral = ?,
+				deleted_at = NULL,
+				missed_heartbeats = 0,
+				ever_landed = false,
+				upgrade_pending = false
+			WHERE `+matchTeamUpsert+`
+			RETURNING state`,
 			conflictValues...,
 		).
 		RunWith(tx).
-		Exec()
-	if err != nil {
-		return nil, err
-	}
-
-	count, err := rows.RowsAffected()
+		QueryRow().
+		Scan(&workerState)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("worker already exists and is either global or owned by another team")
+		}
 		return nil, err
 	}
 
-	if count == 0 {
-		return nil, errors.New("worker already exists and is either global or owned by another team")
-	}
-
 	var workerTeamID int
 	if teamID != nil {
 		workerTeamID = *teamID
@@ -1,6 +1,7 @@
 package db_test
 
 import (
+	"context"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
@@ -149,7 +150,7 @@ var _ = Describe("WorkerFactory", func() {
 					_, err := workerFactory.SaveWorker(atcWorker, -5*time.Minute)
 					Expect(err).NotTo(HaveOccurred())
 
-					_, err = workerLifecycle.StallUnresponsiveWorkers()
+					_, err = workerLifecycle.StallUnresponsiveWorkers(context.Background())
 					Expect(err).NotTo(HaveOccurred())
 				})
 
@@ -251,7 +252,7 @@ var _ = Describe("WorkerFactory", func() {
 				BeforeEach(func() {
 					_, err := workerFactory.SaveWorker(atcWorker, -1*time.Minute)
 					Expect(err).NotTo(HaveOccurred())
-					stalled, err := workerLifecycle.StallUnresponsiveWorkers()
+					stalled, err := workerLifecycle.StallUnresponsiveWorkers(context.Background())
 					Expect(err).NotTo(HaveOccurred())
 					Expect(stalled).To(ContainElement("some-name"))
 				})
@@ -485,7 +486,7 @@ var _ = Describe("WorkerFactory", func() {
 					unresponsiveWorker, err = workerFactory.SaveWorker(atcWorker, -5*time.Minute)
 					Expect(err).NotTo(HaveOccurred())
 
-					_, err = workerLifecycle.StallUnresponsiveWorkers()
+					_, err = workerLifecycle.StallUnresponsiveWorkers(context.Background())
 					Expect(err).NotTo(HaveOccurred())
 				})
 
@@ -1,246 +1,2893 @@
 package db
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"code.cloudfoundry.org/lager/v3/lagerctx"
 	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db/lock"
+	"github.com/jackc/pgx/v5"
 )
 
+// WorkerLifecycle moves workers through their states. Mutating operations
+// which fail part way return the workers they already affected with the error.
+//
 //counterfeiter:generate . WorkerLifecycle
 type WorkerLifecycle interface {
-	DeleteUnresponsiveEphemeralWorkers() ([]string, error)
-	StallUnresponsiveWorkers() ([]string, error)
-	DeleteStalledWorkers(timeout time.Duration) ([]string, error)
-	LandFinishedLandingWorkers() ([]string, error)
-	DeleteFinishedRetiringWorkers() ([]string, error)
-	GetWorkerStateByName() (map[string]WorkerState, error)
+	DeleteUnresponsiveEphemeralWorkers(ctx context.Context) ([]string, error)
+	DeleteUnresponsiveEphemeralWorkersDetailed(ctx context.Context) ([]DeletedWorker, error)
+	DeleteUnresponsiveEphemeralWorkersExcept(ctx context.Context, protected []string) ([]string, error)
+	DeleteUnresponsiveEphemeralWorkersWithSkew(ctx context.Context, skew time.Duration) ([]string, error)
+	DeleteUnresponsiveEphemeralWorkersCount(ctx context.Context) (int, error)
+	GetDeletableEphemeralWorkers(ctx context.Context) ([]string, error)
+	StallUnresponsiveWorkers(ctx context.Context) ([]string, error)
+	StallUnresponsiveWorkersWithGrace(ctx context.Context, grace time.Duration) ([]string, error)
+	StallUnresponsiveWorkersOfKind(ctx context.Context, kind WorkerKind) ([]string, error)
+	StallUnresponsiveWorkersTx(ctx context.Context, tx Tx) ([]string, error)
+	StallUnresponsiveWorkersWithPreviousState(ctx context.Context) ([]WorkerTransition, error)
+	StallUnresponsiveWorkersDetailed(ctx context.Context) ([]WorkerAffected, error)
+	StallUnresponsiveWorkersCount(ctx context.Context) (int, error)
+	DeleteStalledWorkers(ctx context.Context, timeout time.Duration) ([]string, error)
+	DeleteExpiredStalledWorkers(ctx context.Context) ([]string, error)
+	DeleteStalledWorkersCount(ctx context.Context, timeout time.Duration) (int, error)
+	LandAllWorkers(ctx context.Context) ([]string, error)
+	LandWorkersWithTag(ctx context.Context, tag string) ([]string, error)
+	LandUnreachableWorkers(ctx context.Context, threshold time.Duration) ([]string, error)
+	LandFinishedLandingWorkers(ctx context.Context) ([]string, error)
+	LandFinishedLandingWorkersWithDuration(ctx context.Context) ([]LandedWorker, error)
+	LandFinishedLandingWorkersDetailed(ctx context.Context) ([]WorkerAffected, error)
+	LandFinishedLandingWorkersForPlatform(ctx context.Context, platform string) ([]string, error)
+	LandFinishedLandingWorkersKeepMinimum(ctx context.Context, minPerTeam int) ([]string, error)
+	LandFinishedLandingWorkersForWorkers(ctx context.Context, names []string) ([]string, error)
+	LandWorkersInterruptingBuilds(ctx context.Context) ([]string, error)
+	LandFinishedLandingWorkersCount(ctx context.Context) (int, error)
+	DeleteFinishedRetiringWorkers(ctx context.Context) ([]string, error)
+	DeleteFinishedRetiringWorkersWithPreviousState(ctx context.Context) ([]WorkerTransition, error)
+	DeleteFinishedRetiringWorkersDetailed(ctx context.Context) ([]WorkerAffected, error)
+	DeleteFinishedRetiringWorkersBatch(ctx context.Context, limit int) ([]string, error)
+	ForceDeleteRetiringWorkers(ctx context.Context) ([]string, error)
+	DeleteFinishedRetiringWorkersCount(ctx context.Context) (int, error)
+	PurgeDeletedWorkers(ctx context.Context, olderThan time.Duration) ([]string, error)
+	PruneWorkerStateHistory(ctx context.Context, olderThan time.Duration) (int, error)
+	DeleteWorkersForDeletedTeams(ctx context.Context) ([]string, error)
+	ReconcileWorkers(ctx context.Context, existing []string) ([]string, error)
+	CleanWorkerResourceCaches(ctx context.Context, workerName string) (int, error)
+	OrphanContainersForWorker(ctx context.Context, workerName string) (int, error)
+	ProcessFinishedWorkers(ctx context.Context) (landed []string, retired []string, err error)
+	ExpireWorker(ctx context.Context, name string) error
+	HeartbeatWorker(ctx context.Context, name string, ttl time.Duration) (bool, error)
+	HeartbeatWorkers(ctx context.Context, names []string, ttl time.Duration) ([]string, error)
+	ExtendEphemeralExpiry(ctx context.Context, name string, by time.Duration) (bool, error)
+	MarkBaggageclaimHealth(ctx context.Context, name string, healthy bool) error
+	DrainWorker(ctx context.Context, name string) error
+	MarkWorkerForUpgrade(ctx context.Context, name string) error
+	RestoreUpgradedWorkers(ctx context.Context, names []string) ([]string, error)
+	ClaimWorkerForLanding(ctx context.Context, name string, atcID string, lease time.Duration) (bool, error)
+	QuarantineWorker(ctx context.Context, name string) error
+	UnquarantineWorker(ctx context.Context, name string) error
+	ResurrectWorker(ctx context.Context, name string, addr, baggageclaimURL string, ttl time.Duration) error
+	TransitionWorker(ctx context.Context, name string, from, to WorkerState) (int, error)
+	SetWorkerStates(ctx context.Context, names []string, state WorkerState) ([]string, error)
+	GetWorkerStateByName(ctx context.Context) (map[string]WorkerState, error)
+	GetWorkerStateByNameForTeam(ctx context.Context, teamID int) (map[string]WorkerState, error)
+	GetWorkersInState(ctx context.Context, state WorkerState) ([]string, error)
+	GetWorkerStateHistory(ctx context.Context, name string) ([]StateTransition, error)
+	FindUnhealthyBaggageclaimWorkers(ctx context.Context) ([]string, error)
+	StreamWorkerStates(ctx context.Context, fn func(name string, state WorkerState) error) error
+	GetWorkerStatesPaged(ctx context.Context, limit, offset int) (map[string]WorkerState, error)
+	GetWorkerStatesWithTeam(ctx context.Context) (map[string]WorkerStateInfo, error)
+	GetWorkerStatesWithTags(ctx context.Context) (map[string]WorkerStateTags, error)
+	GetWorkerStatesWithCapacity(ctx context.Context) (map[string]WorkerCapacity, error)
+	FindWorkersWithHeadroom(ctx context.Context, minFree int, limit int) ([]WorkerHeadroom, error)
+	GetWorkerHeartbeatAges(ctx context.Context) (map[string]time.Duration, error)
+	GetWorkerUptimes(ctx context.Context) (map[string]time.Duration, error)
+	OldestExpiredWorkerAge(ctx context.Context) (time.Duration, bool, error)
+	TotalActiveContainers(ctx context.Context) (int, error)
+	EphemeralExpiryHistogram(ctx context.Context, buckets []time.Duration) (map[time.Duration]int, error)
+	GetWorkerProcessors(ctx context.Context) (map[string]string, error)
+	GetRunningWorkerAddresses(ctx context.Context) (map[string]string, error)
+	GetPendingContainerDestroysByWorker(ctx context.Context) (map[string]int, error)
+	GetBuildsBlockingWorkerLanding(ctx context.Context, workerName string) ([]BlockingBuild, error)
+	EstimateLandingDrainTimes(ctx context.Context) (map[string]time.Duration, error)
+	FindExpiredWorkersWithActiveBuilds(ctx context.Context) ([]string, error)
+
+	DeleteUnresponsiveEphemeralWorkersSQL() (string, []any, error)
+	StallUnresponsiveWorkersSQL(grace time.Duration) (string, []any, error)
+	DeleteStalledWorkersSQL(timeout time.Duration) (string, []any, error)
+	LandFinishedLandingWorkersSQL() (string, []any, error)
+	DeleteFinishedRetiringWorkersSQL() (string, []any, error)
+	CountWorkersByState(ctx context.Context) (map[WorkerState]int, error)
+	CountWorkersByTeamAndState(ctx context.Context) (map[string]map[WorkerState]int, error)
+	WorkerAgeStatsByState(ctx context.Context) (map[WorkerState]AgeStats, error)
+	GetChronicallyStalledWorkers(ctx context.Context, threshold int) ([]string, error)
+	FindWorkersMissingHeartbeats(ctx context.Context, n int) ([]string, error)
+	FindIdleWorkers(ctx context.Context, idleFor time.Duration) ([]string, error)
+	FindScaleDownCandidates(ctx context.Context, idleFor time.Duration, limit int) ([]string, error)
+	FindDuplicateWorkerAddresses(ctx context.Context) (map[string][]string, error)
+	FindStuckRetiringWorkers(ctx context.Context, stuckFor time.Duration) ([]string, error)
+	FindNeverLandedWorkers(ctx context.Context) ([]string, error)
+	FindWorkersBlockedByDeletedJobs(ctx context.Context) ([]string, error)
+	FindWorkersWithOutdatedResourceTypes(ctx context.Context, expected map[string]string) ([]string, error)
+	FindInconsistentWorkers(ctx context.Context) ([]WorkerInconsistency, error)
+	LifecycleStats() LifecycleCounters
+	WithLifecycleLock(ctx context.Context, fn func() error) error
 }
 
-type workerLifecycle struct {
+// LifecycleCounters are the rows affected by each successful lifecycle
+// operation over the window starting at Since.
+type LifecycleCounters struct {
+	Since        time.Time
+	RowsAffected map[string]int
+}
+
+// DeletedWorker describes a worker row as it was at the moment it was
+// deleted by the lifecycle.
+type DeletedWorker struct {
+	Name     string
+	TeamName string
+	Addr     *string
+	Expires  time.Time
+	State    WorkerState
+	Reason   string
+}
+
+// LifecycleObserver is notified of every worker state transition performed
+// by the WorkerLifecycle. Deleted workers are reported with an empty to state.
+//
+//counterfeiter:generate . LifecycleObserver
+type LifecycleObserver interface {
+	WorkerStateChanged(name string, from, to WorkerState, reason string)
+}
+
+// WorkerLifecycleNotifyChannel is the channel the lifecycle sends a NOTIFY on
+// for every worker state transition when NotifyStateChanges is set.
+const WorkerLifecycleNotifyChannel = "worker_lifecycle"
+
+// WorkerStateChangeNotification is the JSON payload of the NOTIFY sent on
+// WorkerLifecycleNotifyChannel.
+type WorkerStateChangeNotification struct {
+	Name   string      `json:"name"`
+	State  WorkerState `json:"state"`
+	Reason string      `json:"reason"`
+}
+
+// notifyingLifecycleObserver sends a best effort NOTIFY for every transition
+// before passing it on to the next observer, if any.
+type notifyingLifecycleObserver struct {
 	conn DbConn
+	ctx  context.Context
+	next LifecycleObserver
+}
+
+func (observer notifyingLifecycleObserver) WorkerStateChanged(name string, from, to WorkerState, reason string) {
+	state := to
+	if state == "" {
+		state = WorkerStateDeleted
+	}
+
+	payload, err := json.Marshal(WorkerStateChangeNotification{
+		Name:   name,
+		State:  state,
+		Reason: reason,
+	})
+	if err == nil {
+		_, _ = observer.conn.ExecContext(observer.ctx, "SELECT pg_notify($1, $2)", WorkerLifecycleNotifyChannel, string(payload))
+	}
+
+	if observer.next != nil {
+		observer.next.WorkerStateChanged(name, from, to, reason)
+	}
+}
+
+// LifecycleMetricsEmitter is told how long every query run by the
+// WorkerLifecycle took and how many rows it affected.
+//
+//counterfeiter:generate . LifecycleMetricsEmitter
+type LifecycleMetricsEmitter interface {
+	LifecycleQueryCompleted(operation string, duration time.Duration, rowsAffected int)
+}
+
+type noopLifecycleMetricsEmitter struct{}
+
+func (noopLifecycleMetricsEmitter) LifecycleQueryCompleted(string, time.Duration, int) {}
+
+// The reasons for which the lifecycle moves workers, as passed to the
+// LifecycleObserver and carried by the workers the lifecycle returns.
+const (
+	WorkerTransitionReasonHeartbeatExpired = "heartbeat-expired"
+	WorkerTransitionReasonEphemeralExpired = "ephemeral-expired"
+	WorkerTransitionReasonStallTimeout     = "stall-timeout"
+	WorkerTransitionReasonLandingComplete  = "landing-complete"
+	WorkerTransitionReasonRetireComplete   = "retire-complete"
+	WorkerTransitionReasonForceRetired     = "force-retired"
+	WorkerTransitionReasonLandAll          = "land-all"
+	WorkerTransitionReasonLandTagged       = "land-tagged"
+	WorkerTransitionReasonUnreachable      = "unreachable"
+	WorkerTransitionReasonRequested        = "requested"
+	WorkerTransitionReasonPurged           = "purged"
+	WorkerTransitionReasonTeamDeleted      = "team-deleted"
+	WorkerTransitionReasonReconciled       = "reconciled"
+	WorkerTransitionReasonDrain            = "drain"
+	WorkerTransitionReasonUpgrade          = "upgrade"
+	WorkerTransitionReasonUpgraded         = "upgraded"
+	WorkerTransitionReasonResurrected      = "resurrected"
+	WorkerTransitionReasonQuarantined      = "quarantined"
+	WorkerTransitionReasonUnquarantined    = "unquarantined"
+)
+
+// WorkerKind selects workers by whether they are ephemeral.
+type WorkerKind string
+
+// The zero WorkerKind selects any worker, like WorkerKindAny.
+const (
+	WorkerKindAny        WorkerKind = "any"
+	WorkerKindEphemeral  WorkerKind = "ephemeral"
+	WorkerKindPersistent WorkerKind = "persistent"
+)
+
+// GlobalWorkersTeamName is the team name CountWorkersByTeamAndState counts the
+// global workers under.
+const GlobalWorkersTeamName = ""
+
+// ErrInvalidWorkerTransition is returned when asked to move a worker between
+// two states which are not connected by WorkerStateTransitions.
+var ErrInvalidWorkerTransition = errors.New("invalid worker state transition")
+
+// ErrNoLifecycleLockFactory is returned by WithLifecycleLock when the
+// lifecycle was created without a LockFactory.
+var ErrNoLifecycleLockFactory = errors.New("worker lifecycle has no lock factory")
+
+// ErrNoExistingWorkers is returned by ReconcileWorkers when given no existing
+// workers.
+var ErrNoExistingWorkers = errors.New("no existing workers to reconcile with")
+
+// ErrDryRunClaim is returned by ClaimWorkerForLanding in dry-run mode.
+var ErrDryRunClaim = errors.New("cannot claim workers for landing in dry-run mode")
+
+// LifecycleQueryError is returned when a statement of a lifecycle operation
+// fails for good. It names the operation and unwraps to the statement's error.
+type LifecycleQueryError struct {
+	Operation string
+	Err       error
+}
+
+func (e LifecycleQueryError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Operation, e.Err)
+}
+
+func (e LifecycleQueryError) Unwrap() error {
+	return e.Err
+}
+
+// WorkerLifecycleOptions configures the behaviour of a WorkerLifecycle.
+type WorkerLifecycleOptions struct {
+	// Observer, if set, is notified of every worker state transition.
+	Observer LifecycleObserver
+
+	// SoftDeleteEphemeralWorkers makes DeleteUnresponsiveEphemeralWorkers leave
+	// tombstones in the deleted state until PurgeDeletedWorkers deletes them.
+	SoftDeleteEphemeralWorkers bool
+
+	// DryRun makes the mutating operations select the workers they would
+	// affect instead of changing them.
+	DryRun bool
+
+	// Emitter, if set, is told the duration and number of affected rows of
+	// every query.
+	Emitter LifecycleMetricsEmitter
+
+	// Retries is how many times a deadlocked or serialization-failed statement is
+	// retried. Zero means 3, and a negative value disables retrying.
+	Retries int
+
+	// MaxRetryBackoff caps the delay before a retry. Zero means one second.
+	MaxRetryBackoff time.Duration
+
+	// ATCID, if set, is recorded on every worker the lifecycle changes, and is
+	// the ATC whose landing claims LandFinishedLandingWorkers honours.
+	ATCID string
+
+	// StallTTL is how long DeleteExpiredStalledWorkers keeps the stalled workers
+	// which have no stall_ttl of their own.
+	StallTTL time.Duration
+
+	// StallExemptLabel, if set, is a label key exempting the workers carrying it
+	// from being stalled.
+	StallExemptLabel string
+
+	// LockFactory is used by WithLifecycleLock to make sure that only one ATC
+	// runs the lifecycle at a time.
+	LockFactory lock.LockFactory
+
+	// NotifyStateChanges makes the lifecycle send a NOTIFY on
+	// WorkerLifecycleNotifyChannel for every worker state transition.
+	NotifyStateChanges bool
+
+	// Context, if set, is the base context of every query, aborting them when it
+	// is cancelled.
+	Context context.Context
+
+	// Table is the, optionally schema qualified, table holding the workers. It
+	// defaults to workers.
+	Table string
+
+	// EphemeralWorkerPredicate, if set, returns an extra predicate, qualified with
+	// workers, which unresponsive ephemeral workers have to match to be deleted.
+	EphemeralWorkerPredicate func() sq.Sqlizer
+}
+
+const (
+	defaultWorkerLifecycleRetries         = 3
+	defaultWorkerLifecycleMaxRetryBackoff = time.Second
+	defaultWorkersTable                   = "workers"
+)
+
+// LandedWorker describes a worker landed by the lifecycle along with the state
+// it was landed from, how long it spent in it and the capacity it took with it.
+type LandedWorker struct {
+	Name             string
+	From             WorkerState
+	LandingDuration  time.Duration
+	ActiveContainers int
+	ActiveVolumes    int
+	Reason           string
+}
+
+// WorkerTransition describes a worker moved by the lifecycle along with its
+// previous state and the reason. To is empty for deleted workers.
+type WorkerTransition struct {
+	Name   string
+	From   WorkerState
+	To     WorkerState
+	Reason string
+}
+
+// WorkerAffected names a worker stalled, landed or deleted by the lifecycle
+// along with the WorkerTransitionReason it was affected for.
+type WorkerAffected struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+type workerLifecycle struct {
+	conn       DbConn
+	ctx        context.Context
+	atcID      string
+	observer   LifecycleObserver
+	softDelete bool
+	dryRun     bool
+	emitter    LifecycleMetricsEmitter
+	retries    int
+	table      string
+
+	maxRetryBackoff time.Duration
+
+	stallTTL         time.Duration
+	stallExemptLabel string
+	lockFactory      lock.LockFactory
+
+	ephemeralWorkerPredicate func() sq.Sqlizer
+
+	countersLock sync.Mutex
+	counters     LifecycleCounters
+}
+
+// NewWorkerLifecycle returns a WorkerLifecycle that reports the transitions it
+// performs to observer. The observer may be nil.
+func NewWorkerLifecycle(conn DbConn, observer LifecycleObserver) WorkerLifecycle {
+	return NewWorkerLifecycleWithOptions(conn, WorkerLifecycleOptions{
+		Observer: observer,
+	})
 }
 
-func NewWorkerLifecycle(conn DbConn) WorkerLifecycle {
+func NewWorkerLifecycleWithOptions(conn DbConn, opts WorkerLifecycleOptions) WorkerLifecycle {
+	emitter := opts.Emitter
+	if emitter == nil {
+		emitter = noopLifecycleMetricsEmitter{}
+	}
+
+	retries := opts.Retries
+	if retries == 0 {
+		retries = defaultWorkerLifecycleRetries
+	} else if retries < 0 {
+		retries = 0
+	}
+
+	maxRetryBackoff := opts.MaxRetryBackoff
+	if maxRetryBackoff <= 0 {
+		maxRetryBackoff = defaultWorkerLifecycleMaxRetryBackoff
+	}
+
+	observer := opts.Observer
+	if opts.NotifyStateChanges {
+		notifyContext := opts.Context
+		if notifyContext == nil {
+			notifyContext = context.Background()
+		}
+
+		observer = notifyingLifecycleObserver{
+			conn: conn,
+			ctx:  notifyContext,
+			next: observer,
+		}
+	}
+
+	table := defaultWorkersTable
+	if opts.Table != "" {
+		table = pgx.Identifier(strings.Split(opts.Table, ".")).Sanitize()
+	}
+
 	return &workerLifecycle{
-		conn: conn,
+		conn:       conn,
+		ctx:        opts.Context,
+		atcID:      opts.ATCID,
+		observer:   observer,
+		softDelete: opts.SoftDeleteEphemeralWorkers,
+		dryRun:     opts.DryRun,
+		emitter:    emitter,
+		retries:    retries,
+		table:      table,
+
+		maxRetryBackoff: maxRetryBackoff,
+
+		stallTTL:         opts.StallTTL,
+		stallExemptLabel: opts.StallExemptLabel,
+		lockFactory:      opts.LockFactory,
+
+		ephemeralWorkerPredicate: opts.EphemeralWorkerPredicate,
+
+		counters: newLifecycleCounters(),
 	}
 }
 
-func (lifecycle *workerLifecycle) DeleteUnresponsiveEphemeralWorkers() ([]string, error) {
-	query, args, err := psql.Delete("workers").
-		Where(sq.Eq{"ephemeral": true}).
-		Where(sq.Expr("expires < NOW()")).
-		Suffix("RETURNING name").
-		ToSql()
+func newLifecycleCounters() LifecycleCounters {
+	return LifecycleCounters{
+		Since:        time.Now(),
+		RowsAffected: map[string]int{},
+	}
+}
 
-	if err != nil {
-		return []string{}, err
+// LifecycleStats returns the counters accumulated since the previous call and
+// starts a new window.
+func (lifecycle *workerLifecycle) LifecycleStats() LifecycleCounters {
+	lifecycle.countersLock.Lock()
+	defer lifecycle.countersLock.Unlock()
+
+	counters := lifecycle.counters
+	lifecycle.counters = newLifecycleCounters()
+
+	return counters
+}
+
+// WithLifecycleLock runs fn while holding a cluster-wide lock. If another ATC
+// holds the lock, fn is not run and nil is returned.
+func (lifecycle *workerLifecycle) WithLifecycleLock(ctx context.Context, fn func() error) error {
+	if lifecycle.lockFactory == nil {
+		return ErrNoLifecycleLockFactory
 	}
 
-	rows, err := lifecycle.conn.Query(query, args...)
+	logger := lagerctx.FromContext(ctx).Session("worker-lifecycle-lock")
+
+	lifecycleLock, acquired, err := lifecycle.lockFactory.Acquire(logger, lock.NewWorkerLifecycleLockID())
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return workersAffected(rows)
+	if !acquired {
+		logger.Debug("lock-unavailable")
+		return nil
+	}
+
+	defer lifecycleLock.Release()
+
+	return fn()
 }
 
-func (lifecycle *workerLifecycle) StallUnresponsiveWorkers() ([]string, error) {
-	query, args, err := psql.Update("workers").
-		SetMap(map[string]any{
-			"state":         string(WorkerStateStalled),
-			"expires":       nil,
-			"stalled_since": sq.Expr("NOW()"),
-		}).
-		Where(sq.Eq{"state": string(WorkerStateRunning)}).
-		Where(sq.Expr("expires < NOW()")).
-		Suffix("RETURNING name").
-		ToSql()
+func (lifecycle *workerLifecycle) DeleteUnresponsiveEphemeralWorkers(ctx context.Context) ([]string, error) {
+	deletedWorkers, err := lifecycle.DeleteUnresponsiveEphemeralWorkersDetailed(ctx)
+
+	return deletedWorkerNames(deletedWorkers), err
+}
+
+// deletedWorkerColumns selects the columns of a DeletedWorker from the workers
+// aliased by the given name.
+func deletedWorkerColumns(alias string) string {
+	return fmt.Sprintf(`
+			%[1]s.name,
+			(SELECT t.name FROM teams t WHERE t.id = %[1]s.team_id),
+			%[1]s.addr,
+			%[1]s.expires,
+			%[1]s.state`, alias)
+}
+
+func (lifecycle *workerLifecycle) DeleteUnresponsiveEphemeralWorkersDetailed(ctx context.Context) ([]DeletedWorker, error) {
+	return lifecycle.deleteUnresponsiveEphemeralWorkers(ctx, nil, 0)
+}
+
+// DeleteUnresponsiveEphemeralWorkersExcept behaves like
+// DeleteUnresponsiveEphemeralWorkers but never deletes the protected workers.
+func (lifecycle *workerLifecycle) DeleteUnresponsiveEphemeralWorkersExcept(ctx context.Context, protected []string) ([]string, error) {
+	deletedWorkers, err := lifecycle.deleteUnresponsiveEphemeralWorkers(ctx, protected, 0)
+
+	return deletedWorkerNames(deletedWorkers), err
+}
+
+// DeleteUnresponsiveEphemeralWorkersWithSkew behaves like
+// DeleteUnresponsiveEphemeralWorkers but only once a heartbeat expired skew ago.
+func (lifecycle *workerLifecycle) DeleteUnresponsiveEphemeralWorkersWithSkew(ctx context.Context, skew time.Duration) ([]string, error) {
+	deletedWorkers, err := lifecycle.deleteUnresponsiveEphemeralWorkers(ctx, nil, skew)
+
+	return deletedWorkerNames(deletedWorkers), err
+}
+
+func (lifecycle *workerLifecycle) deleteUnresponsiveEphemeralWorkers(ctx context.Context, protected []string, skew time.Duration) ([]DeletedWorker, error) {
+	var deletedWorkers []DeletedWorker
+	err := lifecycle.run(ctx, "delete-unresponsive-ephemeral-workers", func(ctx context.Context) (int, error) {
+		query, args, err := lifecycle.deletedWorkersSQL(lifecycle.unresponsiveEphemeralWorkers(protected, skew))
+		if err != nil {
+			return 0, err
+		}
+
+		deletedWorkers, err = lifecycle.deleteWorkersOrphaningContainers(ctx, query, args)
+		return len(deletedWorkers), err
+	})
+	var to WorkerState
+	if lifecycle.softDelete {
+		to = WorkerStateDeleted
+	}
+
+	for _, deletedWorker := range deletedWorkers {
+		lifecycle.workerStateChanged(deletedWorker.Name, deletedWorker.State, to, deletedWorker.Reason)
+	}
+
 	if err != nil {
-		return []string{}, err
+		return deletedWorkers, err
+	}
+
+	return deletedWorkers, nil
+}
+
+// deleteWorkersOrphaningContainers runs the statement deleting the workers and,
+// for soft deleted ones, marks their containers as destroying in the same tx.
+func (lifecycle *workerLifecycle) deleteWorkersOrphaningContainers(ctx context.Context, query string, args []any) ([]DeletedWorker, error) {
+	if !lifecycle.softDelete || lifecycle.dryRun {
+		return scanDeletedWorkers(lifecycle.conn.QueryContext(ctx, query, args...))
 	}
 
-	rows, err := lifecycle.conn.Query(query, args...)
+	tx, err := lifecycle.conn.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	return workersAffected(rows)
-}
+	defer Rollback(tx)
 
-func (lifecycle *workerLifecycle) DeleteStalledWorkers(timeout time.Duration) ([]string, error) {
-	query, args, err := psql.Delete("workers").
-		Where(sq.Eq{"state": string(WorkerStateStalled)}).
-		Where(sq.Expr(
-			fmt.Sprintf("stalled_since < NOW() - '%d second'::INTERVAL", int(timeout.Seconds())),
-		)).
-		Suffix("RETURNING name").
-		ToSql()
+	deletedWorkers, err := scanDeletedWorkers(tx.QueryContext(ctx, query, args...))
 	if err != nil {
-		return []string{}, err
+		return nil, err
+	}
+
+	if len(deletedWorkers) > 0 {
+		_, err = psql.Update("containers").
+			Set("state", atc.ContainerStateDestroying).
+			Where(sq.Eq{
+				"worker_name": deletedWorkerNames(deletedWorkers),
+				"state":       []string{atc.ContainerStateCreating, atc.ContainerStateCreated},
+			}).
+			RunWith(tx).
+			ExecContext(ctx)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	rows, err := lifecycle.conn.Query(query, args...)
+	err = tx.Commit()
 	if err != nil {
 		return nil, err
 	}
 
-	return workersAffected(rows)
+	return deletedWorkers, nil
 }
 
-func (lifecycle *workerLifecycle) DeleteFinishedRetiringWorkers() ([]string, error) {
-	// Squirrel does not have default support for subqueries in where clauses.
-	// We hacked together a way to do it
-	//
-	// First we generate the subquery's SQL and args using
-	// sq.Select instead of psql.Select so that we get
-	// unordered placeholders instead of psql's ordered placeholders
-	subQ, subQArgs, err := sq.Select("w.name").
-		Distinct().
-		From("builds b").
-		Join("containers c ON b.id = c.build_id").
-		Join("workers w ON w.name = c.worker_name").
-		LeftJoin("jobs j ON j.id = b.job_id").
-		Where(sq.Eq{"b.completed": false}).
-		Where(sq.Or{
-			sq.Eq{
-				"j.interruptible": false,
-			},
-			sq.Eq{
-				"b.job_id": nil,
-			},
-		}).ToSql()
+func (lifecycle *workerLifecycle) StallUnresponsiveWorkers(ctx context.Context) ([]string, error) {
+	return lifecycle.StallUnresponsiveWorkersWithGrace(ctx, 0)
+}
+
+// StallUnresponsiveWorkersWithGrace stalls running workers whose heartbeat
+// expired more than grace ago.
+func (lifecycle *workerLifecycle) StallUnresponsiveWorkersWithGrace(ctx context.Context, grace time.Duration) ([]string, error) {
+	stalledWorkers, err := lifecycle.stallUnresponsiveWorkers(ctx, grace, WorkerKindAny)
+
+	return workerTransitionNames(stalledWorkers), err
+}
+
+// StallUnresponsiveWorkersOfKind stalls only the unresponsive workers of the
+// given kind.
+func (lifecycle *workerLifecycle) StallUnresponsiveWorkersOfKind(ctx context.Context, kind WorkerKind) ([]string, error) {
+	stalledWorkers, err := lifecycle.stallUnresponsiveWorkers(ctx, 0, kind)
+
+	return workerTransitionNames(stalledWorkers), err
+}
+
+// StallUnresponsiveWorkersWithPreviousState behaves like StallUnresponsiveWorkers
+// but returns the state each worker was stalled from.
+func (lifecycle *workerLifecycle) StallUnresponsiveWorkersWithPreviousState(ctx context.Context) ([]WorkerTransition, error) {
+	return lifecycle.stallUnresponsiveWorkers(ctx, 0, WorkerKindAny)
+}
+
+// StallUnresponsiveWorkersDetailed behaves like StallUnresponsiveWorkers but
+// returns the reason each worker was stalled for.
+func (lifecycle *workerLifecycle) StallUnresponsiveWorkersDetailed(ctx context.Context) ([]WorkerAffected, error) {
+	stalledWorkers, err := lifecycle.stallUnresponsiveWorkers(ctx, 0, WorkerKindAny)
+
+	return workerTransitionsAffected(stalledWorkers), err
+}
+
+// StallUnresponsiveWorkersTx behaves like StallUnresponsiveWorkers but runs in
+// the caller's transaction, and so is not retried.
+func (lifecycle *workerLifecycle) StallUnresponsiveWorkersTx(ctx context.Context, tx Tx) ([]string, error) {
+	ctx, cancel := lifecycle.queryContext(ctx)
+	defer cancel()
 
+	start := time.Now()
+
+	err := lifecycle.countMissedHeartbeats(ctx, tx, WorkerKindAny)
 	if err != nil {
-		return []string{}, err
+		return nil, LifecycleQueryError{Operation: "stall-unresponsive-workers", Err: err}
 	}
 
-	// Then we inject the subquery sql directly into
-	// the where clause, and "add" the args from the
-	// first query to the second query's args
-	//
-	// We use sq.Delete instead of psql.Delete for the same reason
-	// but then change the placeholders using .PlaceholderFormat(sq.Dollar)
-	// to go back to postgres's format
-	query, args, err := sq.Delete("workers").
-		Where(sq.Eq{
-			"state": string(WorkerStateRetiring),
-		}).
-		Where("name NOT IN ("+subQ+")", subQArgs...).
-		PlaceholderFormat(sq.Dollar).
-		Suffix("RETURNING name").
-		ToSql()
+	query, args, err := lifecycle.transitionsSQL(lifecycle.unresponsiveWorkers(0, WorkerKindAny))
+	if err != nil {
+		return nil, LifecycleQueryError{Operation: "stall-unresponsive-workers", Err: err}
+	}
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	stalledWorkers, err := scanWorkerTransitions(rows, err, WorkerStateStalled, WorkerTransitionReasonHeartbeatExpired)
+	lifecycle.workerTransitionsStateChanged(stalledWorkers)
 
 	if err != nil {
-		return []string{}, err
+		return workerTransitionNames(stalledWorkers), LifecycleQueryError{Operation: "stall-unresponsive-workers", Err: err}
 	}
 
-	rows, err := lifecycle.conn.Query(query, args...)
+	lifecycle.queryCompleted("stall-unresponsive-workers", start, len(stalledWorkers))
+
+	return workerTransitionNames(stalledWorkers), nil
+}
+
+func (lifecycle *workerLifecycle) stallUnresponsiveWorkers(ctx context.Context, grace time.Duration, kind WorkerKind) ([]WorkerTransition, error) {
+	err := lifecycle.retrying(ctx, "stall-unresponsive-workers", func(ctx context.Context) error {
+		return lifecycle.countMissedHeartbeats(ctx, lifecycle.conn, kind)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return workersAffected(rows)
+	var stalledWorkers []WorkerTransition
+	err = lifecycle.run(ctx, "stall-unresponsive-workers", func(ctx context.Context) (int, error) {
+		query, args, err := lifecycle.transitionsSQL(lifecycle.unresponsiveWorkers(grace, kind))
+		if err != nil {
+			return 0, err
+		}
+
+		rows, err := lifecycle.conn.QueryContext(ctx, query, args...)
+		stalledWorkers, err = scanWorkerTransitions(rows, err, WorkerStateStalled, WorkerTransitionReasonHeartbeatExpired)
+		return len(stalledWorkers), err
+	})
+	lifecycle.workerTransitionsStateChanged(stalledWorkers)
+
+	if err != nil {
+		return stalledWorkers, err
+	}
+
+	return stalledWorkers, nil
 }
 
-func (lifecycle *workerLifecycle) LandFinishedLandingWorkers() ([]string, error) {
-	subQ, subQArgs, err := sq.Select("w.name").
-		Distinct().
-		From("builds b").
-		Join("containers c ON b.id = c.build_id").
-		Join("workers w ON w.name = c.worker_name").
-		LeftJoin("jobs j ON j.id = b.job_id").
-		Where(sq.Eq{"b.completed": false}).
-		Where(sq.Or{
-			sq.Eq{
-				"j.interruptible": false,
-			},
-			sq.Eq{
-				"b.job_id": nil,
-			},
-		}).ToSql()
+func (lifecycle *workerLifecycle) DeleteStalledWorkers(ctx context.Context, timeout time.Duration) ([]string, error) {
+	var deletedWorkers []string
+	err := lifecycle.run(ctx, "delete-stalled-workers", func(ctx context.Context) (int, error) {
+		var err error
+		deletedWorkers, err = lifecycle.mutateWorkers(ctx, lifecycle.conn, lifecycle.stalledWorkersPastTimeout(timeout))
+		return len(deletedWorkers), err
+	})
+	lifecycle.workersStateChanged(deletedWorkers, WorkerStateStalled, "", WorkerTransitionReasonStallTimeout)
 
 	if err != nil {
-		return nil, err
+		return deletedWorkers, err
 	}
 
-	query, args, err := sq.Update("workers").
-		Set("state", string(WorkerStateLanded)).
-		Set("addr", nil).
-		Set("baggageclaim_url", nil).
-		Where(sq.Eq{
-			"state": string(WorkerStateLanding),
-		}).
-		Where("name NOT IN ("+subQ+")", subQArgs...).
-		PlaceholderFormat(sq.Dollar).
-		Suffix("RETURNING name").
-		ToSql()
+	return deletedWorkers, nil
+}
+
+// DeleteExpiredStalledWorkers deletes each stalled worker once it has been
+// stalled longer than its stall_ttl, or the StallTTL option if it has none.
+func (lifecycle *workerLifecycle) DeleteExpiredStalledWorkers(ctx context.Context) ([]string, error) {
+	var deletedWorkers []string
+	err := lifecycle.run(ctx, "delete-expired-stalled-workers", func(ctx context.Context) (int, error) {
+		var err error
+		deletedWorkers, err = lifecycle.mutateWorkers(ctx, lifecycle.conn, lifecycle.stalledWorkersPastTTL())
+		return len(deletedWorkers), err
+	})
+	lifecycle.workersStateChanged(deletedWorkers, WorkerStateStalled, "", WorkerTransitionReasonStallTimeout)
+
+	if err != nil {
+		return deletedWorkers, err
+	}
+
+	return deletedWorkers, nil
+}
+
+func (lifecycle *workerLifecycle) DeleteFinishedRetiringWorkers(ctx context.Context) ([]string, error) {
+	retiredWorkers, err := lifecycle.deleteFinishedRetiringWorkers(ctx, 0)
+
+	return workerTransitionNames(retiredWorkers), err
+}
+
+// DeleteFinishedRetiringWorkersWithPreviousState behaves like
+// DeleteFinishedRetiringWorkers but returns the state each worker was deleted from.
+func (lifecycle *workerLifecycle) DeleteFinishedRetiringWorkersWithPreviousState(ctx context.Context) ([]WorkerTransition, error) {
+	return lifecycle.deleteFinishedRetiringWorkers(ctx, 0)
+}
+
+// DeleteFinishedRetiringWorkersDetailed behaves like
+// DeleteFinishedRetiringWorkers but returns the reason each worker was deleted for.
+func (lifecycle *workerLifecycle) DeleteFinishedRetiringWorkersDetailed(ctx context.Context) ([]WorkerAffected, error) {
+	retiredWorkers, err := lifecycle.deleteFinishedRetiringWorkers(ctx, 0)
+
+	return workerTransitionsAffected(retiredWorkers), err
+}
+
+// DeleteFinishedRetiringWorkersBatch behaves like DeleteFinishedRetiringWorkers
+// but deletes at most limit workers. A limit of zero deletes them all.
+func (lifecycle *workerLifecycle) DeleteFinishedRetiringWorkersBatch(ctx context.Context, limit int) ([]string, error) {
+	retiredWorkers, err := lifecycle.deleteFinishedRetiringWorkers(ctx, limit)
+
+	return workerTransitionNames(retiredWorkers), err
+}
+
+func (lifecycle *workerLifecycle) deleteFinishedRetiringWorkers(ctx context.Context, limit int) ([]WorkerTransition, error) {
+	var retiredWorkers []WorkerTransition
+	err := lifecycle.run(ctx, "delete-finished-retiring-workers", func(ctx context.Context) (int, error) {
+		query, args, err := lifecycle.finishedRetiringWorkersSQL(limit)
+		if err != nil {
+			return 0, err
+		}
+
+		rows, err := lifecycle.conn.QueryContext(ctx, query, args...)
+		retiredWorkers, err = scanWorkerTransitions(rows, err, "", WorkerTransitionReasonRetireComplete)
+		return len(retiredWorkers), err
+	})
+	lifecycle.workerTransitionsStateChanged(retiredWorkers)
 
 	if err != nil {
-		return []string{}, err
+		return retiredWorkers, err
 	}
 
-	rows, err := lifecycle.conn.Query(query, args...)
+	return retiredWorkers, nil
+}
+
+// ForceDeleteRetiringWorkers deletes every retiring worker straight away,
+// abandoning whatever builds are running on it.
+func (lifecycle *workerLifecycle) ForceDeleteRetiringWorkers(ctx context.Context) ([]string, error) {
+	var retiredWorkers []WorkerTransition
+	err := lifecycle.run(ctx, "force-delete-retiring-workers", func(ctx context.Context) (int, error) {
+		query, args, err := lifecycle.transitionsSQL(lifecycle.retiringWorkers())
+		if err != nil {
+			return 0, err
+		}
+
+		rows, err := lifecycle.conn.QueryContext(ctx, query, args...)
+		retiredWorkers, err = scanWorkerTransitions(rows, err, "", WorkerTransitionReasonForceRetired)
+		return len(retiredWorkers), err
+	})
+	lifecycle.workerTransitionsStateChanged(retiredWorkers)
+
+	retiredNames := workerTransitionNames(retiredWorkers)
+
 	if err != nil {
-		return nil, err
+		return retiredNames, err
 	}
 
-	return workersAffected(rows)
+	return retiredNames, nil
 }
 
-func (lifecycle *workerLifecycle) GetWorkerStateByName() (map[string]WorkerState, error) {
-	rows, err := psql.Select(`
-		name,
-		state
-	`).
-		From("workers").
-		RunWith(lifecycle.conn).
-		Query()
+// PurgeDeletedWorkers deletes the tombstones of the workers which were soft
+// deleted more than olderThan ago.
+func (lifecycle *workerLifecycle) PurgeDeletedWorkers(ctx context.Context, olderThan time.Duration) ([]string, error) {
+	var purgedWorkers []string
+	err := lifecycle.run(ctx, "purge-deleted-workers", func(ctx context.Context) (int, error) {
+		var err error
+		purgedWorkers, err = lifecycle.mutateWorkers(ctx, lifecycle.conn, lifecycle.deletedWorkersOlderThan(olderThan))
+		return len(purgedWorkers), err
+	})
+
+	lifecycle.workersStateChanged(purgedWorkers, WorkerStateDeleted, "", WorkerTransitionReasonPurged)
 
 	if err != nil {
-		return nil, err
+		return purgedWorkers, err
 	}
 
-	defer Close(rows)
-	var name string
-	var state WorkerState
+	return purgedWorkers, nil
+}
 
-	workerStateByName := make(map[string]WorkerState)
+// PruneWorkerStateHistory deletes, in batches, the state transitions recorded
+// longer than olderThan ago, and returns how many were deleted.
+func (lifecycle *workerLifecycle) PruneWorkerStateHistory(ctx context.Context, olderThan time.Duration) (int, error) {
+	old := sq.Expr(fmt.Sprintf("transitioned_at < NOW() - '%d second'::INTERVAL", int(olderThan.Seconds())))
 
-	for rows.Next() {
-		err := rows.Scan(
-			&name,
-			&state,
-		)
+	// Nothing is deleted in dry-run mode, so every batch would count the
+	// same transitions again.
+	if lifecycle.dryRun {
+		return lifecycle.countMutatedWorkers(ctx, lifecycle.conn, "prune-worker-state-history", stateTransitionsMatching(old))
+	}
+
+	batch := sq.Select("id").
+		From("worker_state_transitions").
+		Where(old).
+		Limit(workerStateHistoryPruneBatchSize)
+
+	pruned := 0
+	for {
+		count, err := lifecycle.countMutatedWorkers(ctx, lifecycle.conn, "prune-worker-state-history", stateTransitionsMatching(sq.Expr("id IN (?)", batch)))
+		pruned += count
 		if err != nil {
-			return nil, err
+			return pruned, err
+		}
+
+		if count < workerStateHistoryPruneBatchSize {
+			return pruned, nil
 		}
-		workerStateByName[name] = state
 	}
+}
 
-	return workerStateByName, nil
+// workerStateHistoryPruneBatchSize is how many state transitions
+// PruneWorkerStateHistory deletes per statement.
+const workerStateHistoryPruneBatchSize = 1000
 
+// stateTransitionsMatching deletes the recorded state transitions matched by
+// where.
+func stateTransitionsMatching(where sq.Sqlizer) workerMutation {
+	return workerMutation{
+		table: "worker_state_transitions",
+		where: where,
+		statement: func(suffix string) sq.Sqlizer {
+			return sq.Delete("worker_state_transitions").
+				Where(where).
+				Suffix(suffix).
+				PlaceholderFormat(sq.Dollar)
+		},
+	}
 }
-func workersAffected(rows *sql.Rows) ([]string, error) {
-	var (
-		err         error
-		workerNames []string
-	)
 
-	defer Close(rows)
+// DeleteWorkersForDeletedTeams deletes the team workers whose team no longer
+// exists, for a Table which does not cascade. Global workers are never deleted.
+func (lifecycle *workerLifecycle) DeleteWorkersForDeletedTeams(ctx context.Context) ([]string, error) {
+	var deletedWorkers []WorkerTransition
+	err := lifecycle.run(ctx, "delete-workers-for-deleted-teams", func(ctx context.Context) (int, error) {
+		query, args, err := lifecycle.transitionsSQL(lifecycle.workersOfDeletedTeams())
+		if err != nil {
+			return 0, err
+		}
 
-	for rows.Next() {
-		var name string
+		rows, err := lifecycle.conn.QueryContext(ctx, query, args...)
+		deletedWorkers, err = scanWorkerTransitions(rows, err, "", WorkerTransitionReasonTeamDeleted)
+		return len(deletedWorkers), err
+	})
+	lifecycle.workerTransitionsStateChanged(deletedWorkers)
+
+	deletedNames := workerTransitionNames(deletedWorkers)
+
+	if err != nil {
+		return deletedNames, err
+	}
+
+	return deletedNames, nil
+}
 
-		err = rows.Scan(&name)
+// ReconcileWorkers deletes the ephemeral workers which are not among the
+// existing workers. It returns ErrNoExistingWorkers if there are none.
+func (lifecycle *workerLifecycle) ReconcileWorkers(ctx context.Context, existing []string) ([]string, error) {
+	if len(existing) == 0 {
+		return nil, ErrNoExistingWorkers
+	}
+
+	var deletedWorkers []WorkerTransition
+	err := lifecycle.run(ctx, "reconcile-workers", func(ctx context.Context) (int, error) {
+		query, args, err := lifecycle.transitionsSQL(lifecycle.workersMissingFrom(existing))
 		if err != nil {
-			return nil, err
+			return 0, err
 		}
 
-		workerNames = append(workerNames, name)
+		rows, err := lifecycle.conn.QueryContext(ctx, query, args...)
+		deletedWorkers, err = scanWorkerTransitions(rows, err, "", WorkerTransitionReasonReconciled)
+		return len(deletedWorkers), err
+	})
+	lifecycle.workerTransitionsStateChanged(deletedWorkers)
+
+	deletedNames := workerTransitionNames(deletedWorkers)
+
+	if err != nil {
+		return deletedNames, err
+	}
+
+	return deletedNames, nil
+}
+
+// CleanWorkerResourceCaches deletes the resource caches of the named worker
+// once it has landed, and returns how many were deleted.
+func (lifecycle *workerLifecycle) CleanWorkerResourceCaches(ctx context.Context, workerName string) (int, error) {
+	where := sq.And{
+		sq.Eq{"wrc.worker_name": workerName},
+		sq.Expr("wrc.worker_name IN (SELECT name FROM "+lifecycle.table+" WHERE state = ?)", string(WorkerStateLanded)),
+	}
+
+	mutation := workerMutation{
+		table: "worker_resource_caches wrc",
+		where: where,
+		statement: func(suffix string) sq.Sqlizer {
+			return sq.Delete("worker_resource_caches wrc").
+				Where(where).
+				Suffix(suffix).
+				PlaceholderFormat(sq.Dollar)
+		},
+	}
+
+	return lifecycle.countMutatedWorkers(ctx, lifecycle.conn, "clean-worker-resource-caches", mutation)
+}
+
+// OrphanContainersForWorker marks the containers left behind by the named
+// deleted worker as destroying, and returns how many were marked.
+func (lifecycle *workerLifecycle) OrphanContainersForWorker(ctx context.Context, workerName string) (int, error) {
+	where := sq.And{
+		sq.Eq{"c.worker_name": workerName},
+		sq.Eq{"c.state": []string{atc.ContainerStateCreating, atc.ContainerStateCreated}},
+		sq.Expr("c.worker_name NOT IN (SELECT name FROM "+lifecycle.table+" WHERE state <> ?)", string(WorkerStateDeleted)),
+	}
+
+	mutation := workerMutation{
+		table: "containers c",
+		where: where,
+		statement: func(suffix string) sq.Sqlizer {
+			return sq.Update("containers c").
+				Set("state", atc.ContainerStateDestroying).
+				Where(where).
+				Suffix(suffix).
+				PlaceholderFormat(sq.Dollar)
+		},
+	}
+
+	return lifecycle.countMutatedWorkers(ctx, lifecycle.conn, "orphan-containers-for-worker", mutation)
+}
+
+// LandAllWorkers starts landing every running worker.
+func (lifecycle *workerLifecycle) LandAllWorkers(ctx context.Context) ([]string, error) {
+	var landingWorkers []string
+	err := lifecycle.run(ctx, "land-all-workers", func(ctx context.Context) (int, error) {
+		var err error
+		landingWorkers, err = lifecycle.mutateWorkers(ctx, lifecycle.conn, lifecycle.runningWorkers(nil))
+		return len(landingWorkers), err
+	})
+
+	lifecycle.workersStateChanged(landingWorkers, WorkerStateRunning, WorkerStateLanding, WorkerTransitionReasonLandAll)
+
+	if err != nil {
+		return landingWorkers, err
+	}
+
+	return landingWorkers, nil
+}
+
+// LandWorkersWithTag starts landing the running workers which carry the given
+// tag.
+func (lifecycle *workerLifecycle) LandWorkersWithTag(ctx context.Context, tag string) ([]string, error) {
+	var landingWorkers []string
+	err := lifecycle.run(ctx, "land-workers-with-tag", func(ctx context.Context) (int, error) {
+		var err error
+		landingWorkers, err = lifecycle.mutateWorkers(ctx, lifecycle.conn, lifecycle.runningWorkers(taggedWith(tag)))
+		return len(landingWorkers), err
+	})
+
+	lifecycle.workersStateChanged(landingWorkers, WorkerStateRunning, WorkerStateLanding, WorkerTransitionReasonLandTagged)
+
+	if err != nil {
+		return landingWorkers, err
+	}
+
+	return landingWorkers, nil
+}
+
+// LandUnreachableWorkers starts landing the running workers whose baggageclaim
+// was last seen healthy longer than threshold ago.
+func (lifecycle *workerLifecycle) LandUnreachableWorkers(ctx context.Context, threshold time.Duration) ([]string, error) {
+	unreachable := sq.Expr(fmt.Sprintf("workers.baggageclaim_last_seen < NOW() - '%d second'::INTERVAL", int(threshold.Seconds())))
+
+	var landingWorkers []string
+	err := lifecycle.run(ctx, "land-unreachable-workers", func(ctx context.Context) (int, error) {
+		var err error
+		landingWorkers, err = lifecycle.mutateWorkers(ctx, lifecycle.conn, lifecycle.runningWorkers(unreachable))
+		return len(landingWorkers), err
+	})
+
+	lifecycle.workersStateChanged(landingWorkers, WorkerStateRunning, WorkerStateLanding, WorkerTransitionReasonUnreachable)
+
+	if err != nil {
+		return landingWorkers, err
+	}
+
+	return landingWorkers, nil
+}
+
+// LandFinishedLandingWorkers lands the landing workers which have no incomplete
+// uninterruptible builds, and the draining workers which have no builds at all.
+func (lifecycle *workerLifecycle) LandFinishedLandingWorkers(ctx context.Context) ([]string, error) {
+	landedWorkers, err := lifecycle.LandFinishedLandingWorkersWithDuration(ctx)
+
+	return landedWorkerNames(landedWorkers), err
+}
+
+// LandFinishedLandingWorkersWithDuration behaves like
+// LandFinishedLandingWorkers but returns the landed workers as LandedWorkers.
+func (lifecycle *workerLifecycle) LandFinishedLandingWorkersWithDuration(ctx context.Context) ([]LandedWorker, error) {
+	return lifecycle.landFinishedLandingWorkers(ctx, nil)
+}
+
+// LandFinishedLandingWorkersDetailed behaves like LandFinishedLandingWorkers
+// but returns the reason each worker was landed for.
+func (lifecycle *workerLifecycle) LandFinishedLandingWorkersDetailed(ctx context.Context) ([]WorkerAffected, error) {
+	landedWorkers, err := lifecycle.landFinishedLandingWorkers(ctx, nil)
+
+	return landedWorkersAffected(landedWorkers), err
+}
+
+// LandFinishedLandingWorkersForPlatform behaves like LandFinishedLandingWorkers
+// but only lands the workers of the given platform.
+func (lifecycle *workerLifecycle) LandFinishedLandingWorkersForPlatform(ctx context.Context, platform string) ([]string, error) {
+	landedWorkers, err := lifecycle.landFinishedLandingWorkers(ctx, sq.Eq{"workers.platform": platform})
+
+	return landedWorkerNames(landedWorkers), err
+}
+
+// LandFinishedLandingWorkersForWorkers behaves like LandFinishedLandingWorkers
+// but only looks at the named workers and their builds.
+func (lifecycle *workerLifecycle) LandFinishedLandingWorkersForWorkers(ctx context.Context, names []string) ([]string, error) {
+	if len(names) == 0 {
+		return []string{}, nil
+	}
+
+	landedWorkers, err := lifecycle.landFinishedLandingWorkers(ctx,
+		sq.Expr("workers.name = ANY(?)", names),
+		sq.Expr("w.name = ANY(?)", names),
+	)
+
+	return landedWorkerNames(landedWorkers), err
+}
+
+// LandFinishedLandingWorkersKeepMinimum behaves like LandFinishedLandingWorkers
+// but leaves every team with at least minPerTeam running or landing workers.
+func (lifecycle *workerLifecycle) LandFinishedLandingWorkersKeepMinimum(ctx context.Context, minPerTeam int) ([]string, error) {
+	landedWorkers, err := lifecycle.landFinishedLandingWorkers(ctx, lifecycle.keepingTeamMinimum(minPerTeam))
+
+	return landedWorkerNames(landedWorkers), err
+}
+
+// LandWorkersInterruptingBuilds lands the landing workers without waiting for
+// their builds of interruptible jobs. Draining workers are left alone.
+func (lifecycle *workerLifecycle) LandWorkersInterruptingBuilds(ctx context.Context) ([]string, error) {
+	landedWorkers, err := lifecycle.landFinishedLandingWorkers(ctx, sq.Eq{"workers.state": string(WorkerStateLanding)})
+
+	return landedWorkerNames(landedWorkers), err
+}
+
+// landFinishedLandingWorkers only lands the workers matched by only, or every
+// finished landing worker if only is nil.
+func (lifecycle *workerLifecycle) landFinishedLandingWorkers(ctx context.Context, only sq.Sqlizer, onWorkers ...sq.Sqlizer) ([]LandedWorker, error) {
+	var landedWorkers []LandedWorker
+	err := lifecycle.run(ctx, "land-finished-landing-workers", func(ctx context.Context) (int, error) {
+		query, args, err := lifecycle.finishedLandingWorkersSQL(only, onWorkers...)
+		if err != nil {
+			return 0, err
+		}
+
+		landedWorkers, err = scanLandedWorkers(lifecycle.conn.QueryContext(ctx, query, args...))
+		return len(landedWorkers), err
+	})
+	lifecycle.landedWorkersStateChanged(landedWorkers)
+
+	if err != nil {
+		return landedWorkers, err
+	}
+
+	return landedWorkers, nil
+}
+
+// The *Count variants perform the same mutations as their counterparts but
+// only report how many workers were affected, unless an observer needs names.
+
+func (lifecycle *workerLifecycle) DeleteUnresponsiveEphemeralWorkersCount(ctx context.Context) (int, error) {
+	// Soft deleting the workers also orphans their containers.
+	if lifecycle.observer != nil || lifecycle.softDelete {
+		return countWorkers(lifecycle.DeleteUnresponsiveEphemeralWorkers(ctx))
+	}
+
+	return lifecycle.countMutatedWorkers(ctx, lifecycle.conn, "delete-unresponsive-ephemeral-workers", lifecycle.unresponsiveEphemeralWorkers(nil, 0))
+}
+
+func (lifecycle *workerLifecycle) StallUnresponsiveWorkersCount(ctx context.Context) (int, error) {
+	if lifecycle.observer != nil {
+		return countWorkers(lifecycle.StallUnresponsiveWorkers(ctx))
+	}
+
+	err := lifecycle.retrying(ctx, "stall-unresponsive-workers", func(ctx context.Context) error {
+		return lifecycle.countMissedHeartbeats(ctx, lifecycle.conn, WorkerKindAny)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return lifecycle.countMutatedWorkers(ctx, lifecycle.conn, "stall-unresponsive-workers", lifecycle.unresponsiveWorkers(0, WorkerKindAny))
+}
+
+// countMissedHeartbeats counts another missed heartbeat for each worker which
+// is still stalled. It must run before the pass stalls any worker.
+func (lifecycle *workerLifecycle) countMissedHeartbeats(ctx context.Context, runner sq.ExecerContext, kind WorkerKind) error {
+	if lifecycle.dryRun {
+		return nil
+	}
+
+	where := sq.And{
+		sq.Eq{"workers.state": string(WorkerStateStalled)},
+	}
+
+	switch kind {
+	case WorkerKindEphemeral:
+		where = append(where, sq.Eq{"workers.ephemeral": true})
+	case WorkerKindPersistent:
+		where = append(where, sq.Eq{"workers.ephemeral": false})
+	}
+
+	mutation := lifecycle.updateWorkers(map[string]any{
+		"missed_heartbeats": sq.Expr("workers.missed_heartbeats + 1"),
+	}, where)
+
+	query, args, err := mutation.statement("").ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = runner.ExecContext(ctx, query, args...)
+	return err
+}
+
+func (lifecycle *workerLifecycle) DeleteStalledWorkersCount(ctx context.Context, timeout time.Duration) (int, error) {
+	if lifecycle.observer != nil {
+		return countWorkers(lifecycle.DeleteStalledWorkers(ctx, timeout))
+	}
+
+	return lifecycle.countMutatedWorkers(ctx, lifecycle.conn, "delete-stalled-workers", lifecycle.stalledWorkersPastTimeout(timeout))
+}
+
+func (lifecycle *workerLifecycle) LandFinishedLandingWorkersCount(ctx context.Context) (int, error) {
+	if lifecycle.observer != nil {
+		return countWorkers(lifecycle.LandFinishedLandingWorkers(ctx))
+	}
+
+	notBusy, err := lifecycle.withoutUninterruptibleBuilds("workers.name", landingBlockingBuilds)
+	if err != nil {
+		return 0, LifecycleQueryError{Operation: "land-finished-landing-workers", Err: err}
+	}
+
+	idle, err := lifecycle.withoutActiveBuilds("workers.name")
+	if err != nil {
+		return 0, LifecycleQueryError{Operation: "land-finished-landing-workers", Err: err}
+	}
+
+	return lifecycle.countMutatedWorkers(ctx, lifecycle.conn, "land-finished-landing-workers", lifecycle.finishedLandingWorkers(notBusy, idle, nil))
+}
+
+func (lifecycle *workerLifecycle) DeleteFinishedRetiringWorkersCount(ctx context.Context) (int, error) {
+	if lifecycle.observer != nil {
+		return countWorkers(lifecycle.DeleteFinishedRetiringWorkers(ctx))
+	}
+
+	notBusy, err := lifecycle.withoutUninterruptibleBuilds("name", uninterruptibleBuilds)
+	if err != nil {
+		return 0, LifecycleQueryError{Operation: "delete-finished-retiring-workers", Err: err}
+	}
+
+	return lifecycle.countMutatedWorkers(ctx, lifecycle.conn, "delete-finished-retiring-workers", lifecycle.finishedRetiringWorkers(notBusy, 0))
+}
+
+// The *SQL methods return the statement, with its arguments, which the
+// corresponding operation would run, without running it.
+
+func (lifecycle *workerLifecycle) DeleteUnresponsiveEphemeralWorkersSQL() (string, []any, error) {
+	return lifecycle.deletedWorkersSQL(lifecycle.unresponsiveEphemeralWorkers(nil, 0))
+}
+
+// deletedWorkersSQL builds the statement for a mutation deleting workers,
+// which returns the columns of a DeletedWorker.
+func (lifecycle *workerLifecycle) deletedWorkersSQL(mutation workerMutation) (string, []any, error) {
+	// A tombstone is written with an UPDATE, whose RETURNING clause would
+	// report the new values, so the old ones are read from a self join.
+	returning := "workers"
+	if lifecycle.softDelete {
+		returning = "previous"
+	}
+
+	return lifecycle.mutation(
+		mutation.statement("RETURNING "+deletedWorkerColumns(returning)),
+		mutation.preview(deletedWorkerColumns("workers")),
+	).ToSql()
+}
+
+func (lifecycle *workerLifecycle) StallUnresponsiveWorkersSQL(grace time.Duration) (string, []any, error) {
+	return lifecycle.transitionsSQL(lifecycle.unresponsiveWorkers(grace, WorkerKindAny))
+}
+
+func (lifecycle *workerLifecycle) DeleteStalledWorkersSQL(timeout time.Duration) (string, []any, error) {
+	return lifecycle.mutationSQL(lifecycle.stalledWorkersPastTimeout(timeout))
+}
+
+func (lifecycle *workerLifecycle) LandFinishedLandingWorkersSQL() (string, []any, error) {
+	return lifecycle.finishedLandingWorkersSQL(nil)
+}
+
+// finishedLandingWorkersSQL narrows the workers down to the ones matched by
+// only, and their builds to the ones on the workers matched by onWorkers.
+func (lifecycle *workerLifecycle) finishedLandingWorkersSQL(only sq.Sqlizer, onWorkers ...sq.Sqlizer) (string, []any, error) {
+	notBusy, err := lifecycle.withoutUninterruptibleBuilds("workers.name", landingBlockingBuilds, onWorkers...)
+	if err != nil {
+		return "", nil, err
+	}
+
+	idle, err := lifecycle.withoutActiveBuilds("workers.name", onWorkers...)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return checkPlaceholders(lifecycle.landedWorkersSQL(lifecycle.finishedLandingWorkers(notBusy, idle, only)))
+}
+
+// landedWorkersSQL builds the statement for a mutation landing workers, which
+// returns the columns of a LandedWorker.
+func (lifecycle *workerLifecycle) landedWorkersSQL(mutation workerMutation) (string, []any, error) {
+	return lifecycle.mutation(
+		mutation.statement("RETURNING "+landedWorkerColumns("previous")),
+		mutation.preview(landedWorkerColumns("workers")),
+	).ToSql()
+}
+
+func landedWorkerColumns(alias string) string {
+	return fmt.Sprintf("workers.name, %[1]s.state, EXTRACT(EPOCH FROM NOW() - %[1]s.state_changed_at), COALESCE(%[1]s.active_containers, 0), COALESCE(%[1]s.active_volumes, 0)", alias)
+}
+
+func (lifecycle *workerLifecycle) DeleteFinishedRetiringWorkersSQL() (string, []any, error) {
+	return lifecycle.finishedRetiringWorkersSQL(0)
+}
+
+func (lifecycle *workerLifecycle) finishedRetiringWorkersSQL(limit int) (string, []any, error) {
+	notBusy, err := lifecycle.withoutUninterruptibleBuilds("name", uninterruptibleBuilds)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return checkPlaceholders(lifecycle.transitionsSQL(lifecycle.finishedRetiringWorkers(notBusy, limit)))
+}
+
+// ProcessFinishedWorkers lands finished landing workers and deletes finished
+// retiring workers in a single transaction.
+func (lifecycle *workerLifecycle) ProcessFinishedWorkers(ctx context.Context) ([]string, []string, error) {
+	// A failed statement aborts the transaction, so the whole transaction is
+	// retried rather than the statement.
+	var (
+		landedWorkers []LandedWorker
+		retired       []string
+	)
+	err := lifecycle.run(ctx, "process-finished-workers", func(ctx context.Context) (int, error) {
+		var err error
+		landedWorkers, retired, err = lifecycle.processFinishedWorkers(ctx)
+		return len(landedWorkers) + len(retired), err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	landed := landedWorkerNames(landedWorkers)
+
+	lifecycle.landedWorkersStateChanged(landedWorkers)
+	lifecycle.workersStateChanged(retired, WorkerStateRetiring, "", WorkerTransitionReasonRetireComplete)
+
+	return landed, retired, nil
+}
+
+func (lifecycle *workerLifecycle) processFinishedWorkers(ctx context.Context) ([]LandedWorker, []string, error) {
+	tx, err := lifecycle.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer Rollback(tx)
+
+	// only the workers running a build matched by landingBlockingBuilds are
+	// kept from landing
+	subQ, subQArgs, err := lifecycle.activeBuildsOnWorkers("w.name", "bool_or(j.active IS NOT FALSE)").
+		Where(uninterruptibleBuilds).
+		GroupBy("w.name").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	subQ, subQArgs, err = checkPlaceholders(subQ, subQArgs, err)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, err := tx.QueryContext(ctx, subQ, subQArgs...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// A NULL array would make the ANY comparison NULL and thus exclude every
+	// worker, so always pass initialized slices.
+	busyRetiring, busyLanding := []string{}, []string{}
+	err = scanWorkerRows(rows, func(rows *sql.Rows) error {
+		var (
+			name          string
+			blocksLanding bool
+		)
+
+		err := rows.Scan(&name, &blocksLanding)
+		if err != nil {
+			return err
+		}
+
+		busyRetiring = append(busyRetiring, name)
+		if blocksLanding {
+			busyLanding = append(busyLanding, name)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	notBusyLanding := sq.Expr("NOT (workers.name = ANY(?))", busyLanding)
+	notBusyRetiring := sq.Expr("NOT (workers.name = ANY(?))", busyRetiring)
+
+	idle, err := lifecycle.withoutActiveBuilds("workers.name")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	query, args, err := checkPlaceholders(lifecycle.landedWorkersSQL(lifecycle.finishedLandingWorkers(notBusyLanding, idle, nil)))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	landed, err := scanLandedWorkers(tx.QueryContext(ctx, query, args...))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	retired, err := lifecycle.mutateWorkers(ctx, tx, lifecycle.finishedRetiringWorkers(notBusyRetiring, 0))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return landed, retired, nil
+}
+
+// ExpireWorker marks a worker's heartbeat as expired so that the next
+// lifecycle pass cleans it up. It returns ErrWorkerNotPresent if there is none.
+func (lifecycle *workerLifecycle) ExpireWorker(ctx context.Context, name string) error {
+	var count int64
+	err := lifecycle.run(ctx, "expire-worker", func(ctx context.Context) (int, error) {
+		result, err := psql.Update(lifecycle.tableAs("workers")).
+			Set("expires", sq.Expr("NOW() - '1 second'::INTERVAL")).
+			Where(sq.Eq{"name": name}).
+			RunWith(lifecycle.conn).
+			ExecContext(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		count, err = result.RowsAffected()
+		return int(count), err
+	})
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return ErrWorkerNotPresent
+	}
+
+	return nil
+}
+
+// HeartbeatWorker pushes back the expiry of a running worker's heartbeat to ttl
+// from now, or clears it if ttl is zero. It returns false if there is none.
+func (lifecycle *workerLifecycle) HeartbeatWorker(ctx context.Context, name string, ttl time.Duration) (bool, error) {
+	expires := sq.Expr("NULL")
+	if ttl != 0 {
+		expires = sq.Expr(fmt.Sprintf(`NOW() + '%d second'::INTERVAL`, int(ttl.Seconds())))
+	}
+
+	var count int64
+	err := lifecycle.run(ctx, "heartbeat-worker", func(ctx context.Context) (int, error) {
+		result, err := psql.Update(lifecycle.tableAs("workers")).
+			Set("expires", expires).
+			Set("missed_heartbeats", 0).
+			Where(sq.Eq{
+				"name":  name,
+				"state": string(WorkerStateRunning),
+			}).
+			RunWith(lifecycle.conn).
+			ExecContext(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		count, err = result.RowsAffected()
+		return int(count), err
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// HeartbeatWorkers behaves like HeartbeatWorker for all of the named workers
+// at once, and returns the workers which were heartbeat.
+func (lifecycle *workerLifecycle) HeartbeatWorkers(ctx context.Context, names []string, ttl time.Duration) ([]string, error) {
+	expires := sq.Expr("NULL")
+	if ttl != 0 {
+		expires = sq.Expr(fmt.Sprintf(`NOW() + '%d second'::INTERVAL`, int(ttl.Seconds())))
+	}
+
+	mutation := lifecycle.updateWorkers(
+		map[string]any{
+			"expires":           expires,
+			"missed_heartbeats": 0,
+		},
+		sq.And{
+			sq.Expr("name = ANY(?)", names),
+			sq.Eq{"state": string(WorkerStateRunning)},
+		},
+	)
+
+	var heartbeatWorkers []string
+	err := lifecycle.run(ctx, "heartbeat-workers", func(ctx context.Context) (int, error) {
+		var err error
+		heartbeatWorkers, err = lifecycle.mutateWorkers(ctx, lifecycle.conn, mutation)
+		return len(heartbeatWorkers), err
+	})
+	if err != nil {
+		return heartbeatWorkers, err
+	}
+
+	return heartbeatWorkers, nil
+}
+
+// ExtendEphemeralExpiry pushes the heartbeat expiry of a running ephemeral
+// worker back to by from now. It returns whether the expiry was pushed back.
+func (lifecycle *workerLifecycle) ExtendEphemeralExpiry(ctx context.Context, name string, by time.Duration) (bool, error) {
+	expires := fmt.Sprintf(`NOW() + '%d second'::INTERVAL`, int(by.Seconds()))
+
+	var count int64
+	err := lifecycle.run(ctx, "extend-ephemeral-expiry", func(ctx context.Context) (int, error) {
+		result, err := psql.Update(lifecycle.tableAs("workers")).
+			Set("expires", sq.Expr(expires)).
+			Where(sq.Eq{
+				"name":      name,
+				"ephemeral": true,
+				"state":     string(WorkerStateRunning),
+			}).
+			Where(sq.Expr("expires < " + expires)).
+			RunWith(lifecycle.conn).
+			ExecContext(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		count, err = result.RowsAffected()
+		return int(count), err
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// MarkBaggageclaimHealth records whether an external probe could reach the
+// worker's baggageclaim. It returns ErrWorkerNotPresent if there is no such worker.
+func (lifecycle *workerLifecycle) MarkBaggageclaimHealth(ctx context.Context, name string, healthy bool) error {
+	update := psql.Update(lifecycle.tableAs("workers")).
+		Set("baggageclaim_healthy", healthy).
+		Where(sq.Eq{"name": name})
+
+	if healthy {
+		update = update.Set("baggageclaim_last_seen", sq.Expr("NOW()"))
+	}
+
+	var count int64
+	err := lifecycle.run(ctx, "mark-baggageclaim-health", func(ctx context.Context) (int, error) {
+		result, err := update.
+			RunWith(lifecycle.conn).
+			ExecContext(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		count, err = result.RowsAffected()
+		return int(count), err
+	})
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return ErrWorkerNotPresent
+	}
+
+	return nil
+}
+
+// TransitionWorker moves the named worker from one state to another if it is
+// still in the from state, and returns how many workers were moved.
+func (lifecycle *workerLifecycle) TransitionWorker(ctx context.Context, name string, from, to WorkerState) (int, error) {
+	if !ValidWorkerTransition(from, to) {
+		return 0, fmt.Errorf("%w: %s to %s", ErrInvalidWorkerTransition, from, to)
+	}
+
+	count, err := lifecycle.countMutatedWorkers(ctx, lifecycle.conn, "transition-worker", lifecycle.transitioningWorker(name, from, to))
+	if err != nil {
+		return 0, err
+	}
+
+	if count > 0 {
+		lifecycle.workerStateChanged(name, from, to, WorkerTransitionReasonRequested)
+	}
+
+	return count, nil
+}
+
+// DrainWorker starts draining a running worker, which is only landed once all
+// of its builds are done.
+func (lifecycle *workerLifecycle) DrainWorker(ctx context.Context, name string) error {
+	count, err := lifecycle.countMutatedWorkers(ctx, lifecycle.conn, "drain-worker", lifecycle.transitioningWorker(name, WorkerStateRunning, WorkerStateDraining))
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		lifecycle.workerStateChanged(name, WorkerStateRunning, WorkerStateDraining, WorkerTransitionReasonDrain)
+		return nil
+	}
+
+	state, err := lifecycle.workerState(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if state == WorkerStateDraining {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s to %s", ErrInvalidWorkerTransition, state, WorkerStateDraining)
+}
+
+// MarkWorkerForUpgrade starts landing a running worker and flags it so that
+// RestoreUpgradedWorkers brings it back to running afterwards.
+func (lifecycle *workerLifecycle) MarkWorkerForUpgrade(ctx context.Context, name string) error {
+	set := workerStateColumns(WorkerStateLanding)
+	set["upgrade_pending"] = true
+
+	mutation := lifecycle.updateWorkersFromPrevious(set, sq.And{
+		sq.Eq{"workers.name": name},
+		sq.Eq{"workers.state": []string{string(WorkerStateRunning), string(WorkerStateLanding)}},
+	})
+
+	var markedWorkers []WorkerTransition
+	err := lifecycle.run(ctx, "mark-worker-for-upgrade", func(ctx context.Context) (int, error) {
+		query, args, err := lifecycle.transitionsSQL(mutation)
+		if err != nil {
+			return 0, err
+		}
+
+		rows, err := lifecycle.conn.QueryContext(ctx, query, args...)
+		markedWorkers, err = scanWorkerTransitions(rows, err, WorkerStateLanding, WorkerTransitionReasonUpgrade)
+		return len(markedWorkers), err
+	})
+
+	for _, markedWorker := range markedWorkers {
+		if markedWorker.From == WorkerStateRunning {
+			lifecycle.workerStateChanged(markedWorker.Name, markedWorker.From, markedWorker.To, markedWorker.Reason)
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if len(markedWorkers) > 0 {
+		return nil
+	}
+
+	state, err := lifecycle.workerState(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	return fmt.Errorf("%w: %s to %s", ErrInvalidWorkerTransition, state, WorkerStateLanding)
+}
+
+// RestoreUpgradedWorkers brings the named landed workers flagged by
+// MarkWorkerForUpgrade which have an address again back to running.
+func (lifecycle *workerLifecycle) RestoreUpgradedWorkers(ctx context.Context, names []string) ([]string, error) {
+	if len(names) == 0 {
+		return []string{}, nil
+	}
+
+	set := workerStateColumns(WorkerStateRunning)
+	set["upgrade_pending"] = false
+
+	mutation := lifecycle.updateWorkersFromPrevious(set, sq.And{
+		sq.Expr("workers.name = ANY(?)", names),
+		workersTransitioning("workers.state", WorkerStateLanded, WorkerStateRunning),
+		sq.Eq{"workers.upgrade_pending": true},
+		workersWithAddress(),
+	})
+
+	var restoredWorkers []WorkerTransition
+	err := lifecycle.run(ctx, "restore-upgraded-workers", func(ctx context.Context) (int, error) {
+		query, args, err := lifecycle.transitionsSQL(mutation)
+		if err != nil {
+			return 0, err
+		}
+
+		rows, err := lifecycle.conn.QueryContext(ctx, query, args...)
+		restoredWorkers, err = scanWorkerTransitions(rows, err, WorkerStateRunning, WorkerTransitionReasonUpgraded)
+		return len(restoredWorkers), err
+	})
+	lifecycle.workerTransitionsStateChanged(restoredWorkers)
+
+	restoredNames := workerTransitionNames(restoredWorkers)
+
+	if err != nil {
+		return restoredNames, err
+	}
+
+	return restoredNames, nil
+}
+
+// ClaimWorkerForLanding makes the given ATC the one landing the named worker
+// for the lease. It returns false if another ATC holds the claim.
+func (lifecycle *workerLifecycle) ClaimWorkerForLanding(ctx context.Context, name string, atcID string, lease time.Duration) (bool, error) {
+	if lifecycle.dryRun {
+		return false, ErrDryRunClaim
+	}
+
+	mutation := lifecycle.updateWorkers(
+		map[string]any{
+			"landing_owner":         atcID,
+			"landing_lease_expires": sq.Expr("NOW() + make_interval(secs => ?)", lease.Seconds()),
+		},
+		sq.And{
+			sq.Eq{"name": name},
+			sq.NotEq{"state": string(WorkerStateDeleted)},
+			sq.Or{
+				sq.Eq{"landing_owner": nil},
+				sq.Eq{"landing_owner": atcID},
+				sq.Expr("landing_lease_expires < NOW()"),
+			},
+		},
+	)
+
+	count, err := lifecycle.countMutatedWorkers(ctx, lifecycle.conn, "claim-worker-for-landing", mutation)
+	if err != nil {
+		return false, err
+	}
+
+	if count > 0 {
+		return true, nil
+	}
+
+	_, err = lifecycle.workerState(ctx, name)
+	if err != nil {
+		return false, err
+	}
+
+	return false, nil
+}
+
+// ResurrectWorker brings a stalled worker back to running at its new address,
+// with its heartbeat expiring after ttl.
+func (lifecycle *workerLifecycle) ResurrectWorker(ctx context.Context, name string, addr, baggageclaimURL string, ttl time.Duration) error {
+	expires := sq.Expr("NULL")
+	if ttl != 0 {
+		expires = sq.Expr(fmt.Sprintf(`NOW() + '%d second'::INTERVAL`, int(ttl.Seconds())))
+	}
+
+	mutation := lifecycle.updateWorkers(
+		map[string]any{
+			"state":             string(WorkerStateRunning),
+			"addr":              addr,
+			"baggageclaim_url":  baggageclaimURL,
+			"expires":           expires,
+			"stalled_since":     nil,
+			"missed_heartbeats": 0,
+		},
+		sq.And{
+			sq.Eq{"name": name},
+			workersTransitioning("state", WorkerStateStalled, WorkerStateRunning),
+		},
+	)
+
+	count, err := lifecycle.countMutatedWorkers(ctx, lifecycle.conn, "resurrect-worker", mutation)
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		lifecycle.workerStateChanged(name, WorkerStateStalled, WorkerStateRunning, WorkerTransitionReasonResurrected)
+		return nil
+	}
+
+	state, err := lifecycle.workerState(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	return fmt.Errorf("%w: %s to %s", ErrInvalidWorkerTransition, state, WorkerStateRunning)
+}
+
+// QuarantineWorker pins a worker in its place until UnquarantineWorker
+// releases it. The lifecycle never moves a quarantined worker.
+func (lifecycle *workerLifecycle) QuarantineWorker(ctx context.Context, name string) error {
+	mutation := lifecycle.workersNamed([]string{name}, workerStatesTransitioningTo(WorkerStateQuarantined), WorkerStateQuarantined)
+
+	var quarantinedWorkers []WorkerTransition
+	err := lifecycle.run(ctx, "quarantine-worker", func(ctx context.Context) (int, error) {
+		query, args, err := lifecycle.transitionsSQL(mutation)
+		if err != nil {
+			return 0, err
+		}
+
+		rows, err := lifecycle.conn.QueryContext(ctx, query, args...)
+		quarantinedWorkers, err = scanWorkerTransitions(rows, err, WorkerStateQuarantined, WorkerTransitionReasonQuarantined)
+		return len(quarantinedWorkers), err
+	})
+
+	lifecycle.workerTransitionsStateChanged(quarantinedWorkers)
+
+	if err != nil {
+		return err
+	}
+
+	if len(quarantinedWorkers) > 0 {
+		return nil
+	}
+
+	state, err := lifecycle.workerState(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if state == WorkerStateQuarantined {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s to %s", ErrInvalidWorkerTransition, state, WorkerStateQuarantined)
+}
+
+// UnquarantineWorker releases a quarantined worker back to running.
+func (lifecycle *workerLifecycle) UnquarantineWorker(ctx context.Context, name string) error {
+	count, err := lifecycle.countMutatedWorkers(ctx, lifecycle.conn, "unquarantine-worker", lifecycle.transitioningWorker(name, WorkerStateQuarantined, WorkerStateRunning))
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		lifecycle.workerStateChanged(name, WorkerStateQuarantined, WorkerStateRunning, WorkerTransitionReasonUnquarantined)
+		return nil
+	}
+
+	state, err := lifecycle.workerState(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if state == WorkerStateRunning {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s to %s", ErrInvalidWorkerTransition, state, WorkerStateRunning)
+}
+
+// SetWorkerStates moves the named workers which are allowed to move to the
+// given state to it, and returns their names.
+func (lifecycle *workerLifecycle) SetWorkerStates(ctx context.Context, names []string, state WorkerState) ([]string, error) {
+	if len(names) == 0 {
+		return []string{}, nil
+	}
+
+	mutation := lifecycle.workersNamed(names, workerStatesTransitioningTo(state), state)
+
+	var updatedWorkers []WorkerTransition
+	err := lifecycle.run(ctx, "set-worker-states", func(ctx context.Context) (int, error) {
+		query, args, err := lifecycle.transitionsSQL(mutation)
+		if err != nil {
+			return 0, err
+		}
+
+		rows, err := lifecycle.conn.QueryContext(ctx, query, args...)
+		updatedWorkers, err = scanWorkerTransitions(rows, err, state, WorkerTransitionReasonRequested)
+		return len(updatedWorkers), err
+	})
+	lifecycle.workerTransitionsStateChanged(updatedWorkers)
+
+	updatedNames := workerTransitionNames(updatedWorkers)
+
+	if err != nil {
+		return updatedNames, err
+	}
+
+	return updatedNames, nil
+}
+
+// mutation returns the statement to run for a mutating operation, which is
+// the selecting preview in dry-run mode.
+func (lifecycle *workerLifecycle) mutation(mutation, preview sq.Sqlizer) sq.Sqlizer {
+	if lifecycle.dryRun {
+		return preview
+	}
+
+	return mutation
+}
+
+// tableAs returns the workers table aliased as alias.
+func (lifecycle *workerLifecycle) tableAs(alias string) string {
+	if lifecycle.table == alias {
+		return alias
+	}
+
+	return lifecycle.table + " " + alias
+}
+
+// retrying runs fn under the query context, retrying it with backoff on
+// deadlocks and serialization failures, and wraps the final error.
+func (lifecycle *workerLifecycle) retrying(ctx context.Context, operation string, fn func(ctx context.Context) error) error {
+	ctx, cancel := lifecycle.queryContext(ctx)
+	defer cancel()
+
+	for attempt := 0; ; attempt++ {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+
+		if attempt >= lifecycle.retries || !isDeadlockOrSerializationFailure(err) {
+			return LifecycleQueryError{Operation: operation, Err: err}
+		}
+
+		backoff := lifecycle.maxRetryBackoff
+		if attempt < workerLifecycleMaxRetryShift {
+			backoff = min(workerLifecycleRetryBackoff<<attempt, backoff)
+		}
+
+		select {
+		case <-ctx.Done():
+			return LifecycleQueryError{Operation: operation, Err: ctx.Err()}
+		case <-time.After(backoff/2 + rand.N(backoff/2+1)):
+		}
+	}
+}
+
+const (
+	workerLifecycleRetryBackoff = 20 * time.Millisecond
+
+	// workerLifecycleMaxRetryShift keeps the doubled backoff from overflowing
+	// a time.Duration.
+	workerLifecycleMaxRetryShift = 32
+)
+
+// queryContext derives the context of a query from ctx, which is also
+// cancelled along with the base context, if any.
+func (lifecycle *workerLifecycle) queryContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if lifecycle.ctx == nil {
+		return ctx, func() {}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	stop := context.AfterFunc(lifecycle.ctx, cancel)
+
+	return ctx, func() {
+		stop()
+		cancel()
+	}
+}
+
+// run runs fn as the named operation with retrying, and reports the number of
+// rows fn returns once it succeeds.
+func (lifecycle *workerLifecycle) run(ctx context.Context, operation string, fn func(ctx context.Context) (int, error)) error {
+	start := time.Now()
+
+	var rows int
+	err := lifecycle.retrying(ctx, operation, func(ctx context.Context) error {
+		var err error
+		rows, err = fn(ctx)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	lifecycle.queryCompleted(operation, start, rows)
+
+	return nil
+}
+
+func (lifecycle *workerLifecycle) queryCompleted(operation string, start time.Time, rowsAffected int) {
+	lifecycle.emitter.LifecycleQueryCompleted(operation, time.Since(start), rowsAffected)
+
+	lifecycle.countersLock.Lock()
+	lifecycle.counters.RowsAffected[operation] += rowsAffected
+	lifecycle.countersLock.Unlock()
+}
+
+func (lifecycle *workerLifecycle) workerStateChanged(name string, from, to WorkerState, reason string) {
+	if lifecycle.observer == nil || lifecycle.dryRun {
+		return
+	}
+
+	lifecycle.observer.WorkerStateChanged(name, from, to, reason)
+}
+
+func (lifecycle *workerLifecycle) workersStateChanged(names []string, from, to WorkerState, reason string) {
+	for _, name := range names {
+		lifecycle.workerStateChanged(name, from, to, reason)
+	}
+}
+
+func (lifecycle *workerLifecycle) workerTransitionsStateChanged(transitions []WorkerTransition) {
+	for _, transition := range transitions {
+		lifecycle.workerStateChanged(transition.Name, transition.From, transition.To, transition.Reason)
+	}
+}
+
+func (lifecycle *workerLifecycle) landedWorkersStateChanged(landedWorkers []LandedWorker) {
+	for _, landedWorker := range landedWorkers {
+		lifecycle.workerStateChanged(landedWorker.Name, landedWorker.From, WorkerStateLanded, landedWorker.Reason)
+	}
+}
+
+// workersWithActiveUninterruptibleBuilds selects, with unordered placeholders,
+// the workers with containers for incomplete builds matched by builds.
+func (lifecycle *workerLifecycle) workersWithActiveUninterruptibleBuilds(builds sq.Sqlizer, onWorkers ...sq.Sqlizer) (string, []any, error) {
+	query := lifecycle.activeBuildsOnWorkers("w.name").
+		Distinct().
+		Where(builds)
+
+	for _, where := range onWorkers {
+		query = query.Where(where)
+	}
+
+	return query.ToSql()
+}
+
+// activeBuildsOnWorkers selects the given columns of the incomplete builds, b,
+// with containers on the workers, w, along with their jobs, j.
+func (lifecycle *workerLifecycle) activeBuildsOnWorkers(columns ...string) sq.SelectBuilder {
+	return sq.Select(columns...).
+		From("builds b").
+		Join("containers c ON b.id = c.build_id").
+		Join(lifecycle.tableAs("w") + " ON w.name = c.worker_name").
+		LeftJoin("jobs j ON j.id = b.job_id").
+		Where(sq.Eq{"b.completed": false})
+}
+
+// uninterruptibleBuilds matches the builds of uninterruptible jobs and the
+// one-off builds selected by activeBuildsOnWorkers.
+var uninterruptibleBuilds = sq.Or{
+	sq.Eq{
+		"j.interruptible": false,
+	},
+	sq.Eq{
+		"b.job_id": nil,
+	},
+}
+
+// landingBlockingBuilds matches the uninterruptible builds which keep a worker
+// landing, leaving out the builds of jobs removed from their pipeline.
+var landingBlockingBuilds = sq.Or{
+	sq.Eq{
+		"j.interruptible": false,
+		"j.active":        true,
+	},
+	sq.Eq{
+		"b.job_id": nil,
+	},
+}
+
+// buildsOfDeletedJobs matches the builds which landingBlockingBuilds leaves
+// out because their job has been removed from its pipeline.
+var buildsOfDeletedJobs = sq.Eq{
+	"j.interruptible": false,
+	"j.active":        false,
+}
+
+// withoutUninterruptibleBuilds matches the workers, identified by the given
+// name column, which are not running any build matched by builds.
+//
+// Squirrel does not have default support for subqueries in where clauses.
+// We hacked together a way to do it
+//
+// First we generate the subquery's SQL and args using sq.Select instead of
+// psql.Select so that we get unordered placeholders instead of psql's ordered
+// placeholders. Then we inject the subquery sql directly into the where
+// clause, and "add" the args from the first query to the second query's args.
+func (lifecycle *workerLifecycle) withoutUninterruptibleBuilds(column string, builds sq.Sqlizer, onWorkers ...sq.Sqlizer) (sq.Sqlizer, error) {
+	subQ, subQArgs, err := lifecycle.workersWithActiveUninterruptibleBuilds(builds, onWorkers...)
+	if err != nil {
+		return nil, err
+	}
+
+	return sq.Expr(column+" NOT IN ("+subQ+")", subQArgs...), nil
+}
+
+// withoutActiveBuilds is like withoutUninterruptibleBuilds, but matches the
+// workers which are not running any build at all.
+func (lifecycle *workerLifecycle) withoutActiveBuilds(column string, onWorkers ...sq.Sqlizer) (sq.Sqlizer, error) {
+	query := lifecycle.activeBuildsOnWorkers("w.name").
+		Distinct()
+
+	for _, where := range onWorkers {
+		query = query.Where(where)
+	}
+
+	subQ, subQArgs, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	return sq.Expr(column+" NOT IN ("+subQ+")", subQArgs...), nil
+}
+
+// checkPlaceholders makes sure that a statement with injected subqueries
+// numbers its placeholders $1 to $n, with n the number of its args.
+func checkPlaceholders(query string, args []any, err error) (string, []any, error) {
+	if err != nil {
+		return "", nil, err
+	}
+
+	used := map[int]bool{}
+	highest := 0
+
+	var quote byte
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+
+		if quote != 0 {
+			if c == quote {
+				quote = 0
+			}
+			continue
+		}
+
+		if c == '\'' || c == '"' {
+			quote = c
+			continue
+		}
+
+		if c != '$' {
+			continue
+		}
+
+		j := i + 1
+		for j < len(query) && query[j] >= '0' && query[j] <= '9' {
+			j++
+		}
+
+		if j == i+1 {
+			continue
+		}
+
+		n, err := strconv.Atoi(query[i+1 : j])
+		if err != nil {
+			return "", nil, err
+		}
+
+		used[n] = true
+		highest = max(highest, n)
+		i = j - 1
+	}
+
+	if highest != len(args) || len(used) != len(args) {
+		return "", nil, fmt.Errorf("statement uses placeholders up to $%d (%d distinct) but has %d args", highest, len(used), len(args))
+	}
+
+	return query, args, nil
+}
+
+// workersTransitioning matches the workers whose state column is from, as
+// long as they are allowed to move to the to state.
+func workersTransitioning(column string, from, to WorkerState) sq.Sqlizer {
+	if !ValidWorkerTransition(from, to) {
+		return sq.Expr("false")
+	}
+
+	return sq.Eq{column: string(from)}
+}
+
+// workerStatesTransitioningTo returns the states which a worker is allowed to
+// move to the to state from.
+func workerStatesTransitioningTo(to WorkerState) []string {
+	fromStates := []string{}
+	for _, from := range AllWorkerStates() {
+		if ValidWorkerTransition(from, to) {
+			fromStates = append(fromStates, string(from))
+		}
+	}
+
+	return fromStates
+}
+
+// workerMutation describes a lifecycle operation which changes or deletes the
+// rows of table matched by where.
+type workerMutation struct {
+	table     string
+	where     sq.Sqlizer
+	statement func(suffix string) sq.Sqlizer
+
+	// previous is the alias under which the suffix sees the rows as they were
+	// before the mutation, if it can.
+	previous string
+}
+
+// preview selects the given columns of the workers the mutation would affect.
+func (mutation workerMutation) preview(columns string) sq.Sqlizer {
+	return sq.Select(columns).
+		From(mutation.table).
+		Where(mutation.where).
+		PlaceholderFormat(sq.Dollar)
+}
+
+// The statements are built with sq instead of psql so that the placeholders
+// of an injected subquery are numbered together with our own, and are then
+// changed using .PlaceholderFormat(sq.Dollar) to go back to postgres's format.
+
+func (lifecycle *workerLifecycle) updateWorkers(set map[string]any, where sq.Sqlizer) workerMutation {
+	set = lifecycle.processedBy(set)
+
+	return workerMutation{
+		table: lifecycle.tableAs("workers"),
+		where: where,
+		statement: func(suffix string) sq.Sqlizer {
+			return sq.Update(lifecycle.tableAs("workers")).
+				SetMap(set).
+				Where(where).
+				Suffix(suffix).
+				PlaceholderFormat(sq.Dollar)
+		},
+	}
+}
+
+// updateWorkersFromPrevious is like updateWorkers, but joins the workers with
+// themselves, aliased previous. The columns in where must be qualified.
+func (lifecycle *workerLifecycle) updateWorkersFromPrevious(set map[string]any, where sq.Sqlizer) workerMutation {
+	set = lifecycle.processedBy(set)
+
+	return workerMutation{
+		table: lifecycle.tableAs("workers"),
+		where: where,
+		statement: func(suffix string) sq.Sqlizer {
+			return sq.Update(lifecycle.tableAs("workers")).
+				SetMap(set).
+				From(lifecycle.tableAs("previous")).
+				Where("previous.name = workers.name").
+				Where(where).
+				Suffix(suffix).
+				PlaceholderFormat(sq.Dollar)
+		},
+		previous: "previous",
+	}
+}
+
+// processedBy adds the ATC running the lifecycle, if known, to the columns
+// set by an update. The given columns are left untouched.
+func (lifecycle *workerLifecycle) processedBy(set map[string]any) map[string]any {
+	if lifecycle.atcID == "" {
+		return set
+	}
+
+	withProcessedBy := make(map[string]any, len(set)+1)
+	maps.Copy(withProcessedBy, set)
+	withProcessedBy["processed_by"] = lifecycle.atcID
+
+	return withProcessedBy
+}
+
+func (lifecycle *workerLifecycle) deleteWorkers(where sq.Sqlizer) workerMutation {
+	return workerMutation{
+		table: lifecycle.tableAs("workers"),
+		where: where,
+		statement: func(suffix string) sq.Sqlizer {
+			return sq.Delete(lifecycle.tableAs("workers")).
+				Where(where).
+				Suffix(suffix).
+				PlaceholderFormat(sq.Dollar)
+		},
+		previous: "workers",
+	}
+}
+
+// workerStateColumns returns the columns to set when moving a worker to the
+// given state, which for some states is more than the state itself.
+func workerStateColumns(to WorkerState) map[string]any {
+	set := map[string]any{
+		"state": string(to),
+	}
+
+	switch to {
+	case WorkerStateStalled:
+		set["expires"] = nil
+		set["stalled_since"] = sq.Expr("NOW()")
+		set["stall_count"] = sq.Expr("workers.stall_count + 1")
+		set["missed_heartbeats"] = sq.Expr("workers.missed_heartbeats + 1")
+	case WorkerStateLanded:
+		set["addr"] = nil
+		set["baggageclaim_url"] = nil
+	}
+
+	return set
+}
+
+// unresponsiveEphemeralWorkers never matches the protected or quarantined
+// workers, nor the ones failing the EphemeralWorkerPredicate.
+func (lifecycle *workerLifecycle) unresponsiveEphemeralWorkers(protected []string, skew time.Duration) workerMutation {
+	where := sq.And{
+		sq.Eq{"workers.ephemeral": true},
+		sq.NotEq{"workers.state": string(WorkerStateQuarantined)},
+		sq.Expr(
+			fmt.Sprintf("workers.expires < NOW() - '%d second'::INTERVAL", int(skew.Seconds())),
+		),
+	}
+
+	if len(protected) > 0 {
+		where = append(where, sq.Expr("workers.name <> ALL(?)", protected))
+	}
+
+	if lifecycle.ephemeralWorkerPredicate != nil {
+		where = append(where, lifecycle.ephemeralWorkerPredicate())
+	}
+
+	if !lifecycle.softDelete {
+		return lifecycle.deleteWorkers(where)
+	}
+
+	set := map[string]any{
+		"state":            string(WorkerStateDeleted),
+		"deleted_at":       sq.Expr("NOW()"),
+		"expires":          nil,
+		"addr":             nil,
+		"baggageclaim_url": nil,
+	}
+
+	return lifecycle.updateWorkersFromPrevious(set, where)
+}
+
+func (lifecycle *workerLifecycle) deletedWorkersOlderThan(olderThan time.Duration) workerMutation {
+	return lifecycle.deleteWorkers(sq.And{
+		sq.Eq{"state": string(WorkerStateDeleted)},
+		sq.Expr(
+			fmt.Sprintf("deleted_at < NOW() - '%d second'::INTERVAL", int(olderThan.Seconds())),
+		),
+	})
+}
+
+func (lifecycle *workerLifecycle) workersOfDeletedTeams() workerMutation {
+	return lifecycle.deleteWorkers(sq.And{
+		sq.NotEq{"workers.team_id": nil},
+		sq.Expr("NOT EXISTS (SELECT 1 FROM teams t WHERE t.id = workers.team_id)"),
+	})
+}
+
+func (lifecycle *workerLifecycle) workersMissingFrom(existing []string) workerMutation {
+	return lifecycle.deleteWorkers(sq.And{
+		sq.Eq{"workers.ephemeral": true},
+		sq.NotEq{"workers.state": []string{string(WorkerStateQuarantined), string(WorkerStateDeleted)}},
+		sq.Expr("workers.name <> ALL(?)", existing),
+	})
+}
+
+func (lifecycle *workerLifecycle) unresponsiveWorkers(grace time.Duration, kind WorkerKind) workerMutation {
+	where := sq.And{
+		workersTransitioning("workers.state", WorkerStateRunning, WorkerStateStalled),
+		sq.Expr(
+			fmt.Sprintf("workers.expires < NOW() - '%d second'::INTERVAL", int(grace.Seconds())),
+		),
+	}
+
+	switch kind {
+	case WorkerKindEphemeral:
+		where = append(where, sq.Eq{"workers.ephemeral": true})
+	case WorkerKindPersistent:
+		where = append(where, sq.Eq{"workers.ephemeral": false})
+	}
+
+	if lifecycle.stallExemptLabel != "" {
+		where = append(where, sq.Expr("(workers.labels -> ?) IS NULL", lifecycle.stallExemptLabel))
+	}
+
+	return lifecycle.updateWorkersFromPrevious(workerStateColumns(WorkerStateStalled), where)
+}
+
+// stalledWorkersPastTimeout falls back to state_changed_at for workers which
+// have no stalled_since.
+func (lifecycle *workerLifecycle) stalledWorkersPastTimeout(timeout time.Duration) workerMutation {
+	return lifecycle.deleteWorkers(sq.And{
+		sq.Eq{"state": string(WorkerStateStalled)},
+		sq.Expr(
+			fmt.Sprintf("COALESCE(stalled_since, state_changed_at) < NOW() - '%d second'::INTERVAL", int(timeout.Seconds())),
+		),
+	})
+}
+
+// stalledWorkersPastTTL is like stalledWorkersPastTimeout, but with the
+// timeout of each worker, or the StallTTL option if the worker has none.
+func (lifecycle *workerLifecycle) stalledWorkersPastTTL() workerMutation {
+	where := sq.And{
+		sq.Eq{"state": string(WorkerStateStalled)},
+	}
+
+	ttl := "stall_ttl"
+	if lifecycle.stallTTL == 0 {
+		where = append(where, sq.NotEq{"stall_ttl": nil})
+	} else {
+		ttl = fmt.Sprintf("COALESCE(stall_ttl, '%d second'::INTERVAL)", int(lifecycle.stallTTL.Seconds()))
+	}
+
+	where = append(where, sq.Expr("COALESCE(stalled_since, state_changed_at) + "+ttl+" < NOW()"))
+
+	return lifecycle.deleteWorkers(where)
+}
+
+// runningWorkers starts landing the running workers, narrowed down to the
+// ones matched by only unless it is nil.
+func (lifecycle *workerLifecycle) runningWorkers(only sq.Sqlizer) workerMutation {
+	where := sq.And{
+		workersTransitioning("state", WorkerStateRunning, WorkerStateLanding),
+	}
+
+	if only != nil {
+		where = append(where, only)
+	}
+
+	return lifecycle.updateWorkers(workerStateColumns(WorkerStateLanding), where)
+}
+
+// taggedWith matches the workers carrying the given tag. The tags are stored
+// as a JSON array, which is null for workers without any tags.
+func taggedWith(tag string) sq.Sqlizer {
+	return sq.Expr("workers.tags::jsonb @> jsonb_build_array(?::text)", tag)
+}
+
+// finishedLandingWorkers lands the landing workers matched by notBusy and the
+// draining workers matched by idle, unless another ATC has claimed them.
+func (lifecycle *workerLifecycle) finishedLandingWorkers(notBusy, idle, only sq.Sqlizer) workerMutation {
+	where := sq.And{
+		sq.Or{
+			sq.And{
+				workersTransitioning("workers.state", WorkerStateLanding, WorkerStateLanded),
+				notBusy,
+			},
+			sq.And{
+				workersTransitioning("workers.state", WorkerStateDraining, WorkerStateLanded),
+				idle,
+			},
+		},
+		sq.Or{
+			sq.Eq{"workers.landing_owner": nil},
+			sq.Eq{"workers.landing_owner": lifecycle.atcID},
+			sq.Expr("workers.landing_lease_expires < NOW()"),
+		},
+	}
+
+	if only != nil {
+		where = append(where, only)
+	}
+
+	set := workerStateColumns(WorkerStateLanded)
+	set["ever_landed"] = true
+	set["landing_owner"] = nil
+	set["landing_lease_expires"] = nil
+
+	return lifecycle.updateWorkersFromPrevious(set, where)
+}
+
+// keepingTeamMinimum matches the global workers, and the candidates of each
+// team which can land while leaving it at least minPerTeam workers.
+func (lifecycle *workerLifecycle) keepingTeamMinimum(minPerTeam int) sq.Sqlizer {
+	return sq.Or{
+		sq.Eq{"workers.team_id": nil},
+		sq.Expr(`workers.name IN (
+			SELECT candidate.name
+			FROM (
+				SELECT
+					name,
+					team_id,
+					ROW_NUMBER() OVER (PARTITION BY team_id ORDER BY name) AS position,
+					COUNT(*) OVER (PARTITION BY team_id) AS candidates
+				FROM `+lifecycle.table+`
+				WHERE state IN (?, ?) AND team_id IS NOT NULL
+			) candidate
+			LEFT JOIN (
+				SELECT team_id, COUNT(*) AS running
+				FROM `+lifecycle.table+`
+				WHERE state = ? AND team_id IS NOT NULL
+				GROUP BY team_id
+			) team ON team.team_id = candidate.team_id
+			WHERE COALESCE(team.running, 0) + (candidate.candidates - candidate.position) >= ?
+		)`,
+			string(WorkerStateLanding),
+			string(WorkerStateDraining),
+			string(WorkerStateRunning),
+			minPerTeam,
+		),
+	}
+}
+
+func (lifecycle *workerLifecycle) transitioningWorker(name string, from, to WorkerState) workerMutation {
+	where := sq.And{
+		sq.Eq{"name": name},
+		workersTransitioning("state", from, to),
+	}
+
+	if workerStateNeedsAddress(to) {
+		where = append(where, workersWithAddress())
+	}
+
+	return lifecycle.updateWorkers(workerStateColumns(to), where)
+}
+
+// workersNamed moves the named workers which are in one of the from states to
+// the to state.
+func (lifecycle *workerLifecycle) workersNamed(names []string, fromStates []string, to WorkerState) workerMutation {
+	where := sq.And{
+		sq.Expr("workers.name = ANY(?)", names),
+		sq.Expr("workers.state::text = ANY(?)", fromStates),
+	}
+
+	if workerStateNeedsAddress(to) {
+		where = append(where, workersWithAddress())
+	}
+
+	return lifecycle.updateWorkersFromPrevious(workerStateColumns(to), where)
+}
+
+// workerStateNeedsAddress reports whether the addr_when_running constraint
+// requires a worker in the state to have an address.
+func workerStateNeedsAddress(state WorkerState) bool {
+	switch state {
+	case WorkerStateStalled, WorkerStateLanded, WorkerStateDeleted:
+		return false
+	default:
+		return true
+	}
+}
+
+func workersWithAddress() sq.Sqlizer {
+	return sq.Or{
+		sq.NotEq{"workers.addr": nil},
+		sq.NotEq{"workers.baggageclaim_url": nil},
+	}
+}
+
+func (lifecycle *workerLifecycle) retiringWorkers() workerMutation {
+	return lifecycle.deleteWorkers(sq.Eq{"state": string(WorkerStateRetiring)})
+}
+
+// finishedRetiringWorkers matches at most limit of the workers, picked by
+// ctid. A limit of zero or less matches all of them.
+func (lifecycle *workerLifecycle) finishedRetiringWorkers(notBusy sq.Sqlizer, limit int) workerMutation {
+	where := sq.And{
+		sq.Eq{"state": string(WorkerStateRetiring)},
+		notBusy,
+	}
+
+	if limit > 0 {
+		batch := sq.Select("ctid").
+			From(lifecycle.table).
+			Where(where).
+			Limit(uint64(limit))
+
+		where = sq.And{
+			sq.Eq{"state": string(WorkerStateRetiring)},
+			notBusy,
+			sq.Expr("ctid IN (?)", batch),
+		}
+	}
+
+	return lifecycle.deleteWorkers(where)
+}
+
+// mutateWorkers runs the mutation and returns the names of the affected
+// workers.
+func (lifecycle *workerLifecycle) mutateWorkers(ctx context.Context, runner sq.RunnerContext, mutation workerMutation) ([]string, error) {
+	query, args, err := lifecycle.mutationSQL(mutation)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := runner.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return workersAffected(rows)
+}
+
+// mutationSQL builds the statement mutateWorkers runs for the mutation.
+func (lifecycle *workerLifecycle) mutationSQL(mutation workerMutation) (string, []any, error) {
+	return checkPlaceholders(lifecycle.mutation(
+		mutation.statement("RETURNING name"),
+		mutation.preview("name"),
+	).ToSql())
+}
+
+// transitionsSQL builds the statement for a mutation moving workers, which
+// returns the name and previous state of every worker.
+func (lifecycle *workerLifecycle) transitionsSQL(mutation workerMutation) (string, []any, error) {
+	return lifecycle.mutation(
+		mutation.statement("RETURNING workers.name, "+mutation.previous+".state"),
+		mutation.preview("workers.name, workers.state"),
+	).ToSql()
+}
+
+// countMutatedWorkers runs the mutation and returns how many rows, usually
+// workers, were affected, without reading them.
+func (lifecycle *workerLifecycle) countMutatedWorkers(ctx context.Context, runner sq.RunnerContext, operation string, mutation workerMutation) (int, error) {
+	var count int
+	err := lifecycle.run(ctx, operation, func(ctx context.Context) (int, error) {
+		if lifecycle.dryRun {
+			query, args, err := checkPlaceholders(mutation.preview("COUNT(*)").ToSql())
+			if err != nil {
+				return 0, err
+			}
+
+			err = runner.QueryRowContext(ctx, query, args...).Scan(&count)
+			return count, err
+		}
+
+		query, args, err := checkPlaceholders(mutation.statement("").ToSql())
+		if err != nil {
+			return 0, err
+		}
+
+		result, err := runner.ExecContext(ctx, query, args...)
+		if err != nil {
+			return 0, err
+		}
+
+		affected, err := result.RowsAffected()
+		count = int(affected)
+		return count, err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func countWorkers(workerNames []string, err error) (int, error) {
+	return len(workerNames), err
+}
+
+// WithWorkerCount wraps a lifecycle method returning the affected workers so
+// that the caller also gets their count:
+//
+//	names, count, err := db.WithWorkerCount(lifecycle.DeleteStalledWorkers(ctx, timeout))
+func WithWorkerCount(workerNames []string, err error) ([]string, int, error) {
+	return workerNames, len(workerNames), err
+}
+
+func scanDeletedWorkers(rows *sql.Rows, err error) ([]DeletedWorker, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	var deletedWorkers []DeletedWorker
+
+	err = scanWorkerRows(rows, func(rows *sql.Rows) error {
+		var (
+			deletedWorker = DeletedWorker{Reason: WorkerTransitionReasonEphemeralExpired}
+			teamName      sql.NullString
+			addr          sql.NullString
+			expires       sql.NullTime
+		)
+
+		err := rows.Scan(
+			&deletedWorker.Name,
+			&teamName,
+			&addr,
+			&expires,
+			&deletedWorker.State,
+		)
+		if err != nil {
+			return err
+		}
+
+		if teamName.Valid {
+			deletedWorker.TeamName = teamName.String
+		}
+
+		if addr.Valid {
+			deletedWorker.Addr = &addr.String
+		}
+
+		deletedWorker.Expires = expires.Time
+
+		deletedWorkers = append(deletedWorkers, deletedWorker)
+
+		return nil
+	})
+	if err != nil {
+		return deletedWorkers, err
+	}
+
+	if deletedWorkers == nil {
+		deletedWorkers = []DeletedWorker{}
+	}
+
+	return deletedWorkers, nil
+}
+
+func scanLandedWorkers(rows *sql.Rows, err error) ([]LandedWorker, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	var landedWorkers []LandedWorker
+
+	err = scanWorkerRows(rows, func(rows *sql.Rows) error {
+		var (
+			landedWorker = LandedWorker{Reason: WorkerTransitionReasonLandingComplete}
+			seconds      float64
+		)
+
+		err := rows.Scan(
+			&landedWorker.Name,
+			&landedWorker.From,
+			&seconds,
+			&landedWorker.ActiveContainers,
+			&landedWorker.ActiveVolumes,
+		)
+		if err != nil {
+			return err
+		}
+
+		landedWorker.LandingDuration = secondsToDuration(seconds)
+
+		landedWorkers = append(landedWorkers, landedWorker)
+
+		return nil
+	})
+	if err != nil {
+		return landedWorkers, err
+	}
+
+	if landedWorkers == nil {
+		landedWorkers = []LandedWorker{}
+	}
+
+	return landedWorkers, nil
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// scanWorkerTransitions reads the name and previous state of the workers moved
+// to the given state for the given reason.
+func scanWorkerTransitions(rows *sql.Rows, err error, to WorkerState, reason string) ([]WorkerTransition, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	var transitions []WorkerTransition
+
+	err = scanWorkerRows(rows, func(rows *sql.Rows) error {
+		transition := WorkerTransition{To: to, Reason: reason}
+
+		err := rows.Scan(&transition.Name, &transition.From)
+		if err != nil {
+			return err
+		}
+
+		transitions = append(transitions, transition)
+
+		return nil
+	})
+	if err != nil {
+		return transitions, err
+	}
+
+	if transitions == nil {
+		transitions = []WorkerTransition{}
+	}
+
+	return transitions, nil
+}
+
+// deletedWorkerNames, landedWorkerNames, workerTransitionNames and the
+// *Affected helpers keep a nil slice of workers nil.
+
+func deletedWorkerNames(deletedWorkers []DeletedWorker) []string {
+	if deletedWorkers == nil {
+		return nil
+	}
+
+	workerNames := []string{}
+	for _, deletedWorker := range deletedWorkers {
+		workerNames = append(workerNames, deletedWorker.Name)
+	}
+
+	return workerNames
+}
+
+func workerTransitionNames(transitions []WorkerTransition) []string {
+	if transitions == nil {
+		return nil
+	}
+
+	workerNames := []string{}
+	for _, transition := range transitions {
+		workerNames = append(workerNames, transition.Name)
+	}
+
+	return workerNames
+}
+
+func workerTransitionsAffected(transitions []WorkerTransition) []WorkerAffected {
+	if transitions == nil {
+		return nil
+	}
+
+	affectedWorkers := []WorkerAffected{}
+	for _, transition := range transitions {
+		affectedWorkers = append(affectedWorkers, WorkerAffected{Name: transition.Name, Reason: transition.Reason})
+	}
+
+	return affectedWorkers
+}
+
+func landedWorkersAffected(landedWorkers []LandedWorker) []WorkerAffected {
+	if landedWorkers == nil {
+		return nil
+	}
+
+	affectedWorkers := []WorkerAffected{}
+	for _, landedWorker := range landedWorkers {
+		affectedWorkers = append(affectedWorkers, WorkerAffected{Name: landedWorker.Name, Reason: landedWorker.Reason})
+	}
+
+	return affectedWorkers
+}
+
+func landedWorkerNames(landedWorkers []LandedWorker) []string {
+	if landedWorkers == nil {
+		return nil
+	}
+
+	workerNames := []string{}
+	for _, landedWorker := range landedWorkers {
+		workerNames = append(workerNames, landedWorker.Name)
+	}
+
+	return workerNames
+}
+
+// workersAffected reads the worker names from rows. If reading fails midway,
+// the names read so far are returned along with the error.
+func workersAffected(rows *sql.Rows) ([]string, error) {
+	var workerNames []string
+
+	err := scanWorkerRows(rows, func(rows *sql.Rows) error {
+		var name string
+
+		err := rows.Scan(&name)
+		if err != nil {
+			return err
+		}
+
+		workerNames = append(workerNames, name)
+
+		return nil
+	})
+	if err != nil {
+		return workerNames, err
+	}
+
+	if workerNames == nil {
+		workerNames = []string{}
+	}
+
+	return workerNames, nil
+}
+
+// scanRows scans every row into a T with scan and closes the rows. The rows
+// read before a failure are returned along with the error.
+func scanRows[T any](rows *sql.Rows, scan func(rows *sql.Rows) (T, error)) ([]T, error) {
+	var scanned []T
+
+	err := scanWorkerRows(rows, func(rows *sql.Rows) error {
+		value, err := scan(rows)
+		if err != nil {
+			return err
+		}
+
+		scanned = append(scanned, value)
+
+		return nil
+	})
+
+	return scanned, err
+}
+
+// scanDurationsByName scans rows of a worker name and a number of seconds
+// into durations by name.
+func scanDurationsByName(rows *sql.Rows) (map[string]time.Duration, error) {
+	durations := make(map[string]time.Duration)
+
+	err := scanWorkerRows(rows, func(rows *sql.Rows) error {
+		var (
+			name    string
+			seconds float64
+		)
+
+		err := rows.Scan(&name, &seconds)
+		if err != nil {
+			return err
+		}
+
+		durations[name] = secondsToDuration(seconds)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return durations, nil
+}
+
+// scanWorkerRows calls scan for every row and closes the rows. scan keeps
+// whatever it builds, so that the rows read before a failure are not lost.
+func scanWorkerRows(rows *sql.Rows, scan func(rows *sql.Rows) error) error {
+	defer Close(rows)
+
+	for rows.Next() {
+		err := scan(rows)
+		if err != nil {
+			return err
+		}
 	}
 
-	return workerNames, err
+	return rows.Err()
 }
@@ -0,0 +1,1312 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+// WorkerStateInfo describes a worker's state along with the team it belongs
+// to. TeamName is nil for global workers.
+type WorkerStateInfo struct {
+	State    WorkerState
+	TeamName *string
+}
+
+// WorkerStateTags describes a worker's state along with its tags. Tags is
+// empty rather than nil for untagged workers.
+type WorkerStateTags struct {
+	State WorkerState
+	Tags  []string
+}
+
+// WorkerCapacity describes a worker's state along with how many containers
+// and volumes it last reported as active.
+type WorkerCapacity struct {
+	State            WorkerState
+	ActiveContainers int
+	ActiveVolumes    int
+}
+
+// WorkerHeadroom describes how many more containers a worker can take, out of
+// the Total it is allowed to run.
+type WorkerHeadroom struct {
+	Name  string
+	Free  int
+	Total int
+}
+
+// UnboundedWorkerContainers is the Total of the WorkerHeadroom of a worker
+// without a max_containers.
+const UnboundedWorkerContainers = math.MaxInt32
+
+// StateTransition is an entry of a worker's state history. From is empty when
+// the worker registered, and To when it was deleted.
+type StateTransition struct {
+	From        WorkerState
+	To          WorkerState
+	ProcessedBy string
+	At          time.Time
+}
+
+// AgeStats describes how long the workers in a state have been in it.
+type AgeStats struct {
+	Min time.Duration
+	Avg time.Duration
+	Max time.Duration
+}
+
+// BlockingBuild describes an incomplete build which keeps a worker from
+// landing. JobName is nil for one-off builds.
+type BlockingBuild struct {
+	BuildID       int
+	JobName       *string
+	Interruptible bool
+}
+
+// WorkerInconsistency describes a worker whose row violates one of the
+// invariants the lifecycle maintains.
+type WorkerInconsistency struct {
+	Name      string
+	State     WorkerState
+	Violation string
+}
+
+// workerInvariants are the invariants checked by FindInconsistentWorkers.
+var workerInvariants = []struct {
+	violation string
+	where     sq.Sqlizer
+}{
+	{
+		violation: "running worker has no heartbeat expiry",
+		where: sq.And{
+			sq.Eq{"state": string(WorkerStateRunning)},
+			sq.Eq{"expires": nil},
+		},
+	},
+	{
+		violation: "stalled worker still has a heartbeat expiry",
+		where: sq.And{
+			sq.Eq{"state": string(WorkerStateStalled)},
+			sq.NotEq{"expires": nil},
+		},
+	},
+	{
+		violation: "landed worker still has an address",
+		where: sq.And{
+			sq.Eq{"state": string(WorkerStateLanded)},
+			sq.Or{
+				sq.NotEq{"addr": nil},
+				sq.NotEq{"baggageclaim_url": nil},
+			},
+		},
+	},
+	{
+		violation: "deleted worker has no deletion time",
+		where: sq.And{
+			sq.Eq{"state": string(WorkerStateDeleted)},
+			sq.Eq{"deleted_at": nil},
+		},
+	},
+}
+
+// GetDeletableEphemeralWorkers returns the ephemeral workers which
+// DeleteUnresponsiveEphemeralWorkers would delete, without deleting them.
+func (lifecycle *workerLifecycle) GetDeletableEphemeralWorkers(ctx context.Context) ([]string, error) {
+	return lifecycle.readWorkerNames(ctx, "get-deletable-ephemeral-workers", lifecycle.unresponsiveEphemeralWorkers(nil, 0).preview("name"))
+}
+
+func (lifecycle *workerLifecycle) GetWorkerStateByName(ctx context.Context) (map[string]WorkerState, error) {
+	return lifecycle.getWorkerStateByName(ctx, lifecycle.workerStatesQuery())
+}
+
+// StreamWorkerStates calls fn with the state of every worker, one row at a
+// time, and stops as soon as fn returns an error.
+func (lifecycle *workerLifecycle) StreamWorkerStates(ctx context.Context, fn func(name string, state WorkerState) error) error {
+	var fnErr error
+	err := lifecycle.run(ctx, "stream-worker-states", func(ctx context.Context) (int, error) {
+		rows, err := lifecycle.workerStatesQuery().
+			RunWith(lifecycle.conn).
+			QueryContext(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		var streamed int
+		err = scanWorkerRows(rows, func(rows *sql.Rows) error {
+			var (
+				name  string
+				state WorkerState
+			)
+
+			err := rows.Scan(&name, &state)
+			if err != nil {
+				return err
+			}
+
+			streamed++
+
+			fnErr = fn(name, state)
+			return fnErr
+		})
+
+		return streamed, err
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+
+	return err
+}
+
+// GetWorkerStateByNameForTeam returns the state of the workers that are
+// visible to a team, which includes the global workers not scoped to any team.
+func (lifecycle *workerLifecycle) GetWorkerStateByNameForTeam(ctx context.Context, teamID int) (map[string]WorkerState, error) {
+	return lifecycle.getWorkerStateByName(ctx, lifecycle.workerStatesQuery().Where(sq.Or{
+		sq.Eq{"team_id": teamID},
+		sq.Eq{"team_id": nil},
+	}))
+}
+
+// GetWorkerStatesPaged returns the state of at most limit workers ordered by
+// name, skipping the first offset of them.
+func (lifecycle *workerLifecycle) GetWorkerStatesPaged(ctx context.Context, limit, offset int) (map[string]WorkerState, error) {
+	if limit < 0 || offset < 0 {
+		return nil, fmt.Errorf("invalid page: limit %d, offset %d", limit, offset)
+	}
+
+	return lifecycle.getWorkerStateByName(ctx, lifecycle.workerStatesQuery().
+		OrderBy("name").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)))
+}
+
+// GetWorkerStateHistory returns the state transitions of the named worker,
+// oldest first, including those of a deleted worker.
+func (lifecycle *workerLifecycle) GetWorkerStateHistory(ctx context.Context, name string) ([]StateTransition, error) {
+	var history []StateTransition
+	err := lifecycle.run(ctx, "get-worker-state-history", func(ctx context.Context) (int, error) {
+		rows, err := psql.Select("COALESCE(from_state, '')", "COALESCE(to_state, '')", "COALESCE(processed_by, '')", "transitioned_at").
+			From("worker_state_transitions").
+			Where(sq.Eq{"worker_name": name}).
+			OrderBy("transitioned_at", "id").
+			RunWith(lifecycle.conn).
+			QueryContext(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		history, err = scanRows(rows, func(rows *sql.Rows) (StateTransition, error) {
+			var (
+				transition StateTransition
+				from, to   string
+			)
+
+			err := rows.Scan(&from, &to, &transition.ProcessedBy, &transition.At)
+			if err != nil {
+				return transition, err
+			}
+
+			transition.From = WorkerState(from)
+			transition.To = WorkerState(to)
+
+			return transition, nil
+		})
+
+		return len(history), err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if history == nil {
+		history = []StateTransition{}
+	}
+
+	return history, nil
+}
+
+// GetWorkersInState returns the names of the workers in the given state,
+// ordered by name.
+func (lifecycle *workerLifecycle) GetWorkersInState(ctx context.Context, state WorkerState) ([]string, error) {
+	return lifecycle.readWorkerNames(ctx, "get-workers-in-state", psql.Select("name").
+		From(lifecycle.tableAs("workers")).
+		Where(sq.Eq{"state": string(state)}).
+		OrderBy("name"))
+}
+
+// FindUnhealthyBaggageclaimWorkers returns the running workers whose
+// baggageclaim was last marked unhealthy.
+func (lifecycle *workerLifecycle) FindUnhealthyBaggageclaimWorkers(ctx context.Context) ([]string, error) {
+	return lifecycle.readWorkerNames(ctx, "find-unhealthy-baggageclaim-workers", psql.Select("name").
+		From(lifecycle.tableAs("workers")).
+		Where(sq.Eq{
+			"state":                string(WorkerStateRunning),
+			"baggageclaim_healthy": false,
+		}).
+		OrderBy("name"))
+}
+
+// GetChronicallyStalledWorkers returns the workers which have been stalled
+// more than threshold times, counted across re-registrations.
+func (lifecycle *workerLifecycle) GetChronicallyStalledWorkers(ctx context.Context, threshold int) ([]string, error) {
+	return lifecycle.readWorkerNames(ctx, "get-chronically-stalled-workers", psql.Select("name").
+		From(lifecycle.tableAs("workers")).
+		Where(sq.Gt{"stall_count": threshold}).
+		OrderBy("name"))
+}
+
+// FindWorkersMissingHeartbeats returns the workers which have missed more than
+// n heartbeats in a row.
+func (lifecycle *workerLifecycle) FindWorkersMissingHeartbeats(ctx context.Context, n int) ([]string, error) {
+	return lifecycle.readWorkerNames(ctx, "find-workers-missing-heartbeats", psql.Select("name").
+		From(lifecycle.tableAs("workers")).
+		Where(sq.Gt{"missed_heartbeats": n}).
+		OrderBy("name"))
+}
+
+// FindIdleWorkers returns the running workers which have had no build
+// containers active in the last idleFor.
+func (lifecycle *workerLifecycle) FindIdleWorkers(ctx context.Context, idleFor time.Duration) ([]string, error) {
+	return lifecycle.readWorkerNames(ctx, "find-idle-workers", lifecycle.idleWorkers(idleFor))
+}
+
+// FindScaleDownCandidates returns at most limit of the idle workers which have
+// also been running for at least idleFor. A limit of zero returns them all.
+func (lifecycle *workerLifecycle) FindScaleDownCandidates(ctx context.Context, idleFor time.Duration, limit int) ([]string, error) {
+	query := lifecycle.idleWorkers(idleFor).
+		Where(fmt.Sprintf("w.state_changed_at < NOW() - '%d second'::INTERVAL", int(idleFor.Seconds())))
+
+	if limit > 0 {
+		query = query.Limit(uint64(limit))
+	}
+
+	return lifecycle.readWorkerNames(ctx, "find-scale-down-candidates", query)
+}
+
+// idleWorkers selects the names of the running workers, aliased w, which have
+// had no build containers active in the last idleFor, ordered by name.
+func (lifecycle *workerLifecycle) idleWorkers(idleFor time.Duration) sq.SelectBuilder {
+	return psql.Select("w.name").
+		From(lifecycle.tableAs("w")).
+		LeftJoin(
+			"containers c ON c.worker_name = w.name AND c.state::text = ANY(?)",
+			[]string{atc.ContainerStateCreating, atc.ContainerStateCreated},
+		).
+		LeftJoin(fmt.Sprintf(
+			"builds b ON b.id = c.build_id AND (NOT b.completed OR b.end_time > NOW() - '%d second'::INTERVAL)",
+			int(idleFor.Seconds()),
+		)).
+		Where(sq.Eq{"w.state": string(WorkerStateRunning)}).
+		GroupBy("w.name").
+		Having("COUNT(b.id) = 0").
+		OrderBy("w.name")
+}
+
+// FindStuckRetiringWorkers returns the workers which have been retiring for
+// more than stuckFor and are still held up by uninterruptible builds.
+func (lifecycle *workerLifecycle) FindStuckRetiringWorkers(ctx context.Context, stuckFor time.Duration) ([]string, error) {
+	var workerNames []string
+	err := lifecycle.run(ctx, "find-stuck-retiring-workers", func(ctx context.Context) (int, error) {
+		busyQ, busyArgs, err := lifecycle.workersWithActiveUninterruptibleBuilds(uninterruptibleBuilds)
+		if err != nil {
+			return 0, err
+		}
+
+		query, args, err := checkPlaceholders(sq.Select("name").
+			From(lifecycle.tableAs("workers")).
+			Where(sq.Eq{"state": string(WorkerStateRetiring)}).
+			Where(fmt.Sprintf("state_changed_at < NOW() - '%d second'::INTERVAL", int(stuckFor.Seconds()))).
+			Where(sq.Expr("name IN ("+busyQ+")", busyArgs...)).
+			OrderBy("name").
+			PlaceholderFormat(sq.Dollar).
+			ToSql())
+		if err != nil {
+			return 0, err
+		}
+
+		rows, err := lifecycle.conn.QueryContext(ctx, query, args...)
+		if err != nil {
+			return 0, err
+		}
+
+		workerNames, err = workersAffected(rows)
+		return len(workerNames), err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return workerNames, nil
+}
+
+// FindNeverLandedWorkers returns the soft deleted workers which were reaped
+// without having finished landing since they last registered.
+func (lifecycle *workerLifecycle) FindNeverLandedWorkers(ctx context.Context) ([]string, error) {
+	return lifecycle.readWorkerNames(ctx, "find-never-landed-workers", psql.Select("name").
+		From(lifecycle.tableAs("workers")).
+		Where(sq.Eq{
+			"state":       string(WorkerStateDeleted),
+			"ever_landed": false,
+		}).
+		OrderBy("name"))
+}
+
+// FindWorkersBlockedByDeletedJobs returns the landing workers which still have
+// incomplete builds of uninterruptible jobs that have since been removed.
+func (lifecycle *workerLifecycle) FindWorkersBlockedByDeletedJobs(ctx context.Context) ([]string, error) {
+	return lifecycle.readWorkerNames(ctx, "find-workers-blocked-by-deleted-jobs", lifecycle.activeBuildsOnWorkers("w.name").
+		Distinct().
+		Where(sq.Eq{"w.state": string(WorkerStateLanding)}).
+		Where(buildsOfDeletedJobs).
+		OrderBy("w.name").
+		PlaceholderFormat(sq.Dollar))
+}
+
+// FindWorkersWithOutdatedResourceTypes returns the workers advertising a
+// version of an expected resource type, keyed by type, other than the expected one.
+func (lifecycle *workerLifecycle) FindWorkersWithOutdatedResourceTypes(ctx context.Context, expected map[string]string) ([]string, error) {
+	var workerNames []string
+	err := lifecycle.run(ctx, "find-workers-with-outdated-resource-types", func(ctx context.Context) (int, error) {
+		rows, err := psql.Select("name", "resource_types").
+			From(lifecycle.tableAs("workers")).
+			Where(sq.NotEq{"state": string(WorkerStateDeleted)}).
+			OrderBy("name").
+			RunWith(lifecycle.conn).
+			QueryContext(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		workerNames = []string{}
+
+		err = scanWorkerRows(rows, func(rows *sql.Rows) error {
+			var (
+				name          string
+				resourceTypes sql.NullString
+			)
+
+			err := rows.Scan(&name, &resourceTypes)
+			if err != nil {
+				return err
+			}
+
+			if !resourceTypes.Valid || resourceTypes.String == "" {
+				return nil
+			}
+
+			var advertised []atc.WorkerResourceType
+			err = json.Unmarshal([]byte(resourceTypes.String), &advertised)
+			if err != nil {
+				return err
+			}
+
+			for _, resourceType := range advertised {
+				version, found := expected[resourceType.Type]
+				if found && resourceType.Version != version {
+					workerNames = append(workerNames, name)
+					break
+				}
+			}
+
+			return nil
+		})
+
+		return len(workerNames), err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return workerNames, nil
+}
+
+// FindDuplicateWorkerAddresses returns the names of the workers claiming each
+// address which is claimed by more than one worker.
+func (lifecycle *workerLifecycle) FindDuplicateWorkerAddresses(ctx context.Context) (map[string][]string, error) {
+	notLanded := sq.NotEq{"state": string(WorkerStateLanded)}
+
+	duplicates := sq.Select("addr").
+		From(lifecycle.table).
+		Where(notLanded).
+		Where(sq.NotEq{"addr": nil}).
+		GroupBy("addr").
+		Having("COUNT(*) > 1")
+
+	var namesByAddr map[string][]string
+	err := lifecycle.run(ctx, "find-duplicate-worker-addresses", func(ctx context.Context) (int, error) {
+		rows, err := sq.Select("addr", "name").
+			From(lifecycle.tableAs("workers")).
+			Where(notLanded).
+			Where(sq.Expr("addr IN (?)", duplicates)).
+			OrderBy("addr", "name").
+			PlaceholderFormat(sq.Dollar).
+			RunWith(lifecycle.conn).
+			QueryContext(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		namesByAddr = make(map[string][]string)
+
+		err = scanWorkerRows(rows, func(rows *sql.Rows) error {
+			var addr, name string
+
+			err := rows.Scan(&addr, &name)
+			if err != nil {
+				return err
+			}
+
+			namesByAddr[addr] = append(namesByAddr[addr], name)
+
+			return nil
+		})
+
+		return len(namesByAddr), err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return namesByAddr, nil
+}
+
+// GetWorkerStatesWithTeam returns the state of every worker along with the
+// name of its team.
+func (lifecycle *workerLifecycle) GetWorkerStatesWithTeam(ctx context.Context) (map[string]WorkerStateInfo, error) {
+	var stateInfoByName map[string]WorkerStateInfo
+	err := lifecycle.run(ctx, "get-worker-states-with-team", func(ctx context.Context) (int, error) {
+		rows, err := psql.Select("workers.name", "workers.state", "t.name").
+			From(lifecycle.tableAs("workers")).
+			LeftJoin("teams t ON t.id = workers.team_id").
+			RunWith(lifecycle.conn).
+			QueryContext(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		stateInfoByName = make(map[string]WorkerStateInfo)
+
+		err = scanWorkerRows(rows, func(rows *sql.Rows) error {
+			var (
+				name      string
+				stateInfo WorkerStateInfo
+				teamName  sql.NullString
+			)
+
+			err := rows.Scan(&name, &stateInfo.State, &teamName)
+			if err != nil {
+				return err
+			}
+
+			if teamName.Valid {
+				stateInfo.TeamName = &teamName.String
+			}
+
+			stateInfoByName[name] = stateInfo
+
+			return nil
+		})
+
+		return len(stateInfoByName), err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return stateInfoByName, nil
+}
+
+// GetWorkerStatesWithTags returns the state of every worker along with its tags.
+func (lifecycle *workerLifecycle) GetWorkerStatesWithTags(ctx context.Context) (map[string]WorkerStateTags, error) {
+	var stateTagsByName map[string]WorkerStateTags
+	err := lifecycle.run(ctx, "get-worker-states-with-tags", func(ctx context.Context) (int, error) {
+		rows, err := psql.Select("workers.name", "workers.state", "workers.tags").
+			From(lifecycle.tableAs("workers")).
+			RunWith(lifecycle.conn).
+			QueryContext(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		stateTagsByName = make(map[string]WorkerStateTags)
+
+		err = scanWorkerRows(rows, func(rows *sql.Rows) error {
+			var (
+				name      string
+				stateTags WorkerStateTags
+				tags      sql.NullString
+			)
+
+			err := rows.Scan(&name, &stateTags.State, &tags)
+			if err != nil {
+				return err
+			}
+
+			// tags is stored as JSON, which may be NULL or "null" for untagged
+			// workers
+			if tags.Valid && tags.String != "" {
+				err = json.Unmarshal([]byte(tags.String), &stateTags.Tags)
+				if err != nil {
+					return err
+				}
+			}
+
+			if stateTags.Tags == nil {
+				stateTags.Tags = []string{}
+			}
+
+			stateTagsByName[name] = stateTags
+
+			return nil
+		})
+
+		return len(stateTagsByName), err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return stateTagsByName, nil
+}
+
+// GetWorkerStatesWithCapacity returns the state of every worker along with its
+// active containers and volumes.
+func (lifecycle *workerLifecycle) GetWorkerStatesWithCapacity(ctx context.Context) (map[string]WorkerCapacity, error) {
+	var capacityByName map[string]WorkerCapacity
+	err := lifecycle.run(ctx, "get-worker-states-with-capacity", func(ctx context.Context) (int, error) {
+		rows, err := psql.Select(
+			"name",
+			"state",
+			"COALESCE(active_containers, 0)",
+			"COALESCE(active_volumes, 0)",
+		).
+			From(lifecycle.tableAs("workers")).
+			RunWith(lifecycle.conn).
+			QueryContext(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		capacityByName = make(map[string]WorkerCapacity)
+
+		err = scanWorkerRows(rows, func(rows *sql.Rows) error {
+			var (
+				name     string
+				capacity WorkerCapacity
+			)
+
+			err := rows.Scan(&name, &capacity.State, &capacity.ActiveContainers, &capacity.ActiveVolumes)
+			if err != nil {
+				return err
+			}
+
+			capacityByName[name] = capacity
+
+			return nil
+		})
+
+		return len(capacityByName), err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return capacityByName, nil
+}
+
+// FindWorkersWithHeadroom returns at most limit of the running workers with
+// room for at least minFree more containers, the ones with the most room first.
+func (lifecycle *workerLifecycle) FindWorkersWithHeadroom(ctx context.Context, minFree int, limit int) ([]WorkerHeadroom, error) {
+	total := sq.Expr("COALESCE(max_containers, ?)", UnboundedWorkerContainers)
+	free := sq.Expr("COALESCE(max_containers, ?) - COALESCE(active_containers, 0)", UnboundedWorkerContainers)
+
+	query := psql.Select("name").
+		Column(sq.Alias(free, "free")).
+		Column(sq.Alias(total, "total")).
+		From(lifecycle.tableAs("workers")).
+		Where(sq.Eq{"state": string(WorkerStateRunning)}).
+		Where(sq.Expr("COALESCE(max_containers, ?) - COALESCE(active_containers, 0) >= ?", UnboundedWorkerContainers, minFree)).
+		OrderBy("free DESC", "name")
+
+	if limit > 0 {
+		query = query.Limit(uint64(limit))
+	}
+
+	var headrooms []WorkerHeadroom
+	err := lifecycle.run(ctx, "find-workers-with-headroom", func(ctx context.Context) (int, error) {
+		rows, err := query.
+			RunWith(lifecycle.conn).
+			QueryContext(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		headrooms, err = scanRows(rows, func(rows *sql.Rows) (WorkerHeadroom, error) {
+			var headroom WorkerHeadroom
+
+			err := rows.Scan(&headroom.Name, &headroom.Free, &headroom.Total)
+
+			return headroom, err
+		})
+
+		return len(headrooms), err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if headrooms == nil {
+		headrooms = []WorkerHeadroom{}
+	}
+
+	return headrooms, nil
+}
+
+// GetWorkerHeartbeatAges returns how long until the heartbeat of every worker
+// expires, which is negative once it has expired.
+func (lifecycle *workerLifecycle) GetWorkerHeartbeatAges(ctx context.Context) (map[string]time.Duration, error) {
+	var durations map[string]time.Duration
+	err := lifecycle.run(ctx, "get-worker-heartbeat-ages", func(ctx context.Context) (int, error) {
+		rows, err := psql.Select("name", "EXTRACT(EPOCH FROM expires - NOW())").
+			From(lifecycle.tableAs("workers")).
+			Where(sq.NotEq{"expires": nil}).
+			RunWith(lifecycle.conn).
+			QueryContext(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		durations, err = scanDurationsByName(rows)
+		return len(durations), err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return durations, nil
+}
+
+// GetWorkerUptimes returns how long ago every worker registered, leaving out
+// deleted workers.
+func (lifecycle *workerLifecycle) GetWorkerUptimes(ctx context.Context) (map[string]time.Duration, error) {
+	var durations map[string]time.Duration
+	err := lifecycle.run(ctx, "get-worker-uptimes", func(ctx context.Context) (int, error) {
+		rows, err := psql.Select("name", "EXTRACT(EPOCH FROM NOW() - start_time)").
+			From(lifecycle.tableAs("workers")).
+			Where(sq.NotEq{"start_time": nil}).
+			Where(sq.NotEq{"state": string(WorkerStateDeleted)}).
+			RunWith(lifecycle.conn).
+			QueryContext(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		durations, err = scanDurationsByName(rows)
+		return len(durations), err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return durations, nil
+}
+
+// OldestExpiredWorkerAge returns how long ago the heartbeat of the most overdue
+// worker expired, or false if none has expired.
+func (lifecycle *workerLifecycle) OldestExpiredWorkerAge(ctx context.Context) (time.Duration, bool, error) {
+	var seconds sql.NullFloat64
+	err := lifecycle.run(ctx, "oldest-expired-worker-age", func(ctx context.Context) (int, error) {
+		return 1, psql.Select("EXTRACT(EPOCH FROM MAX(NOW() - expires))").
+			From(lifecycle.tableAs("workers")).
+			Where(sq.Expr("expires < NOW()")).
+			RunWith(lifecycle.conn).
+			QueryRowContext(ctx).
+			Scan(&seconds)
+	})
+	if err != nil {
+		return 0, false, err
+	}
+
+	if !seconds.Valid {
+		return 0, false, nil
+	}
+
+	return secondsToDuration(seconds.Float64), true, nil
+}
+
+// TotalActiveContainers returns the number of active containers across all of
+// the running workers.
+func (lifecycle *workerLifecycle) TotalActiveContainers(ctx context.Context) (int, error) {
+	var total int
+	err := lifecycle.run(ctx, "total-active-containers", func(ctx context.Context) (int, error) {
+		return 1, psql.Select("COALESCE(SUM(active_containers), 0)").
+			From(lifecycle.tableAs("workers")).
+			Where(sq.Eq{"state": string(WorkerStateRunning)}).
+			RunWith(lifecycle.conn).
+			QueryRowContext(ctx).
+			Scan(&total)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// EphemeralExpiryHistogram counts the ephemeral workers by the smallest bucket
+// their heartbeat expires within, with the expired workers under zero.
+func (lifecycle *workerLifecycle) EphemeralExpiryHistogram(ctx context.Context, buckets []time.Duration) (map[time.Duration]int, error) {
+	buckets = slices.Compact(slices.Sorted(slices.Values(buckets)))
+
+	// the workers are put in a bucket by its index, with the expired workers
+	// in front of the first one
+	bucketOf := "CASE WHEN expires <= NOW() THEN 0"
+	for i, bucket := range buckets {
+		bucketOf += fmt.Sprintf(" WHEN expires <= NOW() + '%d microsecond'::INTERVAL THEN %d", bucket.Microseconds(), i+1)
+	}
+	bucketOf += " END"
+
+	var histogram map[time.Duration]int
+	err := lifecycle.run(ctx, "ephemeral-expiry-histogram", func(ctx context.Context) (int, error) {
+		rows, err := psql.Select(bucketOf+" AS bucket", "COUNT(*)").
+			From(lifecycle.tableAs("workers")).
+			Where(sq.Eq{"ephemeral": true}).
+			Where(sq.NotEq{"expires": nil}).
+			GroupBy("bucket").
+			RunWith(lifecycle.conn).
+			QueryContext(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		histogram = map[time.Duration]int{0: 0}
+		for _, bucket := range buckets {
+			histogram[bucket] = 0
+		}
+
+		err = scanWorkerRows(rows, func(rows *sql.Rows) error {
+			var (
+				bucket sql.NullInt64
+				count  int
+			)
+
+			err := rows.Scan(&bucket, &count)
+			if err != nil {
+				return err
+			}
+
+			switch {
+			case !bucket.Valid:
+			case bucket.Int64 == 0:
+				histogram[0] += count
+			default:
+				histogram[buckets[bucket.Int64-1]] += count
+			}
+
+			return nil
+		})
+
+		return len(histogram), err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return histogram, nil
+}
+
+// GetWorkerProcessors returns the ATC which last changed every worker, for the
+// workers changed by a lifecycle with an ATCID.
+func (lifecycle *workerLifecycle) GetWorkerProcessors(ctx context.Context) (map[string]string, error) {
+	var processorByName map[string]string
+	err := lifecycle.run(ctx, "get-worker-processors", func(ctx context.Context) (int, error) {
+		rows, err := psql.Select("name", "processed_by").
+			From(lifecycle.tableAs("workers")).
+			Where(sq.NotEq{"processed_by": nil}).
+			RunWith(lifecycle.conn).
+			QueryContext(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		processorByName = make(map[string]string)
+
+		err = scanWorkerRows(rows, func(rows *sql.Rows) error {
+			var name, processedBy string
+
+			err := rows.Scan(&name, &processedBy)
+			if err != nil {
+				return err
+			}
+
+			processorByName[name] = processedBy
+
+			return nil
+		})
+
+		return len(processorByName), err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return processorByName, nil
+}
+
+// GetRunningWorkerAddresses returns the garden address of every running worker
+// which has one.
+func (lifecycle *workerLifecycle) GetRunningWorkerAddresses(ctx context.Context) (map[string]string, error) {
+	var addrByName map[string]string
+	err := lifecycle.run(ctx, "get-running-worker-addresses", func(ctx context.Context) (int, error) {
+		rows, err := psql.Select("name", "addr").
+			From(lifecycle.tableAs("workers")).
+			Where(sq.Eq{"state": string(WorkerStateRunning)}).
+			Where(sq.NotEq{"addr": nil}).
+			RunWith(lifecycle.conn).
+			QueryContext(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		addrByName = make(map[string]string)
+
+		err = scanWorkerRows(rows, func(rows *sql.Rows) error {
+			var name, addr string
+
+			err := rows.Scan(&name, &addr)
+			if err != nil {
+				return err
+			}
+
+			addrByName[name] = addr
+
+			return nil
+		})
+
+		return len(addrByName), err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return addrByName, nil
+}
+
+// GetPendingContainerDestroysByWorker returns how many containers are waiting
+// to be destroyed on each worker which has any.
+func (lifecycle *workerLifecycle) GetPendingContainerDestroysByWorker(ctx context.Context) (map[string]int, error) {
+	var pendingByName map[string]int
+	err := lifecycle.run(ctx, "get-pending-container-destroys-by-worker", func(ctx context.Context) (int, error) {
+		rows, err := psql.Select("workers.name", "COUNT(*)").
+			From("containers c").
+			Join(lifecycle.tableAs("workers") + " ON workers.name = c.worker_name").
+			Where(sq.Eq{"c.state": atc.ContainerStateDestroying}).
+			GroupBy("workers.name").
+			RunWith(lifecycle.conn).
+			QueryContext(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		pendingByName = make(map[string]int)
+
+		err = scanWorkerRows(rows, func(rows *sql.Rows) error {
+			var (
+				name    string
+				pending int
+			)
+
+			err := rows.Scan(&name, &pending)
+			if err != nil {
+				return err
+			}
+
+			pendingByName[name] = pending
+
+			return nil
+		})
+
+		return len(pendingByName), err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pendingByName, nil
+}
+
+// GetBuildsBlockingWorkerLanding returns the builds which keep the named worker
+// from being landed, ordered by ID.
+func (lifecycle *workerLifecycle) GetBuildsBlockingWorkerLanding(ctx context.Context, workerName string) ([]BlockingBuild, error) {
+	var blockingBuilds []BlockingBuild
+	err := lifecycle.run(ctx, "get-builds-blocking-worker-landing", func(ctx context.Context) (int, error) {
+		rows, err := lifecycle.activeBuildsOnWorkers("b.id", "j.name", "COALESCE(j.interruptible, false)").
+			Where(sq.Eq{"w.name": workerName}).
+			Where(sq.Or{
+				landingBlockingBuilds,
+				sq.Eq{"w.state": string(WorkerStateDraining)},
+			}).
+			OrderBy("b.id").
+			PlaceholderFormat(sq.Dollar).
+			RunWith(lifecycle.conn).
+			QueryContext(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		blockingBuilds, err = scanRows(rows, func(rows *sql.Rows) (BlockingBuild, error) {
+			var blockingBuild BlockingBuild
+
+			err := rows.Scan(&blockingBuild.BuildID, &blockingBuild.JobName, &blockingBuild.Interruptible)
+
+			return blockingBuild, err
+		})
+
+		return len(blockingBuilds), err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if blockingBuilds == nil {
+		blockingBuilds = []BlockingBuild{}
+	}
+
+	return blockingBuilds, nil
+}
+
+// EstimateLandingDrainTimes returns how long the oldest build keeping each
+// landing or draining worker from being landed has been running.
+func (lifecycle *workerLifecycle) EstimateLandingDrainTimes(ctx context.Context) (map[string]time.Duration, error) {
+	var drainTimes map[string]time.Duration
+	err := lifecycle.run(ctx, "estimate-landing-drain-times", func(ctx context.Context) (int, error) {
+		blocking, blockingArgs, err := lifecycle.activeBuildsOnWorkers("w.name AS worker_name", "EXTRACT(EPOCH FROM MAX(NOW() - b.start_time)) AS seconds").
+			Where(sq.Or{
+				landingBlockingBuilds,
+				sq.Eq{"w.state": string(WorkerStateDraining)},
+			}).
+			GroupBy("w.name").
+			ToSql()
+		if err != nil {
+			return 0, err
+		}
+
+		rows, err := psql.Select("workers.name", "COALESCE(blocking.seconds, 0)").
+			From(lifecycle.tableAs("workers")).
+			LeftJoin("("+blocking+") blocking ON blocking.worker_name = workers.name", blockingArgs...).
+			Where(sq.Eq{"workers.state": []string{string(WorkerStateLanding), string(WorkerStateDraining)}}).
+			RunWith(lifecycle.conn).
+			QueryContext(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		drainTimes, err = scanDurationsByName(rows)
+		return len(drainTimes), err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return drainTimes, nil
+}
+
+// FindExpiredWorkersWithActiveBuilds returns the workers the next stall pass
+// will stall even though they have containers for incomplete builds.
+func (lifecycle *workerLifecycle) FindExpiredWorkersWithActiveBuilds(ctx context.Context) ([]string, error) {
+	return lifecycle.readWorkerNames(ctx, "find-expired-workers-with-active-builds", psql.Select("workers.name").
+		From(lifecycle.tableAs("workers")).
+		Where(sq.Eq{"workers.state": string(WorkerStateRunning)}).
+		Where(sq.Expr("workers.expires < NOW()")).
+		Where(sq.Expr("EXISTS (?)", lifecycle.activeBuildsOnWorkers("1").Where("w.name = workers.name"))).
+		OrderBy("workers.name"))
+}
+
+// FindInconsistentWorkers returns the workers violating the invariants of the
+// worker table, once for every invariant they violate.
+func (lifecycle *workerLifecycle) FindInconsistentWorkers(ctx context.Context) ([]WorkerInconsistency, error) {
+	var inconsistencies []WorkerInconsistency
+	err := lifecycle.run(ctx, "find-inconsistent-workers", func(ctx context.Context) (int, error) {
+		inconsistencies = []WorkerInconsistency{}
+
+		for _, invariant := range workerInvariants {
+			violating, err := lifecycle.workersViolating(ctx, invariant.violation, invariant.where)
+			if err != nil {
+				return 0, err
+			}
+
+			inconsistencies = append(inconsistencies, violating...)
+		}
+
+		return len(inconsistencies), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return inconsistencies, nil
+}
+
+func (lifecycle *workerLifecycle) workersViolating(ctx context.Context, violation string, where sq.Sqlizer) ([]WorkerInconsistency, error) {
+	rows, err := psql.Select("name", "state").
+		From(lifecycle.tableAs("workers")).
+		Where(where).
+		OrderBy("name").
+		RunWith(lifecycle.conn).
+		QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanRows(rows, func(rows *sql.Rows) (WorkerInconsistency, error) {
+		inconsistency := WorkerInconsistency{
+			Violation: violation,
+		}
+
+		err := rows.Scan(&inconsistency.Name, &inconsistency.State)
+
+		return inconsistency, err
+	})
+}
+
+func (lifecycle *workerLifecycle) CountWorkersByState(ctx context.Context) (map[WorkerState]int, error) {
+	var countByState map[WorkerState]int
+	err := lifecycle.run(ctx, "count-workers-by-state", func(ctx context.Context) (int, error) {
+		rows, err := psql.Select("state", "COUNT(*)").
+			From(lifecycle.tableAs("workers")).
+			GroupBy("state").
+			RunWith(lifecycle.conn).
+			QueryContext(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		// every known state is reported, even when no worker is in it
+		countByState = make(map[WorkerState]int)
+		for _, state := range AllWorkerStates() {
+			countByState[state] = 0
+		}
+
+		var rowCount int
+		err = scanWorkerRows(rows, func(rows *sql.Rows) error {
+			var (
+				state WorkerState
+				count int
+			)
+
+			err := rows.Scan(&state, &count)
+			if err != nil {
+				return err
+			}
+
+			countByState[state] = count
+			rowCount++
+
+			return nil
+		})
+
+		return rowCount, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return countByState, nil
+}
+
+// CountWorkersByTeamAndState counts the workers in every state by team, with
+// the global workers under GlobalWorkersTeamName.
+func (lifecycle *workerLifecycle) CountWorkersByTeamAndState(ctx context.Context) (map[string]map[WorkerState]int, error) {
+	var countByTeamAndState map[string]map[WorkerState]int
+	err := lifecycle.run(ctx, "count-workers-by-team-and-state", func(ctx context.Context) (int, error) {
+		rows, err := psql.Select().
+			Column(sq.Expr("COALESCE(t.name, ?)", GlobalWorkersTeamName)).
+			Columns("workers.state", "COUNT(*)").
+			From(lifecycle.tableAs("workers")).
+			LeftJoin("teams t ON t.id = workers.team_id").
+			GroupBy("t.name", "workers.state").
+			RunWith(lifecycle.conn).
+			QueryContext(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		countByTeamAndState = make(map[string]map[WorkerState]int)
+
+		var rowCount int
+		err = scanWorkerRows(rows, func(rows *sql.Rows) error {
+			var (
+				teamName string
+				state    WorkerState
+				count    int
+			)
+
+			err := rows.Scan(&teamName, &state, &count)
+			if err != nil {
+				return err
+			}
+
+			if countByTeamAndState[teamName] == nil {
+				countByTeamAndState[teamName] = make(map[WorkerState]int)
+			}
+
+			countByTeamAndState[teamName][state] = count
+			rowCount++
+
+			return nil
+		})
+
+		return rowCount, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return countByTeamAndState, nil
+}
+
+// WorkerAgeStatsByState returns the shortest, average and longest time the
+// workers in every state have been in it.
+func (lifecycle *workerLifecycle) WorkerAgeStatsByState(ctx context.Context) (map[WorkerState]AgeStats, error) {
+	var statsByState map[WorkerState]AgeStats
+	err := lifecycle.run(ctx, "worker-age-stats-by-state", func(ctx context.Context) (int, error) {
+		rows, err := psql.Select(
+			"state",
+			"EXTRACT(EPOCH FROM MIN(NOW() - state_changed_at))",
+			"EXTRACT(EPOCH FROM AVG(NOW() - state_changed_at))",
+			"EXTRACT(EPOCH FROM MAX(NOW() - state_changed_at))",
+		).
+			From(lifecycle.tableAs("workers")).
+			GroupBy("state").
+			RunWith(lifecycle.conn).
+			QueryContext(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		statsByState = make(map[WorkerState]AgeStats)
+
+		err = scanWorkerRows(rows, func(rows *sql.Rows) error {
+			var (
+				state         WorkerState
+				min, avg, max float64
+			)
+
+			err := rows.Scan(&state, &min, &avg, &max)
+			if err != nil {
+				return err
+			}
+
+			statsByState[state] = AgeStats{
+				Min: secondsToDuration(min),
+				Avg: secondsToDuration(avg),
+				Max: secondsToDuration(max),
+			}
+
+			return nil
+		})
+
+		return len(statsByState), err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return statsByState, nil
+}
+
+// workerState returns the state of the named worker, or ErrWorkerNotPresent if
+// there is no such worker.
+func (lifecycle *workerLifecycle) workerState(ctx context.Context, name string) (WorkerState, error) {
+	var state WorkerState
+	err := lifecycle.run(ctx, "get-worker-state", func(ctx context.Context) (int, error) {
+		return 1, psql.Select("state").
+			From(lifecycle.tableAs("workers")).
+			Where(sq.Eq{"name": name}).
+			RunWith(lifecycle.conn).
+			QueryRowContext(ctx).
+			Scan(&state)
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", ErrWorkerNotPresent
+		}
+		return "", err
+	}
+
+	return state, nil
+}
+
+func (lifecycle *workerLifecycle) workerStatesQuery() sq.SelectBuilder {
+	return psql.Select(`
+		name,
+		state
+	`).
+		From(lifecycle.tableAs("workers"))
+}
+
+func (lifecycle *workerLifecycle) getWorkerStateByName(ctx context.Context, query sq.SelectBuilder) (map[string]WorkerState, error) {
+	var workerStateByName map[string]WorkerState
+	err := lifecycle.run(ctx, "get-worker-states", func(ctx context.Context) (int, error) {
+		rows, err := query.
+			RunWith(lifecycle.conn).
+			QueryContext(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		workerStateByName = make(map[string]WorkerState)
+
+		err = scanWorkerRows(rows, func(rows *sql.Rows) error {
+			var (
+				name  string
+				state WorkerState
+			)
+
+			err := rows.Scan(&name, &state)
+			if err != nil {
+				return err
+			}
+
+			workerStateByName[name] = state
+
+			return nil
+		})
+
+		return len(workerStateByName), err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return workerStateByName, nil
+}
+
+// readWorkerNames runs the query selecting the names of workers as the named
+// operation.
+func (lifecycle *workerLifecycle) readWorkerNames(ctx context.Context, operation string, query sq.Sqlizer) ([]string, error) {
+	var workerNames []string
+	err := lifecycle.run(ctx, operation, func(ctx context.Context) (int, error) {
+		rows, err := sq.QueryContextWith(ctx, lifecycle.conn, query)
+		if err != nil {
+			return 0, err
+		}
+
+		workerNames, err = workersAffected(rows)
+		return len(workerNames), err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return workerNames, nil
+}
@@ -1,22 +1,36 @@
 package db_test
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"time"
 
+	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/db/lock"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
 
 var _ = Describe("Worker Lifecycle", func() {
 	var (
+		ctx       context.Context
 		atcWorker atc.Worker
 		worker    db.Worker
 	)
 
 	BeforeEach(func() {
+		ctx = context.Background()
+
 		atcWorker = atc.Worker{
 			GardenAddr:       "some-garden-addr",
 			BaggageclaimURL:  "some-bc-url",
@@ -52,7 +66,7 @@ var _ = Describe("Worker Lifecycle", func() {
 			})
 
 			It("leaves the worker alone", func() {
-				deletedWorkers, err := workerLifecycle.DeleteUnresponsiveEphemeralWorkers()
+				deletedWorkers, err := workerLifecycle.DeleteUnresponsiveEphemeralWorkers(ctx)
 				Expect(err).ToNot(HaveOccurred())
 				Expect(deletedWorkers).To(BeEmpty())
 			})
@@ -65,12 +79,233 @@ var _ = Describe("Worker Lifecycle", func() {
 			})
 
 			It("deletes the ephemeral worker", func() {
-				deletedWorkers, err := workerLifecycle.DeleteUnresponsiveEphemeralWorkers()
+				deletedWorkers, err := workerLifecycle.DeleteUnresponsiveEphemeralWorkers(ctx)
 				Expect(err).ToNot(HaveOccurred())
 				Expect(len(deletedWorkers)).To(Equal(1))
 				Expect(deletedWorkers[0]).To(Equal("some-name"))
 			})
 		})
+
+		Context("with an ephemeral worker predicate", func() {
+			var spotSparingLifecycle db.WorkerLifecycle
+
+			BeforeEach(func() {
+				spotSparingLifecycle = db.NewWorkerLifecycleWithOptions(dbConn, db.WorkerLifecycleOptions{
+					EphemeralWorkerPredicate: func() sq.Sqlizer {
+						return sq.Expr("NOT COALESCE(workers.tags::jsonb @> ?::jsonb, false)", `["spot"]`)
+					},
+				})
+
+				_, err := workerFactory.SaveWorker(atcWorker, -1*time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+
+				spotWorker := atcWorker
+				spotWorker.Name = "spot-worker"
+				spotWorker.Tags = atc.Tags{"spot"}
+				_, err = workerFactory.SaveWorker(spotWorker, -1*time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("only deletes the workers matching the predicate", func() {
+				deletedWorkers, err := spotSparingLifecycle.DeleteUnresponsiveEphemeralWorkers(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deletedWorkers).To(ConsistOf("some-name"))
+
+				_, found, err := workerFactory.GetWorker("spot-worker")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("DeleteUnresponsiveEphemeralWorkersDetailed", func() {
+		Context("when the worker has heartbeated recently", func() {
+			BeforeEach(func() {
+				_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("leaves the worker alone", func() {
+				deletedWorkers, err := workerLifecycle.DeleteUnresponsiveEphemeralWorkersDetailed(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deletedWorkers).To(BeEmpty())
+			})
+		})
+
+		Context("when a team worker has not heartbeated recently", func() {
+			BeforeEach(func() {
+				_, err := defaultTeam.SaveWorker(atcWorker, -1*time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns the details of the deleted worker", func() {
+				deletedWorkers, err := workerLifecycle.DeleteUnresponsiveEphemeralWorkersDetailed(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deletedWorkers).To(HaveLen(1))
+
+				deletedWorker := deletedWorkers[0]
+				Expect(deletedWorker.Name).To(Equal("some-name"))
+				Expect(deletedWorker.TeamName).To(Equal(defaultTeam.Name()))
+				Expect(deletedWorker.Addr).To(Equal(&atcWorker.GardenAddr))
+				Expect(deletedWorker.State).To(Equal(db.WorkerStateRunning))
+				Expect(deletedWorker.Reason).To(Equal(db.WorkerTransitionReasonEphemeralExpired))
+				Expect(deletedWorker.Expires).To(BeTemporally("<", time.Now()))
+			})
+		})
+
+		Context("when a global worker has not heartbeated recently", func() {
+			BeforeEach(func() {
+				_, err := workerFactory.SaveWorker(atcWorker, -1*time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns an empty team name", func() {
+				deletedWorkers, err := workerLifecycle.DeleteUnresponsiveEphemeralWorkersDetailed(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deletedWorkers).To(HaveLen(1))
+				Expect(deletedWorkers[0].TeamName).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("DeleteUnresponsiveEphemeralWorkersWithSkew", func() {
+		BeforeEach(func() {
+			_, err := workerFactory.SaveWorker(atcWorker, -1*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("when the worker expired within the skew", func() {
+			It("leaves the worker alone", func() {
+				deletedWorkers, err := workerLifecycle.DeleteUnresponsiveEphemeralWorkersWithSkew(ctx, 5*time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deletedWorkers).To(BeEmpty())
+			})
+		})
+
+		Context("when the worker expired before the skew", func() {
+			It("deletes the worker", func() {
+				deletedWorkers, err := workerLifecycle.DeleteUnresponsiveEphemeralWorkersWithSkew(ctx, 10*time.Second)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deletedWorkers).To(ConsistOf("some-name"))
+			})
+		})
+	})
+
+	Describe("DeleteUnresponsiveEphemeralWorkersExcept", func() {
+		BeforeEach(func() {
+			_, err := workerFactory.SaveWorker(atcWorker, -1*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			protectedWorker := atcWorker
+			protectedWorker.Name = "protected-worker"
+			_, err = workerFactory.SaveWorker(protectedWorker, -1*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("deletes the unresponsive ephemeral workers which are not protected", func() {
+			deletedWorkers, err := workerLifecycle.DeleteUnresponsiveEphemeralWorkersExcept(ctx, []string{"protected-worker"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deletedWorkers).To(ConsistOf("some-name"))
+
+			_, found, err := workerFactory.GetWorker("protected-worker")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		It("deletes every unresponsive ephemeral worker when none are protected", func() {
+			deletedWorkers, err := workerLifecycle.DeleteUnresponsiveEphemeralWorkersExcept(ctx, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deletedWorkers).To(ConsistOf("some-name", "protected-worker"))
+		})
+	})
+
+	Describe("soft-deleting ephemeral workers", func() {
+		var softDeletingLifecycle db.WorkerLifecycle
+
+		BeforeEach(func() {
+			softDeletingLifecycle = db.NewWorkerLifecycleWithOptions(dbConn, db.WorkerLifecycleOptions{
+				SoftDeleteEphemeralWorkers: true,
+			})
+
+			_, err := defaultTeam.SaveWorker(atcWorker, -1*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("leaves a tombstone reporting the worker as it was", func() {
+			deletedWorkers, err := softDeletingLifecycle.DeleteUnresponsiveEphemeralWorkersDetailed(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deletedWorkers).To(HaveLen(1))
+			Expect(deletedWorkers[0].Name).To(Equal("some-name"))
+			Expect(deletedWorkers[0].TeamName).To(Equal(defaultTeam.Name()))
+			Expect(deletedWorkers[0].Addr).To(Equal(&atcWorker.GardenAddr))
+			Expect(deletedWorkers[0].State).To(Equal(db.WorkerStateRunning))
+
+			stateByName, err := softDeletingLifecycle.GetWorkerStateByName(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stateByName).To(HaveKeyWithValue("some-name", db.WorkerStateDeleted))
+
+			deletedWorkers, err = softDeletingLifecycle.DeleteUnresponsiveEphemeralWorkersDetailed(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deletedWorkers).To(BeEmpty())
+		})
+
+		Context("when the tombstone is purged", func() {
+			BeforeEach(func() {
+				_, err := softDeletingLifecycle.DeleteUnresponsiveEphemeralWorkers(ctx)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("keeps tombstones which are not old enough", func() {
+				purgedWorkers, err := softDeletingLifecycle.PurgeDeletedWorkers(ctx, time.Hour)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(purgedWorkers).To(BeEmpty())
+			})
+
+			It("deletes tombstones which are old enough", func() {
+				_, err := dbConn.Exec(`UPDATE workers SET deleted_at = NOW() - '2 hours'::INTERVAL WHERE name = $1`, atcWorker.Name)
+				Expect(err).ToNot(HaveOccurred())
+
+				purgedWorkers, err := softDeletingLifecycle.PurgeDeletedWorkers(ctx, time.Hour)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(purgedWorkers).To(ConsistOf("some-name"))
+
+				_, found, err := workerFactory.GetWorker(atcWorker.Name)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("GetDeletableEphemeralWorkers", func() {
+		Context("when the worker has heartbeated recently", func() {
+			BeforeEach(func() {
+				_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("does not return the worker", func() {
+				workerNames, err := workerLifecycle.GetDeletableEphemeralWorkers(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(workerNames).To(BeEmpty())
+			})
+		})
+
+		Context("when the worker has not heartbeated recently", func() {
+			BeforeEach(func() {
+				_, err := workerFactory.SaveWorker(atcWorker, -1*time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns the worker without deleting it", func() {
+				workerNames, err := workerLifecycle.GetDeletableEphemeralWorkers(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(workerNames).To(ConsistOf("some-name"))
+
+				_, found, err := workerFactory.GetWorker(atcWorker.Name)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
 	})
 
 	Describe("StallUnresponsiveWorkers", func() {
@@ -81,7 +316,7 @@ var _ = Describe("Worker Lifecycle", func() {
 			})
 
 			It("leaves the worker alone", func() {
-				stalledWorkers, err := workerLifecycle.StallUnresponsiveWorkers()
+				stalledWorkers, err := workerLifecycle.StallUnresponsiveWorkers(ctx)
 				Expect(err).ToNot(HaveOccurred())
 				Expect(stalledWorkers).To(BeEmpty())
 			})
@@ -94,7 +329,7 @@ var _ = Describe("Worker Lifecycle", func() {
 			})
 
 			It("marks the worker as `stalled`", func() {
-				stalledWorkers, err := workerLifecycle.StallUnresponsiveWorkers()
+				stalledWorkers, err := workerLifecycle.StallUnresponsiveWorkers(ctx)
 				Expect(err).ToNot(HaveOccurred())
 				Expect(len(stalledWorkers)).To(Equal(1))
 				Expect(stalledWorkers[0]).To(Equal("some-name"))
@@ -102,6 +337,190 @@ var _ = Describe("Worker Lifecycle", func() {
 		})
 	})
 
+	Describe("StallUnresponsiveWorkers with a stall-exempt label", func() {
+		var exemptingLifecycle db.WorkerLifecycle
+
+		BeforeEach(func() {
+			exemptingLifecycle = db.NewWorkerLifecycleWithOptions(dbConn, db.WorkerLifecycleOptions{
+				StallExemptLabel: "managed-externally",
+			})
+
+			_, err := workerFactory.SaveWorker(atcWorker, -1*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("when the worker has the label", func() {
+			BeforeEach(func() {
+				_, err := dbConn.Exec(`UPDATE workers SET labels = '{"managed-externally": "true"}' WHERE name = $1`, atcWorker.Name)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("leaves the worker alone", func() {
+				stalledWorkers, err := exemptingLifecycle.StallUnresponsiveWorkers(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(stalledWorkers).To(BeEmpty())
+			})
+
+			It("still stalls the worker when no label is configured", func() {
+				stalledWorkers, err := workerLifecycle.StallUnresponsiveWorkers(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(stalledWorkers).To(ConsistOf(atcWorker.Name))
+			})
+		})
+
+		Context("when the worker has other labels", func() {
+			BeforeEach(func() {
+				_, err := dbConn.Exec(`UPDATE workers SET labels = '{"team": "ops"}' WHERE name = $1`, atcWorker.Name)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("stalls the worker", func() {
+				stalledWorkers, err := exemptingLifecycle.StallUnresponsiveWorkers(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(stalledWorkers).To(ConsistOf(atcWorker.Name))
+			})
+		})
+
+		Context("when the worker has no labels", func() {
+			It("stalls the worker", func() {
+				stalledWorkers, err := exemptingLifecycle.StallUnresponsiveWorkers(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(stalledWorkers).To(ConsistOf(atcWorker.Name))
+			})
+		})
+	})
+
+	Describe("StallUnresponsiveWorkersWithGrace", func() {
+		BeforeEach(func() {
+			_, err := workerFactory.SaveWorker(atcWorker, -1*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("when the worker expired within the grace period", func() {
+			It("leaves the worker alone", func() {
+				stalledWorkers, err := workerLifecycle.StallUnresponsiveWorkersWithGrace(ctx, 5*time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(stalledWorkers).To(BeEmpty())
+			})
+		})
+
+		Context("when the worker expired before the grace period", func() {
+			It("marks the worker as `stalled`", func() {
+				stalledWorkers, err := workerLifecycle.StallUnresponsiveWorkersWithGrace(ctx, 30*time.Second)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(stalledWorkers).To(ConsistOf("some-name"))
+			})
+		})
+	})
+
+	Describe("StallUnresponsiveWorkersWithPreviousState", func() {
+		BeforeEach(func() {
+			_, err := workerFactory.SaveWorker(atcWorker, -1*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns the state the workers were stalled from", func() {
+			stalledWorkers, err := workerLifecycle.StallUnresponsiveWorkersWithPreviousState(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stalledWorkers).To(Equal([]db.WorkerTransition{{
+				Name:   atcWorker.Name,
+				From:   db.WorkerStateRunning,
+				To:     db.WorkerStateStalled,
+				Reason: db.WorkerTransitionReasonHeartbeatExpired,
+			}}))
+		})
+	})
+
+	Describe("StallUnresponsiveWorkersDetailed", func() {
+		BeforeEach(func() {
+			_, err := workerFactory.SaveWorker(atcWorker, -1*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns the reason the workers were stalled for", func() {
+			stalledWorkers, err := workerLifecycle.StallUnresponsiveWorkersDetailed(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stalledWorkers).To(Equal([]db.WorkerAffected{{
+				Name:   atcWorker.Name,
+				Reason: "heartbeat-expired",
+			}}))
+		})
+	})
+
+	Describe("StallUnresponsiveWorkersTx", func() {
+		var tx db.Tx
+
+		BeforeEach(func() {
+			_, err := workerFactory.SaveWorker(atcWorker, -1*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			tx, err = dbConn.Begin()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			db.Rollback(tx)
+		})
+
+		It("stalls the workers once the transaction commits", func() {
+			stalledWorkers, err := workerLifecycle.StallUnresponsiveWorkersTx(ctx, tx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stalledWorkers).To(ConsistOf("some-name"))
+
+			err = tx.Commit()
+			Expect(err).ToNot(HaveOccurred())
+
+			worker, found, err := workerFactory.GetWorker(atcWorker.Name)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(worker.State()).To(Equal(db.WorkerStateStalled))
+		})
+
+		It("leaves the workers alone when the transaction rolls back", func() {
+			_, err := workerLifecycle.StallUnresponsiveWorkersTx(ctx, tx)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = tx.Rollback()
+			Expect(err).ToNot(HaveOccurred())
+
+			worker, found, err := workerFactory.GetWorker(atcWorker.Name)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(worker.State()).To(Equal(db.WorkerStateRunning))
+		})
+	})
+
+	Describe("StallUnresponsiveWorkersOfKind", func() {
+		BeforeEach(func() {
+			_, err := workerFactory.SaveWorker(atcWorker, -1*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			persistentWorker := atcWorker
+			persistentWorker.Name = "persistent-worker"
+			persistentWorker.Ephemeral = false
+			_, err = workerFactory.SaveWorker(persistentWorker, -1*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("stalls only the persistent workers", func() {
+			stalledWorkers, err := workerLifecycle.StallUnresponsiveWorkersOfKind(ctx, db.WorkerKindPersistent)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stalledWorkers).To(ConsistOf("persistent-worker"))
+		})
+
+		It("stalls only the ephemeral workers", func() {
+			stalledWorkers, err := workerLifecycle.StallUnresponsiveWorkersOfKind(ctx, db.WorkerKindEphemeral)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stalledWorkers).To(ConsistOf("some-name"))
+		})
+
+		It("stalls workers of any kind", func() {
+			stalledWorkers, err := workerLifecycle.StallUnresponsiveWorkersOfKind(ctx, db.WorkerKindAny)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stalledWorkers).To(ConsistOf("some-name", "persistent-worker"))
+		})
+	})
+
 	Describe("DeleteStalledWorkers", func() {
 		Context("when there is a stalled worker", func() {
 			var stalledTimeout time.Duration
@@ -114,14 +533,14 @@ var _ = Describe("Worker Lifecycle", func() {
 					Expect(err).ToNot(HaveOccurred())
 				})
 
-				stalledWorkers, err := workerLifecycle.StallUnresponsiveWorkers()
+				stalledWorkers, err := workerLifecycle.StallUnresponsiveWorkers(ctx)
 				Expect(err).ToNot(HaveOccurred())
 				Expect(stalledWorkers).To(ConsistOf("some-name"))
 			})
 
 			Context("when the worker has been stalled for less than the timeout", func() {
 				It("leaves the worker alone", func() {
-					deletedWorkers, err := workerLifecycle.DeleteStalledWorkers(stalledTimeout)
+					deletedWorkers, err := workerLifecycle.DeleteStalledWorkers(ctx, stalledTimeout)
 					Expect(err).ToNot(HaveOccurred())
 					Expect(deletedWorkers).To(BeEmpty())
 				})
@@ -132,7 +551,7 @@ var _ = Describe("Worker Lifecycle", func() {
 					By("waiting for the timeout to elapse", func() {
 						time.Sleep(stalledTimeout + time.Second)
 					})
-					deletedWorkers, err := workerLifecycle.DeleteStalledWorkers(stalledTimeout)
+					deletedWorkers, err := workerLifecycle.DeleteStalledWorkers(ctx, stalledTimeout)
 					Expect(err).ToNot(HaveOccurred())
 					Expect(deletedWorkers).To(ConsistOf("some-name"))
 				})
@@ -143,13 +562,30 @@ var _ = Describe("Worker Lifecycle", func() {
 					_, err := workerFactory.HeartbeatWorker(atcWorker, 5*time.Minute)
 					Expect(err).ToNot(HaveOccurred())
 
-					deletedWorkers, err := workerLifecycle.DeleteStalledWorkers(stalledTimeout)
+					deletedWorkers, err := workerLifecycle.DeleteStalledWorkers(ctx, stalledTimeout)
 					Expect(err).ToNot(HaveOccurred())
 					Expect(deletedWorkers).To(BeEmpty())
 				})
 			})
 		})
 
+		Context("when the worker was saved as stalled", func() {
+			BeforeEach(func() {
+				atcWorker.State = string(db.WorkerStateStalled)
+				_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = dbConn.Exec(`UPDATE workers SET state_changed_at = NOW() - '1 hour'::INTERVAL WHERE name = $1`, atcWorker.Name)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("deletes the worker once it has been stalled for longer than the timeout", func() {
+				deletedWorkers, err := workerLifecycle.DeleteStalledWorkers(ctx, time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deletedWorkers).To(ConsistOf("some-name"))
+			})
+		})
+
 		Context("when the worker is running", func() {
 			BeforeEach(func() {
 				_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
@@ -157,28 +593,76 @@ var _ = Describe("Worker Lifecycle", func() {
 			})
 
 			It("leaves the worker alone", func() {
-				deletedWorkers, err := workerLifecycle.DeleteStalledWorkers(-1 * time.Hour)
+				deletedWorkers, err := workerLifecycle.DeleteStalledWorkers(ctx, -1*time.Hour)
 				Expect(err).ToNot(HaveOccurred())
 				Expect(deletedWorkers).To(BeEmpty())
 			})
 		})
 	})
 
-	Describe("DeleteFinishedRetiringWorkers", func() {
-		var (
-			dbWorker db.Worker
-			dbBuild  db.Build
-		)
+	Describe("DeleteExpiredStalledWorkers", func() {
+		BeforeEach(func() {
+			atcWorker.Ephemeral = false
+			_, err := workerFactory.SaveWorker(atcWorker, -1*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
 
-		JustBeforeEach(func() {
-			var err error
-			dbWorker, err = workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			otherWorker := atcWorker
+			otherWorker.Name = "other-stalled-worker"
+			_, err = workerFactory.SaveWorker(otherWorker, -1*time.Minute)
 			Expect(err).ToNot(HaveOccurred())
-		})
 
-		Context("when worker is not retiring", func() {
-			JustBeforeEach(func() {
-				var err error
+			stalledWorkers, err := workerLifecycle.StallUnresponsiveWorkers(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stalledWorkers).To(ConsistOf(atcWorker.Name, otherWorker.Name))
+
+			_, err = dbConn.Exec(`UPDATE workers SET stalled_since = NOW() - '1 hour'::INTERVAL WHERE state = 'stalled'`)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = dbConn.Exec(`UPDATE workers SET stall_ttl = '30 minutes' WHERE name = $1`, atcWorker.Name)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("deletes the workers stalled for longer than their stall_ttl", func() {
+			deletedWorkers, err := workerLifecycle.DeleteExpiredStalledWorkers(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deletedWorkers).To(ConsistOf(atcWorker.Name))
+		})
+
+		It("leaves the workers stalled for less than their stall_ttl alone", func() {
+			_, err := dbConn.Exec(`UPDATE workers SET stall_ttl = '2 hours' WHERE name = $1`, atcWorker.Name)
+			Expect(err).ToNot(HaveOccurred())
+
+			deletedWorkers, err := workerLifecycle.DeleteExpiredStalledWorkers(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deletedWorkers).To(BeEmpty())
+		})
+
+		It("falls back to the StallTTL option for the workers without a stall_ttl", func() {
+			ttlLifecycle := db.NewWorkerLifecycleWithOptions(dbConn, db.WorkerLifecycleOptions{
+				StallTTL: 30 * time.Minute,
+			})
+
+			deletedWorkers, err := ttlLifecycle.DeleteExpiredStalledWorkers(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deletedWorkers).To(ConsistOf(atcWorker.Name, "other-stalled-worker"))
+		})
+	})
+
+	Describe("DeleteFinishedRetiringWorkers", func() {
+		var (
+			dbWorker db.Worker
+			dbBuild  db.Build
+		)
+
+		JustBeforeEach(func() {
+			var err error
+			dbWorker, err = workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("when worker is not retiring", func() {
+			JustBeforeEach(func() {
+				var err error
 				atcWorker.State = string(db.WorkerStateRunning)
 				dbWorker, err = workerFactory.SaveWorker(atcWorker, 5*time.Minute)
 				Expect(err).ToNot(HaveOccurred())
@@ -189,7 +673,7 @@ var _ = Describe("Worker Lifecycle", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				deletedWorkers, err := workerLifecycle.DeleteFinishedRetiringWorkers()
+				deletedWorkers, err := workerLifecycle.DeleteFinishedRetiringWorkers(ctx)
 				Expect(err).ToNot(HaveOccurred())
 				Expect(len(deletedWorkers)).To(Equal(0))
 
@@ -210,7 +694,7 @@ var _ = Describe("Worker Lifecycle", func() {
 					Expect(err).ToNot(HaveOccurred())
 					Expect(found).To(BeTrue())
 
-					deletedWorkers, err := workerLifecycle.DeleteFinishedRetiringWorkers()
+					deletedWorkers, err := workerLifecycle.DeleteFinishedRetiringWorkers(ctx)
 					Expect(err).ToNot(HaveOccurred())
 					Expect(len(deletedWorkers)).To(Equal(1))
 					Expect(deletedWorkers[0]).To(Equal(atcWorker.Name))
@@ -242,7 +726,7 @@ var _ = Describe("Worker Lifecycle", func() {
 					Expect(err).ToNot(HaveOccurred())
 					Expect(found).To(BeTrue())
 
-					_, err = workerLifecycle.DeleteFinishedRetiringWorkers()
+					_, err = workerLifecycle.DeleteFinishedRetiringWorkers(ctx)
 					Expect(err).ToNot(HaveOccurred())
 
 					_, found, err = workerFactory.GetWorker(atcWorker.Name)
@@ -275,7 +759,7 @@ var _ = Describe("Worker Lifecycle", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				_, err = workerLifecycle.DeleteFinishedRetiringWorkers()
+				_, err = workerLifecycle.DeleteFinishedRetiringWorkers(ctx)
 				Expect(err).ToNot(HaveOccurred())
 
 				_, found, err = workerFactory.GetWorker(atcWorker.Name)
@@ -367,6 +851,74 @@ var _ = Describe("Worker Lifecycle", func() {
 		})
 	})
 
+	Describe("DeleteFinishedRetiringWorkersWithPreviousState", func() {
+		BeforeEach(func() {
+			atcWorker.State = string(db.WorkerStateRetiring)
+			_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns the state the workers were deleted from", func() {
+			retiredWorkers, err := workerLifecycle.DeleteFinishedRetiringWorkersWithPreviousState(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(retiredWorkers).To(Equal([]db.WorkerTransition{{
+				Name:   atcWorker.Name,
+				From:   db.WorkerStateRetiring,
+				Reason: db.WorkerTransitionReasonRetireComplete,
+			}}))
+		})
+	})
+
+	Describe("DeleteFinishedRetiringWorkersDetailed", func() {
+		BeforeEach(func() {
+			atcWorker.State = string(db.WorkerStateRetiring)
+			_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns the reason the workers were deleted for", func() {
+			retiredWorkers, err := workerLifecycle.DeleteFinishedRetiringWorkersDetailed(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(retiredWorkers).To(Equal([]db.WorkerAffected{{
+				Name:   atcWorker.Name,
+				Reason: "retire-complete",
+			}}))
+		})
+	})
+
+	Describe("DeleteFinishedRetiringWorkersBatch", func() {
+		BeforeEach(func() {
+			atcWorker.State = string(db.WorkerStateRetiring)
+			for _, name := range []string{"worker-1", "worker-2", "worker-3"} {
+				atcWorker.Name = name
+				_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+			}
+		})
+
+		It("deletes at most limit workers at a time until none remain", func() {
+			firstBatch, err := workerLifecycle.DeleteFinishedRetiringWorkersBatch(ctx, 2)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(firstBatch).To(HaveLen(2))
+
+			secondBatch, err := workerLifecycle.DeleteFinishedRetiringWorkersBatch(ctx, 2)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secondBatch).To(HaveLen(1))
+
+			Expect(append(firstBatch, secondBatch...)).To(ConsistOf("worker-1", "worker-2", "worker-3"))
+
+			lastBatch, err := workerLifecycle.DeleteFinishedRetiringWorkersBatch(ctx, 2)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(lastBatch).To(BeEmpty())
+		})
+
+		It("deletes every finished retiring worker without a limit", func() {
+			retiredWorkers, err := workerLifecycle.DeleteFinishedRetiringWorkersBatch(ctx, 0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(retiredWorkers).To(ConsistOf("worker-1", "worker-2", "worker-3"))
+		})
+	})
+
 	Describe("LandFinishedLandingWorkers", func() {
 		var (
 			dbWorker db.Worker
@@ -392,7 +944,7 @@ var _ = Describe("Worker Lifecycle", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				landedWorkers, err := workerLifecycle.LandFinishedLandingWorkers()
+				landedWorkers, err := workerLifecycle.LandFinishedLandingWorkers(ctx)
 				Expect(err).ToNot(HaveOccurred())
 				Expect(len(landedWorkers)).To(Equal(0))
 
@@ -414,7 +966,7 @@ var _ = Describe("Worker Lifecycle", func() {
 					Expect(err).ToNot(HaveOccurred())
 					Expect(found).To(BeTrue())
 
-					landedWorkers, err := workerLifecycle.LandFinishedLandingWorkers()
+					landedWorkers, err := workerLifecycle.LandFinishedLandingWorkers(ctx)
 					Expect(err).ToNot(HaveOccurred())
 					Expect(len(landedWorkers)).To(Equal(1))
 					Expect(landedWorkers[0]).To(Equal(atcWorker.Name))
@@ -449,7 +1001,7 @@ var _ = Describe("Worker Lifecycle", func() {
 
 					err = worker.Land()
 					Expect(err).ToNot(HaveOccurred())
-					landedWorkers, err := workerLifecycle.LandFinishedLandingWorkers()
+					landedWorkers, err := workerLifecycle.LandFinishedLandingWorkers(ctx)
 					Expect(err).ToNot(HaveOccurred())
 					Expect(len(landedWorkers)).To(Equal(1))
 					Expect(landedWorkers[0]).To(Equal(atcWorker.Name))
@@ -487,7 +1039,7 @@ var _ = Describe("Worker Lifecycle", func() {
 					Expect(err).ToNot(HaveOccurred())
 					Expect(found).To(BeTrue())
 
-					_, err = workerLifecycle.LandFinishedLandingWorkers()
+					_, err = workerLifecycle.LandFinishedLandingWorkers(ctx)
 					Expect(err).ToNot(HaveOccurred())
 
 					foundWorker, found, err := workerFactory.GetWorker(atcWorker.Name)
@@ -521,7 +1073,7 @@ var _ = Describe("Worker Lifecycle", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				_, err = workerLifecycle.LandFinishedLandingWorkers()
+				_, err = workerLifecycle.LandFinishedLandingWorkers(ctx)
 				Expect(err).ToNot(HaveOccurred())
 
 				foundWorker, found, err := workerFactory.GetWorker(atcWorker.Name)
@@ -614,24 +1166,3986 @@ var _ = Describe("Worker Lifecycle", func() {
 		})
 	})
 
-	Describe("GetWorkersState", func() {
+	Describe("FindExpiredWorkersWithActiveBuilds", func() {
+		var (
+			dbWorker db.Worker
+			build    db.Build
+		)
 
-		JustBeforeEach(func() {
-			atcWorker.State = string(db.WorkerStateStalled)
+		BeforeEach(func() {
+			var err error
+			atcWorker.Ephemeral = false
+			dbWorker, err = workerFactory.SaveWorker(atcWorker, -1*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			idleWorker := atcWorker
+			idleWorker.Name = "idle-worker"
+			_, err = workerFactory.SaveWorker(idleWorker, -1*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			build, err = defaultTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = dbWorker.CreateContainer(db.NewBuildStepContainerOwner(build.ID(), atc.PlanID("4"), defaultTeam.ID()), db.ContainerMetadata{})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns the expired workers with containers for incomplete builds", func() {
+			workerNames, err := workerLifecycle.FindExpiredWorkersWithActiveBuilds(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(workerNames).To(Equal([]string{atcWorker.Name}))
+		})
+
+		It("leaves out the workers once their builds have finished", func() {
+			err := build.Finish(db.BuildStatusSucceeded)
+			Expect(err).ToNot(HaveOccurred())
+
+			workerNames, err := workerLifecycle.FindExpiredWorkersWithActiveBuilds(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(workerNames).To(BeEmpty())
+		})
+
+		It("leaves out the workers which have not expired", func() {
+			_, err := workerFactory.HeartbeatWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			workerNames, err := workerLifecycle.FindExpiredWorkersWithActiveBuilds(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(workerNames).To(BeEmpty())
+		})
+	})
+
+	Describe("EstimateLandingDrainTimes", func() {
+		var dbWorker db.Worker
+
+		BeforeEach(func() {
+			var err error
+			atcWorker.State = string(db.WorkerStateLanding)
+			dbWorker, err = workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			idleWorker := atcWorker
+			idleWorker.Name = "idle-worker"
+			_, err = workerFactory.SaveWorker(idleWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			for _, startedAgo := range []string{"1 hour", "2 hours"} {
+				build, err := defaultTeam.CreateOneOffBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = dbWorker.CreateContainer(db.NewBuildStepContainerOwner(build.ID(), atc.PlanID("4"), defaultTeam.ID()), db.ContainerMetadata{})
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = dbConn.Exec(`UPDATE builds SET start_time = NOW() - $1::INTERVAL WHERE id = $2`, startedAgo, build.ID())
+				Expect(err).ToNot(HaveOccurred())
+			}
+		})
+
+		It("returns how long the oldest blocking build of each landing worker has been running", func() {
+			drainTimes, err := workerLifecycle.EstimateLandingDrainTimes(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(drainTimes).To(HaveKeyWithValue(atcWorker.Name, BeNumerically("~", 2*time.Hour, 10*time.Second)))
+		})
+
+		It("returns zero for the landing workers without blocking builds", func() {
+			drainTimes, err := workerLifecycle.EstimateLandingDrainTimes(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(drainTimes).To(HaveKeyWithValue("idle-worker", time.Duration(0)))
+		})
+
+		It("leaves out the workers which are not landing", func() {
+			drainTimes, err := workerLifecycle.EstimateLandingDrainTimes(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(drainTimes).ToNot(HaveKey("default-worker"))
+		})
+	})
+
+	Describe("GetBuildsBlockingWorkerLanding", func() {
+		var dbWorker db.Worker
+
+		BeforeEach(func() {
+			var err error
+			atcWorker.State = string(db.WorkerStateLanding)
+			dbWorker, err = workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			pipeline, _, err := defaultTeam.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, atc.Config{
+				Jobs: atc.JobConfigs{
+					{
+						Name:          "uninterruptible-job",
+						Interruptible: false,
+					},
+					{
+						Name:          "interruptible-job",
+						Interruptible: true,
+					},
+				},
+			}, db.ConfigVersion(0), false)
+			Expect(err).ToNot(HaveOccurred())
+
+			for _, jobName := range []string{"uninterruptible-job", "interruptible-job"} {
+				job, found, err := pipeline.Job(jobName)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				build, err := job.CreateBuild(defaultBuildCreatedBy)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = dbWorker.CreateContainer(db.NewBuildStepContainerOwner(build.ID(), atc.PlanID("4"), defaultTeam.ID()), db.ContainerMetadata{})
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			finishedBuild, err := defaultTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = finishedBuild.Finish(db.BuildStatusSucceeded)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = dbWorker.CreateContainer(db.NewBuildStepContainerOwner(finishedBuild.ID(), atc.PlanID("4"), defaultTeam.ID()), db.ContainerMetadata{})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns the incomplete builds which keep the worker from landing", func() {
+			blockingBuilds, err := workerLifecycle.GetBuildsBlockingWorkerLanding(ctx, atcWorker.Name)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(blockingBuilds).To(HaveLen(1))
+			Expect(blockingBuilds[0].JobName).To(HaveValue(Equal("uninterruptible-job")))
+			Expect(blockingBuilds[0].Interruptible).To(BeFalse())
+		})
+
+		It("returns no builds for other workers", func() {
+			blockingBuilds, err := workerLifecycle.GetBuildsBlockingWorkerLanding(ctx, "other-worker")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(blockingBuilds).To(BeEmpty())
+		})
+
+		Context("when the worker is draining", func() {
+			BeforeEach(func() {
+				_, err := dbConn.Exec(`UPDATE workers SET state = 'draining' WHERE name = $1`, atcWorker.Name)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("also returns the builds of interruptible jobs", func() {
+				blockingBuilds, err := workerLifecycle.GetBuildsBlockingWorkerLanding(ctx, atcWorker.Name)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(blockingBuilds).To(HaveLen(2))
+				Expect(blockingBuilds[0].JobName).To(HaveValue(Equal("uninterruptible-job")))
+				Expect(blockingBuilds[0].Interruptible).To(BeFalse())
+				Expect(blockingBuilds[1].JobName).To(HaveValue(Equal("interruptible-job")))
+				Expect(blockingBuilds[1].Interruptible).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("DeleteWorkersForDeletedTeams", func() {
+		BeforeEach(func() {
 			_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
 			Expect(err).ToNot(HaveOccurred())
+
+			teamWorker := atcWorker
+			teamWorker.Name = "team-worker"
+			_, err = defaultTeam.SaveWorker(teamWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
 		})
 
-		It("gets the workers' state", func() {
-			countByState, err := workerLifecycle.GetWorkerStateByName()
+		It("leaves the workers of existing teams and the global workers alone", func() {
+			deletedWorkers, err := workerLifecycle.DeleteWorkersForDeletedTeams(ctx)
 			Expect(err).ToNot(HaveOccurred())
-			expectedState := map[string]db.WorkerState{
-				"default-worker": db.WorkerStateRunning,
-				"other-worker":   db.WorkerStateRunning,
-				"some-name":      db.WorkerStateStalled,
+			Expect(deletedWorkers).To(BeEmpty())
+		})
+
+		Context("when the workers table does not delete the workers of deleted teams", func() {
+			var tenantLifecycle db.WorkerLifecycle
+
+			BeforeEach(func() {
+				_, err := dbConn.Exec(`CREATE TABLE tenant_workers (LIKE workers INCLUDING DEFAULTS)`)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = dbConn.Exec(`INSERT INTO tenant_workers SELECT * FROM workers`)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = dbConn.Exec(`UPDATE tenant_workers SET team_id = 9999 WHERE name = 'team-worker'`)
+				Expect(err).ToNot(HaveOccurred())
+
+				tenantLifecycle = db.NewWorkerLifecycleWithOptions(dbConn, db.WorkerLifecycleOptions{
+					Table: "tenant_workers",
+				})
+			})
+
+			It("deletes the workers whose team is gone", func() {
+				deletedWorkers, err := tenantLifecycle.DeleteWorkersForDeletedTeams(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deletedWorkers).To(Equal([]string{"team-worker"}))
+
+				stateByName, err := tenantLifecycle.GetWorkerStateByName(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(stateByName).To(HaveKey(atcWorker.Name))
+				Expect(stateByName).ToNot(HaveKey("team-worker"))
+			})
+		})
+	})
+
+	Describe("ReconcileWorkers", func() {
+		BeforeEach(func() {
+			_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			vanishedWorker := atcWorker
+			vanishedWorker.Name = "vanished-worker"
+			_, err = workerFactory.SaveWorker(vanishedWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			quarantinedWorker := atcWorker
+			quarantinedWorker.Name = "quarantined-worker"
+			_, err = workerFactory.SaveWorker(quarantinedWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = workerLifecycle.QuarantineWorker(ctx, quarantinedWorker.Name)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("deletes the ephemeral workers which do not exist any more", func() {
+			deletedWorkers, err := workerLifecycle.ReconcileWorkers(ctx, []string{atcWorker.Name})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deletedWorkers).To(Equal([]string{"vanished-worker"}))
+
+			stateByName, err := workerLifecycle.GetWorkerStateByName(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stateByName).To(HaveKey(atcWorker.Name))
+			Expect(stateByName).ToNot(HaveKey("vanished-worker"))
+		})
+
+		It("leaves the persistent and the quarantined workers alone", func() {
+			_, err := workerLifecycle.ReconcileWorkers(ctx, []string{atcWorker.Name})
+			Expect(err).ToNot(HaveOccurred())
+
+			stateByName, err := workerLifecycle.GetWorkerStateByName(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stateByName).To(HaveKey("default-worker"))
+			Expect(stateByName).To(HaveKey("other-worker"))
+			Expect(stateByName).To(HaveKeyWithValue("quarantined-worker", db.WorkerStateQuarantined))
+		})
+
+		It("refuses to reconcile with no existing workers", func() {
+			deletedWorkers, err := workerLifecycle.ReconcileWorkers(ctx, nil)
+			Expect(err).To(Equal(db.ErrNoExistingWorkers))
+			Expect(deletedWorkers).To(BeEmpty())
+
+			stateByName, err := workerLifecycle.GetWorkerStateByName(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stateByName).To(HaveKey("vanished-worker"))
+		})
+	})
+
+	Describe("CleanWorkerResourceCaches", func() {
+		BeforeEach(func() {
+			build, err := defaultTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			resourceCache, err := resourceCacheFactory.FindOrCreateResourceCache(
+				db.ForBuild(build.ID()),
+				"some-base-resource-type",
+				atc.Version{"some": "version"},
+				atc.Source{"some": "source"},
+				atc.Params{"some": "params"},
+				nil,
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			usedBaseResourceType, found, err := db.WorkerBaseResourceType{
+				Name:       "some-base-resource-type",
+				WorkerName: defaultWorker.Name(),
+			}.Find(dbConn)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			tx, err := dbConn.Begin()
+			Expect(err).ToNot(HaveOccurred())
+
+			_, _, err = db.WorkerResourceCache{
+				WorkerName:    defaultWorker.Name(),
+				ResourceCache: resourceCache,
+			}.FindOrCreate(tx, usedBaseResourceType.ID)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(tx.Commit()).To(Succeed())
+		})
+
+		It("leaves the caches of a worker which has not landed", func() {
+			cleaned, err := workerLifecycle.CleanWorkerResourceCaches(ctx, defaultWorker.Name())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cleaned).To(BeZero())
+		})
+
+		It("deletes the caches of a landed worker", func() {
+			_, err := dbConn.Exec(`UPDATE workers SET state = 'landed' WHERE name = $1`, defaultWorker.Name())
+			Expect(err).ToNot(HaveOccurred())
+
+			cleaned, err := workerLifecycle.CleanWorkerResourceCaches(ctx, defaultWorker.Name())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cleaned).To(Equal(1))
+
+			var remaining int
+			err = dbConn.QueryRow(`SELECT COUNT(*) FROM worker_resource_caches WHERE worker_name = $1`, defaultWorker.Name()).Scan(&remaining)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(remaining).To(BeZero())
+		})
+	})
+
+	Describe("GetPendingContainerDestroysByWorker", func() {
+		BeforeEach(func() {
+			dbWorker, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			build, err := defaultTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			for _, planID := range []atc.PlanID{"1", "2", "3"} {
+				creatingContainer, err := dbWorker.CreateContainer(db.NewBuildStepContainerOwner(build.ID(), planID, defaultTeam.ID()), db.ContainerMetadata{})
+				Expect(err).ToNot(HaveOccurred())
+
+				if planID == "3" {
+					continue
+				}
+
+				createdContainer, err := creatingContainer.Created()
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = createdContainer.Destroying()
+				Expect(err).ToNot(HaveOccurred())
 			}
-			Expect(countByState).To(Equal(expectedState))
 		})
 
+		It("counts the containers being destroyed on each worker", func() {
+			pendingByName, err := workerLifecycle.GetPendingContainerDestroysByWorker(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pendingByName).To(Equal(map[string]int{
+				atcWorker.Name: 2,
+			}))
+		})
+	})
+
+	Describe("OrphanContainersForWorker", func() {
+		var (
+			dbWorker         db.Worker
+			createdContainer db.CreatedContainer
+		)
+
+		BeforeEach(func() {
+			var err error
+			dbWorker, err = workerFactory.SaveWorker(atcWorker, -1*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			build, err := defaultTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			creatingContainer, err := dbWorker.CreateContainer(db.NewBuildStepContainerOwner(build.ID(), atc.PlanID("4"), defaultTeam.ID()), db.ContainerMetadata{})
+			Expect(err).ToNot(HaveOccurred())
+
+			createdContainer, err = creatingContainer.Created()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		containerState := func() string {
+			var state string
+			err := dbConn.QueryRow(`SELECT state FROM containers WHERE handle = $1`, createdContainer.Handle()).Scan(&state)
+			Expect(err).ToNot(HaveOccurred())
+			return state
+		}
+
+		It("leaves the containers of a worker which is not deleted", func() {
+			orphaned, err := workerLifecycle.OrphanContainersForWorker(ctx, atcWorker.Name)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(orphaned).To(BeZero())
+			Expect(containerState()).To(Equal(atc.ContainerStateCreated))
+		})
+
+		Context("when the worker has been soft deleted", func() {
+			BeforeEach(func() {
+				softDeletingLifecycle := db.NewWorkerLifecycleWithOptions(dbConn, db.WorkerLifecycleOptions{
+					SoftDeleteEphemeralWorkers: true,
+				})
+
+				deletedWorkers, err := softDeletingLifecycle.DeleteUnresponsiveEphemeralWorkers(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deletedWorkers).To(ConsistOf(atcWorker.Name))
+			})
+
+			It("has already had its containers marked as destroying by the deletion", func() {
+				Expect(containerState()).To(Equal(atc.ContainerStateDestroying))
+
+				orphaned, err := workerLifecycle.OrphanContainersForWorker(ctx, atcWorker.Name)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(orphaned).To(BeZero())
+			})
+
+			Context("when its containers were left behind", func() {
+				BeforeEach(func() {
+					_, err := dbConn.Exec(`UPDATE containers SET state = $1 WHERE handle = $2`, atc.ContainerStateCreated, createdContainer.Handle())
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("marks its containers as destroying", func() {
+					orphaned, err := workerLifecycle.OrphanContainersForWorker(ctx, atcWorker.Name)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(orphaned).To(Equal(1))
+					Expect(containerState()).To(Equal(atc.ContainerStateDestroying))
+				})
+			})
+		})
+	})
+
+	Describe("LandAllWorkers", func() {
+		BeforeEach(func() {
+			_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			retiringWorker := atcWorker
+			retiringWorker.Name = "retiring-worker"
+			retiringWorker.State = string(db.WorkerStateRetiring)
+			_, err = workerFactory.SaveWorker(retiringWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("starts landing every running worker", func() {
+			landingWorkers, err := workerLifecycle.LandAllWorkers(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(landingWorkers).To(ConsistOf("default-worker", "other-worker", "some-name"))
+
+			stateByName, err := workerLifecycle.GetWorkerStateByName(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stateByName).To(Equal(map[string]db.WorkerState{
+				"default-worker":  db.WorkerStateLanding,
+				"other-worker":    db.WorkerStateLanding,
+				"some-name":       db.WorkerStateLanding,
+				"retiring-worker": db.WorkerStateRetiring,
+			}))
+		})
+
+		It("is idempotent", func() {
+			_, err := workerLifecycle.LandAllWorkers(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			landingWorkers, err := workerLifecycle.LandAllWorkers(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(landingWorkers).To(BeEmpty())
+		})
+	})
+
+	Describe("LandWorkersWithTag", func() {
+		BeforeEach(func() {
+			_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			otherTagWorker := atcWorker
+			otherTagWorker.Name = "other-tag-worker"
+			otherTagWorker.Tags = []string{"other-tag"}
+			_, err = workerFactory.SaveWorker(otherTagWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			retiringWorker := atcWorker
+			retiringWorker.Name = "retiring-worker"
+			retiringWorker.State = string(db.WorkerStateRetiring)
+			_, err = workerFactory.SaveWorker(retiringWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("starts landing the running workers carrying the tag", func() {
+			landingWorkers, err := workerLifecycle.LandWorkersWithTag(ctx, "tags")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(landingWorkers).To(ConsistOf("some-name"))
+
+			stateByName, err := workerLifecycle.GetWorkerStateByName(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stateByName).To(Equal(map[string]db.WorkerState{
+				"default-worker":   db.WorkerStateRunning,
+				"other-worker":     db.WorkerStateRunning,
+				"some-name":        db.WorkerStateLanding,
+				"other-tag-worker": db.WorkerStateRunning,
+				"retiring-worker":  db.WorkerStateRetiring,
+			}))
+		})
+
+		It("does not match part of a tag", func() {
+			landingWorkers, err := workerLifecycle.LandWorkersWithTag(ctx, "tag")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(landingWorkers).To(BeEmpty())
+		})
+
+		It("tells the observer why the workers are landing", func() {
+			fakeObserver := new(dbfakes.FakeLifecycleObserver)
+			observedLifecycle := db.NewWorkerLifecycle(dbConn, fakeObserver)
+
+			_, err := observedLifecycle.LandWorkersWithTag(ctx, "other-tag")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeObserver.WorkerStateChangedCallCount()).To(Equal(1))
+			name, from, to, reason := fakeObserver.WorkerStateChangedArgsForCall(0)
+			Expect(name).To(Equal("other-tag-worker"))
+			Expect(from).To(Equal(db.WorkerStateRunning))
+			Expect(to).To(Equal(db.WorkerStateLanding))
+			Expect(reason).To(Equal(db.WorkerTransitionReasonLandTagged))
+		})
+	})
+
+	Describe("LandUnreachableWorkers", func() {
+		BeforeEach(func() {
+			_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = workerLifecycle.MarkBaggageclaimHealth(ctx, atcWorker.Name, true)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = workerLifecycle.MarkBaggageclaimHealth(ctx, "default-worker", true)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = dbConn.Exec(`UPDATE workers SET baggageclaim_last_seen = NOW() - '1 hour'::interval WHERE name = $1`, atcWorker.Name)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("starts landing the running workers whose baggageclaim has not been seen for longer than the threshold", func() {
+			landingWorkers, err := workerLifecycle.LandUnreachableWorkers(ctx, 10*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(landingWorkers).To(ConsistOf(atcWorker.Name))
+
+			stateByName, err := workerLifecycle.GetWorkerStateByName(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stateByName).To(Equal(map[string]db.WorkerState{
+				"default-worker": db.WorkerStateRunning,
+				"other-worker":   db.WorkerStateRunning,
+				"some-name":      db.WorkerStateLanding,
+			}))
+		})
+
+		It("leaves the workers whose baggageclaim was seen within the threshold alone", func() {
+			landingWorkers, err := workerLifecycle.LandUnreachableWorkers(ctx, 2*time.Hour)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(landingWorkers).To(BeEmpty())
+		})
+
+		It("leaves the workers whose baggageclaim has never been seen alone", func() {
+			landingWorkers, err := workerLifecycle.LandUnreachableWorkers(ctx, 0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(landingWorkers).ToNot(ContainElement("other-worker"))
+		})
+
+		It("does not count an unhealthy probe as the baggageclaim being seen", func() {
+			err := workerLifecycle.MarkBaggageclaimHealth(ctx, atcWorker.Name, false)
+			Expect(err).ToNot(HaveOccurred())
+
+			landingWorkers, err := workerLifecycle.LandUnreachableWorkers(ctx, 10*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(landingWorkers).To(ConsistOf(atcWorker.Name))
+		})
+
+		It("tells the observer why the workers are landing", func() {
+			fakeObserver := new(dbfakes.FakeLifecycleObserver)
+			observedLifecycle := db.NewWorkerLifecycle(dbConn, fakeObserver)
+
+			_, err := observedLifecycle.LandUnreachableWorkers(ctx, 10*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeObserver.WorkerStateChangedCallCount()).To(Equal(1))
+			_, _, _, reason := fakeObserver.WorkerStateChangedArgsForCall(0)
+			Expect(reason).To(Equal(db.WorkerTransitionReasonUnreachable))
+		})
+	})
+
+	Describe("ForceDeleteRetiringWorkers", func() {
+		BeforeEach(func() {
+			atcWorker.State = string(db.WorkerStateRetiring)
+			dbWorker, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			dbBuild, err := defaultTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = dbWorker.CreateContainer(db.NewBuildStepContainerOwner(dbBuild.ID(), atc.PlanID("4"), defaultTeam.ID()), db.ContainerMetadata{})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("deletes the retiring workers despite their uninterruptible builds", func() {
+			retiredWorkers, err := workerLifecycle.DeleteFinishedRetiringWorkers(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(retiredWorkers).To(BeEmpty())
+
+			retiredWorkers, err = workerLifecycle.ForceDeleteRetiringWorkers(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(retiredWorkers).To(Equal([]string{atcWorker.Name}))
+
+			_, found, err := workerFactory.GetWorker(atcWorker.Name)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Describe("LandFinishedLandingWorkersForWorkers", func() {
+		BeforeEach(func() {
+			atcWorker.State = string(db.WorkerStateLanding)
+			dbWorker, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			build, err := defaultTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = dbWorker.CreateContainer(db.NewBuildStepContainerOwner(build.ID(), atc.PlanID("4"), defaultTeam.ID()), db.ContainerMetadata{})
+			Expect(err).ToNot(HaveOccurred())
+
+			for _, name := range []string{"landing-worker", "other-landing-worker"} {
+				landingWorker := atcWorker
+				landingWorker.Name = name
+				_, err = workerFactory.SaveWorker(landingWorker, 5*time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+			}
+		})
+
+		It("only lands the named workers which have finished landing", func() {
+			landedWorkers, err := workerLifecycle.LandFinishedLandingWorkersForWorkers(ctx, []string{atcWorker.Name, "landing-worker"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(landedWorkers).To(Equal([]string{"landing-worker"}))
+
+			stateByName, err := workerLifecycle.GetWorkerStateByName(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stateByName).To(HaveKeyWithValue(atcWorker.Name, db.WorkerStateLanding))
+			Expect(stateByName).To(HaveKeyWithValue("other-landing-worker", db.WorkerStateLanding))
+		})
+
+		It("does nothing given no names", func() {
+			landedWorkers, err := workerLifecycle.LandFinishedLandingWorkersForWorkers(ctx, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(landedWorkers).ToNot(BeNil())
+			Expect(landedWorkers).To(BeEmpty())
+
+			stateByName, err := workerLifecycle.GetWorkerStateByName(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stateByName).To(HaveKeyWithValue("landing-worker", db.WorkerStateLanding))
+		})
+	})
+
+	Describe("LandWorkersInterruptingBuilds", func() {
+		BeforeEach(func() {
+			pipeline, _, err := defaultTeam.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, atc.Config{
+				Jobs: atc.JobConfigs{
+					{
+						Name:          "uninterruptible-job",
+						Interruptible: false,
+					},
+					{
+						Name:          "interruptible-job",
+						Interruptible: true,
+					},
+				},
+			}, db.ConfigVersion(0), false)
+			Expect(err).ToNot(HaveOccurred())
+
+			for workerName, jobName := range map[string]string{
+				"interrupting-worker":    "interruptible-job",
+				"uninterruptible-worker": "uninterruptible-job",
+			} {
+				landingWorker := atcWorker
+				landingWorker.Name = workerName
+				landingWorker.State = string(db.WorkerStateLanding)
+				dbWorker, err := workerFactory.SaveWorker(landingWorker, 5*time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+
+				job, found, err := pipeline.Job(jobName)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				build, err := job.CreateBuild(defaultBuildCreatedBy)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = dbWorker.CreateContainer(db.NewBuildStepContainerOwner(build.ID(), atc.PlanID("4"), defaultTeam.ID()), db.ContainerMetadata{})
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			drainingWorker := atcWorker
+			drainingWorker.Name = "draining-worker"
+			drainingWorker.State = string(db.WorkerStateDraining)
+			_, err = workerFactory.SaveWorker(drainingWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("lands the landing workers running only interruptible builds", func() {
+			landedWorkers, err := workerLifecycle.LandWorkersInterruptingBuilds(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(landedWorkers).To(Equal([]string{"interrupting-worker"}))
+
+			stateByName, err := workerLifecycle.GetWorkerStateByName(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stateByName).To(HaveKeyWithValue("interrupting-worker", db.WorkerStateLanded))
+			Expect(stateByName).To(HaveKeyWithValue("uninterruptible-worker", db.WorkerStateLanding))
+			Expect(stateByName).To(HaveKeyWithValue("draining-worker", db.WorkerStateDraining))
+		})
+	})
+
+	Describe("LandFinishedLandingWorkersForPlatform", func() {
+		BeforeEach(func() {
+			atcWorker.State = string(db.WorkerStateLanding)
+			_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			windowsWorker := atcWorker
+			windowsWorker.Name = "windows-worker"
+			windowsWorker.Platform = "windows"
+			_, err = workerFactory.SaveWorker(windowsWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("only lands the workers of the platform", func() {
+			landedWorkers, err := workerLifecycle.LandFinishedLandingWorkersForPlatform(ctx, "windows")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(landedWorkers).To(Equal([]string{"windows-worker"}))
+
+			stateByName, err := workerLifecycle.GetWorkerStateByName(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stateByName).To(HaveKeyWithValue("windows-worker", db.WorkerStateLanded))
+			Expect(stateByName).To(HaveKeyWithValue(atcWorker.Name, db.WorkerStateLanding))
+		})
+	})
+
+	Describe("LandFinishedLandingWorkersKeepMinimum", func() {
+		BeforeEach(func() {
+			atcWorker.State = string(db.WorkerStateLanding)
+			_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			for _, name := range []string{"team-worker-b", "team-worker-a"} {
+				teamWorker := atcWorker
+				teamWorker.Name = name
+				_, err := defaultTeam.SaveWorker(teamWorker, 5*time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+			}
+		})
+
+		It("keeps the minimum number of workers available for every team", func() {
+			landedWorkers, err := workerLifecycle.LandFinishedLandingWorkersKeepMinimum(ctx, 1)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(landedWorkers).To(ConsistOf(atcWorker.Name, "team-worker-a"))
+
+			landedWorkers, err = workerLifecycle.LandFinishedLandingWorkersKeepMinimum(ctx, 1)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(landedWorkers).To(BeEmpty())
+
+			stateByName, err := workerLifecycle.GetWorkerStateByName(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stateByName).To(HaveKeyWithValue("team-worker-b", db.WorkerStateLanding))
+		})
+
+		It("counts the running workers of the team as available", func() {
+			runningWorker := atcWorker
+			runningWorker.Name = "team-worker-c"
+			runningWorker.State = string(db.WorkerStateRunning)
+			_, err := defaultTeam.SaveWorker(runningWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			landedWorkers, err := workerLifecycle.LandFinishedLandingWorkersKeepMinimum(ctx, 1)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(landedWorkers).To(ConsistOf(atcWorker.Name, "team-worker-a", "team-worker-b"))
+		})
+
+		It("does not count the landing and draining workers of the team as running", func() {
+			drainingWorker := atcWorker
+			drainingWorker.Name = "team-worker-c"
+			drainingWorker.State = string(db.WorkerStateDraining)
+			_, err := defaultTeam.SaveWorker(drainingWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			landedWorkers, err := workerLifecycle.LandFinishedLandingWorkersKeepMinimum(ctx, 3)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(landedWorkers).To(ConsistOf(atcWorker.Name))
+
+			stateByName, err := workerLifecycle.GetWorkerStateByName(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stateByName).To(HaveKeyWithValue("team-worker-a", db.WorkerStateLanding))
+			Expect(stateByName).To(HaveKeyWithValue("team-worker-b", db.WorkerStateLanding))
+			Expect(stateByName).To(HaveKeyWithValue("team-worker-c", db.WorkerStateDraining))
+		})
+
+		It("does not land a worker which would leave the team below the minimum of running workers", func() {
+			runningWorker := atcWorker
+			runningWorker.Name = "team-worker-c"
+			runningWorker.State = string(db.WorkerStateRunning)
+			_, err := defaultTeam.SaveWorker(runningWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			landedWorkers, err := workerLifecycle.LandFinishedLandingWorkersKeepMinimum(ctx, 2)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(landedWorkers).To(ConsistOf(atcWorker.Name, "team-worker-a"))
+
+			stateByName, err := workerLifecycle.GetWorkerStateByName(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stateByName).To(HaveKeyWithValue("team-worker-b", db.WorkerStateLanding))
+		})
+	})
+
+	Describe("LandFinishedLandingWorkersWithDuration", func() {
+		BeforeEach(func() {
+			atcWorker.State = string(db.WorkerStateLanding)
+			atcWorker.ActiveVolumes = 7
+			_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = dbConn.Exec(`UPDATE workers SET state_changed_at = NOW() - '10 minutes'::interval WHERE name = $1`, atcWorker.Name)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("lands the worker and returns how long it was landing", func() {
+			landedWorkers, err := workerLifecycle.LandFinishedLandingWorkersWithDuration(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(landedWorkers).To(HaveLen(1))
+			Expect(landedWorkers[0].Name).To(Equal(atcWorker.Name))
+			Expect(landedWorkers[0].From).To(Equal(db.WorkerStateLanding))
+			Expect(landedWorkers[0].Reason).To(Equal(db.WorkerTransitionReasonLandingComplete))
+			Expect(landedWorkers[0].LandingDuration).To(BeNumerically("~", 10*time.Minute, time.Minute))
+			Expect(landedWorkers[0].ActiveContainers).To(Equal(140))
+			Expect(landedWorkers[0].ActiveVolumes).To(Equal(7))
+
+			foundWorker, found, err := workerFactory.GetWorker(atcWorker.Name)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(foundWorker.State()).To(Equal(db.WorkerStateLanded))
+		})
+
+		It("returns the reason the workers were landed for from the detailed variant", func() {
+			landedWorkers, err := workerLifecycle.LandFinishedLandingWorkersDetailed(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(landedWorkers).To(Equal([]db.WorkerAffected{{
+				Name:   atcWorker.Name,
+				Reason: "landing-complete",
+			}}))
+		})
+
+		It("resets the time the worker changed state", func() {
+			_, err := workerLifecycle.LandFinishedLandingWorkersWithDuration(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			var stateChangedAt time.Time
+			err = dbConn.QueryRow(`SELECT state_changed_at FROM workers WHERE name = $1`, atcWorker.Name).Scan(&stateChangedAt)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stateChangedAt).To(BeTemporally("~", time.Now(), time.Minute))
+		})
+	})
+
+	Describe("ProcessFinishedWorkers", func() {
+		var landingWorker, retiringWorker, busyWorker atc.Worker
+
+		BeforeEach(func() {
+			landingWorker = atcWorker
+			landingWorker.Name = "landing-worker"
+			landingWorker.State = string(db.WorkerStateLanding)
+
+			retiringWorker = atcWorker
+			retiringWorker.Name = "retiring-worker"
+			retiringWorker.State = string(db.WorkerStateRetiring)
+
+			busyWorker = atcWorker
+			busyWorker.Name = "busy-worker"
+			busyWorker.State = string(db.WorkerStateRetiring)
+
+			for _, w := range []atc.Worker{landingWorker, retiringWorker} {
+				_, err := workerFactory.SaveWorker(w, 5*time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			dbWorker, err := workerFactory.SaveWorker(busyWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			dbBuild, err := defaultTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = dbBuild.Start(atc.Plan{})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = dbWorker.CreateContainer(db.NewBuildStepContainerOwner(dbBuild.ID(), atc.PlanID("4"), defaultTeam.ID()), db.ContainerMetadata{})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("lands and retires finished workers in one pass", func() {
+			landed, retired, err := workerLifecycle.ProcessFinishedWorkers(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(landed).To(ConsistOf("landing-worker"))
+			Expect(retired).To(ConsistOf("retiring-worker"))
+
+			foundWorker, found, err := workerFactory.GetWorker("landing-worker")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(foundWorker.State()).To(Equal(db.WorkerStateLanded))
+
+			_, found, err = workerFactory.GetWorker("retiring-worker")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("leaves workers with uninterruptible builds alone", func() {
+			_, _, err := workerLifecycle.ProcessFinishedWorkers(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			foundWorker, found, err := workerFactory.GetWorker("busy-worker")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(foundWorker.State()).To(Equal(db.WorkerStateRetiring))
+		})
+	})
+
+	Describe("upgrading workers", func() {
+		upgradePending := func(name string) bool {
+			var pending bool
+			err := dbConn.QueryRow(`SELECT upgrade_pending FROM workers WHERE name = $1`, name).Scan(&pending)
+			Expect(err).ToNot(HaveOccurred())
+			return pending
+		}
+
+		BeforeEach(func() {
+			_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Describe("MarkWorkerForUpgrade", func() {
+			It("starts landing the worker and flags it", func() {
+				err := workerLifecycle.MarkWorkerForUpgrade(ctx, atcWorker.Name)
+				Expect(err).ToNot(HaveOccurred())
+
+				stateByName, err := workerLifecycle.GetWorkerStateByName(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(stateByName).To(HaveKeyWithValue(atcWorker.Name, db.WorkerStateLanding))
+				Expect(upgradePending(atcWorker.Name)).To(BeTrue())
+			})
+
+			It("flags a worker which is already landing", func() {
+				_, err := workerLifecycle.TransitionWorker(ctx, atcWorker.Name, db.WorkerStateRunning, db.WorkerStateLanding)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = workerLifecycle.MarkWorkerForUpgrade(ctx, atcWorker.Name)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(upgradePending(atcWorker.Name)).To(BeTrue())
+			})
+
+			It("returns ErrInvalidWorkerTransition when the worker cannot be landed", func() {
+				atcWorker.State = string(db.WorkerStateStalled)
+				_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = workerLifecycle.MarkWorkerForUpgrade(ctx, atcWorker.Name)
+				Expect(err).To(MatchError(db.ErrInvalidWorkerTransition))
+				Expect(upgradePending(atcWorker.Name)).To(BeFalse())
+			})
+
+			It("returns ErrWorkerNotPresent when the worker does not exist", func() {
+				err := workerLifecycle.MarkWorkerForUpgrade(ctx, "bogus-worker")
+				Expect(err).To(Equal(db.ErrWorkerNotPresent))
+			})
+
+			It("clears the flag when the worker registers again", func() {
+				err := workerLifecycle.MarkWorkerForUpgrade(ctx, atcWorker.Name)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+
+				stateByName, err := workerLifecycle.GetWorkerStateByName(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(stateByName).To(HaveKeyWithValue(atcWorker.Name, db.WorkerStateRunning))
+				Expect(upgradePending(atcWorker.Name)).To(BeFalse())
+			})
+		})
+
+		Describe("RestoreUpgradedWorkers", func() {
+			BeforeEach(func() {
+				err := workerLifecycle.MarkWorkerForUpgrade(ctx, atcWorker.Name)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			Context("once the flagged worker has landed", func() {
+				BeforeEach(func() {
+					landedWorkers, err := workerLifecycle.LandFinishedLandingWorkers(ctx)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(landedWorkers).To(ConsistOf(atcWorker.Name))
+				})
+
+				It("leaves the worker landed while it has no address", func() {
+					restoredWorkers, err := workerLifecycle.RestoreUpgradedWorkers(ctx, []string{atcWorker.Name})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(restoredWorkers).To(BeEmpty())
+
+					stateByName, err := workerLifecycle.GetWorkerStateByName(ctx)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(stateByName).To(HaveKeyWithValue(atcWorker.Name, db.WorkerStateLanded))
+					Expect(upgradePending(atcWorker.Name)).To(BeTrue())
+				})
+
+				It("brings the worker back to running and clears its flag once it has an address", func() {
+					_, err := dbConn.Exec(`UPDATE workers SET addr = $1 WHERE name = $2`, atcWorker.GardenAddr, atcWorker.Name)
+					Expect(err).ToNot(HaveOccurred())
+
+					restoredWorkers, err := workerLifecycle.RestoreUpgradedWorkers(ctx, []string{atcWorker.Name})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(restoredWorkers).To(ConsistOf(atcWorker.Name))
+
+					stateByName, err := workerLifecycle.GetWorkerStateByName(ctx)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(stateByName).To(HaveKeyWithValue(atcWorker.Name, db.WorkerStateRunning))
+					Expect(upgradePending(atcWorker.Name)).To(BeFalse())
+				})
+
+				It("leaves the workers it is not given alone", func() {
+					restoredWorkers, err := workerLifecycle.RestoreUpgradedWorkers(ctx, []string{"other-worker"})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(restoredWorkers).To(BeEmpty())
+				})
+			})
+
+			It("leaves the flagged worker alone while it is still landing", func() {
+				restoredWorkers, err := workerLifecycle.RestoreUpgradedWorkers(ctx, []string{atcWorker.Name})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(restoredWorkers).To(BeEmpty())
+			})
+
+			It("leaves landed workers without the flag alone", func() {
+				_, err := dbConn.Exec(`UPDATE workers SET state = 'landed' WHERE name = 'default-worker'`)
+				Expect(err).ToNot(HaveOccurred())
+
+				restoredWorkers, err := workerLifecycle.RestoreUpgradedWorkers(ctx, []string{"default-worker"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(restoredWorkers).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("ClaimWorkerForLanding", func() {
+		BeforeEach(func() {
+			atcWorker.State = string(db.WorkerStateLanding)
+			_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("claims an unclaimed worker", func() {
+			claimed, err := workerLifecycle.ClaimWorkerForLanding(ctx, atcWorker.Name, "some-atc", time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(claimed).To(BeTrue())
+		})
+
+		It("renews the claim of the ATC which holds it", func() {
+			_, err := workerLifecycle.ClaimWorkerForLanding(ctx, atcWorker.Name, "some-atc", time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			claimed, err := workerLifecycle.ClaimWorkerForLanding(ctx, atcWorker.Name, "some-atc", time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(claimed).To(BeTrue())
+		})
+
+		It("does not claim a worker claimed by another ATC", func() {
+			_, err := workerLifecycle.ClaimWorkerForLanding(ctx, atcWorker.Name, "some-atc", time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			claimed, err := workerLifecycle.ClaimWorkerForLanding(ctx, atcWorker.Name, "other-atc", time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(claimed).To(BeFalse())
+		})
+
+		It("claims a worker whose claim has expired", func() {
+			_, err := workerLifecycle.ClaimWorkerForLanding(ctx, atcWorker.Name, "some-atc", -1*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			claimed, err := workerLifecycle.ClaimWorkerForLanding(ctx, atcWorker.Name, "other-atc", time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(claimed).To(BeTrue())
+		})
+
+		It("holds a claim for a lease shorter than a second", func() {
+			_, err := workerLifecycle.ClaimWorkerForLanding(ctx, atcWorker.Name, "some-atc", 500*time.Millisecond)
+			Expect(err).ToNot(HaveOccurred())
+
+			claimed, err := workerLifecycle.ClaimWorkerForLanding(ctx, atcWorker.Name, "other-atc", time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(claimed).To(BeFalse())
+		})
+
+		It("returns ErrDryRunClaim in dry-run mode, without claiming the worker", func() {
+			dryRunLifecycle := db.NewWorkerLifecycleWithOptions(dbConn, db.WorkerLifecycleOptions{DryRun: true})
+
+			claimed, err := dryRunLifecycle.ClaimWorkerForLanding(ctx, atcWorker.Name, "some-atc", time.Minute)
+			Expect(err).To(Equal(db.ErrDryRunClaim))
+			Expect(claimed).To(BeFalse())
+
+			claimed, err = workerLifecycle.ClaimWorkerForLanding(ctx, atcWorker.Name, "other-atc", time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(claimed).To(BeTrue())
+		})
+
+		It("returns ErrWorkerNotPresent if there is no such worker", func() {
+			claimed, err := workerLifecycle.ClaimWorkerForLanding(ctx, "bogus-worker", "some-atc", time.Minute)
+			Expect(err).To(Equal(db.ErrWorkerNotPresent))
+			Expect(claimed).To(BeFalse())
+		})
+
+		Context("when the worker is claimed", func() {
+			BeforeEach(func() {
+				claimed, err := workerLifecycle.ClaimWorkerForLanding(ctx, atcWorker.Name, "some-atc", time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(claimed).To(BeTrue())
+			})
+
+			It("is not landed by another ATC", func() {
+				otherLifecycle := db.NewWorkerLifecycleWithOptions(dbConn, db.WorkerLifecycleOptions{
+					ATCID: "other-atc",
+				})
+
+				landedWorkers, err := otherLifecycle.LandFinishedLandingWorkers(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(landedWorkers).To(BeEmpty())
+			})
+
+			It("is landed by the ATC which claimed it, releasing the claim", func() {
+				claimingLifecycle := db.NewWorkerLifecycleWithOptions(dbConn, db.WorkerLifecycleOptions{
+					ATCID: "some-atc",
+				})
+
+				landedWorkers, err := claimingLifecycle.LandFinishedLandingWorkers(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(landedWorkers).To(Equal([]string{atcWorker.Name}))
+
+				claimed, err := workerLifecycle.ClaimWorkerForLanding(ctx, atcWorker.Name, "other-atc", time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(claimed).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("DrainWorker", func() {
+		BeforeEach(func() {
+			_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("drains a running worker", func() {
+			err := workerLifecycle.DrainWorker(ctx, atcWorker.Name)
+			Expect(err).ToNot(HaveOccurred())
+
+			stateByName, err := workerLifecycle.GetWorkerStateByName(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stateByName).To(HaveKeyWithValue(atcWorker.Name, db.WorkerStateDraining))
+		})
+
+		It("does nothing when the worker is already draining", func() {
+			err := workerLifecycle.DrainWorker(ctx, atcWorker.Name)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = workerLifecycle.DrainWorker(ctx, atcWorker.Name)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns ErrInvalidWorkerTransition when the worker cannot be drained", func() {
+			atcWorker.State = string(db.WorkerStateLanded)
+			_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = workerLifecycle.DrainWorker(ctx, atcWorker.Name)
+			Expect(err).To(MatchError(db.ErrInvalidWorkerTransition))
+		})
+
+		It("returns ErrWorkerNotPresent when the worker does not exist", func() {
+			err := workerLifecycle.DrainWorker(ctx, "bogus-worker")
+			Expect(err).To(Equal(db.ErrWorkerNotPresent))
+		})
+
+		Context("when the worker has a build of an interruptible job", func() {
+			var dbBuild db.Build
+
+			BeforeEach(func() {
+				dbWorker, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+
+				pipeline, _, err := defaultTeam.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, atc.Config{
+					Jobs: atc.JobConfigs{
+						{
+							Name:          "some-job",
+							Interruptible: true,
+						},
+					},
+				}, db.ConfigVersion(0), false)
+				Expect(err).ToNot(HaveOccurred())
+
+				job, found, err := pipeline.Job("some-job")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				dbBuild, err = job.CreateBuild(defaultBuildCreatedBy)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = dbWorker.CreateContainer(db.NewBuildStepContainerOwner(dbBuild.ID(), atc.PlanID("4"), defaultTeam.ID()), db.ContainerMetadata{})
+				Expect(err).ToNot(HaveOccurred())
+
+				err = workerLifecycle.DrainWorker(ctx, atcWorker.Name)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("only lands the worker once the build is done", func() {
+				landedWorkers, err := workerLifecycle.LandFinishedLandingWorkersWithDuration(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(landedWorkers).To(BeEmpty())
+
+				err = dbBuild.Finish(db.BuildStatusSucceeded)
+				Expect(err).ToNot(HaveOccurred())
+
+				landedWorkers, err = workerLifecycle.LandFinishedLandingWorkersWithDuration(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(landedWorkers).To(HaveLen(1))
+				Expect(landedWorkers[0].Name).To(Equal(atcWorker.Name))
+				Expect(landedWorkers[0].From).To(Equal(db.WorkerStateDraining))
+			})
+		})
+	})
+
+	Describe("QuarantineWorker", func() {
+		BeforeEach(func() {
+			_, err := workerFactory.SaveWorker(atcWorker, -1*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = workerLifecycle.QuarantineWorker(ctx, atcWorker.Name)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("quarantines the worker", func() {
+			stateByName, err := workerLifecycle.GetWorkerStateByName(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stateByName).To(HaveKeyWithValue(atcWorker.Name, db.WorkerStateQuarantined))
+		})
+
+		It("leaves the quarantined worker alone in every lifecycle pass", func() {
+			deletedWorkers, err := workerLifecycle.DeleteUnresponsiveEphemeralWorkers(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deletedWorkers).To(BeEmpty())
+
+			stalledWorkers, err := workerLifecycle.StallUnresponsiveWorkers(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stalledWorkers).ToNot(ContainElement(atcWorker.Name))
+
+			landedWorkers, err := workerLifecycle.LandFinishedLandingWorkers(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(landedWorkers).To(BeEmpty())
+
+			retiredWorkers, err := workerLifecycle.DeleteFinishedRetiringWorkers(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(retiredWorkers).To(BeEmpty())
+
+			stateByName, err := workerLifecycle.GetWorkerStateByName(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stateByName).To(HaveKeyWithValue(atcWorker.Name, db.WorkerStateQuarantined))
+		})
+
+		It("keeps the worker quarantined when it heartbeats", func() {
+			_, err := workerFactory.HeartbeatWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			stateByName, err := workerLifecycle.GetWorkerStateByName(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stateByName).To(HaveKeyWithValue(atcWorker.Name, db.WorkerStateQuarantined))
+		})
+
+		It("keeps the worker quarantined when it registers again", func() {
+			_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			stateByName, err := workerLifecycle.GetWorkerStateByName(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stateByName).To(HaveKeyWithValue(atcWorker.Name, db.WorkerStateQuarantined))
+		})
+
+		It("returns ErrInvalidWorkerTransition when the worker has landed", func() {
+			landedWorker := atcWorker
+			landedWorker.Name = "landed-worker"
+			landedWorker.State = string(db.WorkerStateLanding)
+			_, err := workerFactory.SaveWorker(landedWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = workerLifecycle.SetWorkerStates(ctx, []string{landedWorker.Name}, db.WorkerStateLanded)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = workerLifecycle.QuarantineWorker(ctx, landedWorker.Name)
+			Expect(err).To(MatchError(db.ErrInvalidWorkerTransition))
+
+			stateByName, err := workerLifecycle.GetWorkerStateByName(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stateByName).To(HaveKeyWithValue(landedWorker.Name, db.WorkerStateLanded))
+		})
+
+		It("does nothing when the worker is already quarantined", func() {
+			err := workerLifecycle.QuarantineWorker(ctx, atcWorker.Name)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns ErrWorkerNotPresent when the worker does not exist", func() {
+			err := workerLifecycle.QuarantineWorker(ctx, "bogus-worker")
+			Expect(err).To(Equal(db.ErrWorkerNotPresent))
+		})
+
+		Describe("UnquarantineWorker", func() {
+			It("releases the worker back to running", func() {
+				err := workerLifecycle.UnquarantineWorker(ctx, atcWorker.Name)
+				Expect(err).ToNot(HaveOccurred())
+
+				stateByName, err := workerLifecycle.GetWorkerStateByName(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(stateByName).To(HaveKeyWithValue(atcWorker.Name, db.WorkerStateRunning))
+			})
+
+			It("returns ErrInvalidWorkerTransition when the worker is not quarantined", func() {
+				_, err := workerLifecycle.SetWorkerStates(ctx, []string{"default-worker"}, db.WorkerStateLanding)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = workerLifecycle.UnquarantineWorker(ctx, "default-worker")
+				Expect(err).To(MatchError(db.ErrInvalidWorkerTransition))
+			})
+
+			It("returns ErrWorkerNotPresent when the worker does not exist", func() {
+				err := workerLifecycle.UnquarantineWorker(ctx, "bogus-worker")
+				Expect(err).To(Equal(db.ErrWorkerNotPresent))
+			})
+		})
+	})
+
+	Describe("ResurrectWorker", func() {
+		BeforeEach(func() {
+			atcWorker.Ephemeral = false
+			_, err := workerFactory.SaveWorker(atcWorker, -1*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("when the worker is stalled", func() {
+			BeforeEach(func() {
+				stalledWorkers, err := workerLifecycle.StallUnresponsiveWorkers(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(stalledWorkers).To(ConsistOf(atcWorker.Name))
+			})
+
+			It("brings the worker back to running at its new address", func() {
+				err := workerLifecycle.ResurrectWorker(ctx, atcWorker.Name, "1.2.3.4:7777", "http://1.2.3.4:7788", 5*time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+
+				foundWorker, found, err := workerFactory.GetWorker(atcWorker.Name)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(foundWorker.State()).To(Equal(db.WorkerStateRunning))
+				Expect(foundWorker.GardenAddr()).To(HaveValue(Equal("1.2.3.4:7777")))
+				Expect(foundWorker.BaggageclaimURL()).To(HaveValue(Equal("http://1.2.3.4:7788")))
+
+				stalledWorkers, err := workerLifecycle.StallUnresponsiveWorkers(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(stalledWorkers).To(BeEmpty())
+			})
+		})
+
+		Context("when the worker is not stalled", func() {
+			It("returns ErrInvalidWorkerTransition", func() {
+				err := workerLifecycle.ResurrectWorker(ctx, atcWorker.Name, "1.2.3.4:7777", "http://1.2.3.4:7788", 5*time.Minute)
+				Expect(err).To(MatchError(db.ErrInvalidWorkerTransition))
+			})
+		})
+
+		Context("when the worker does not exist", func() {
+			It("returns ErrWorkerNotPresent", func() {
+				err := workerLifecycle.ResurrectWorker(ctx, "bogus-worker", "1.2.3.4:7777", "http://1.2.3.4:7788", 5*time.Minute)
+				Expect(err).To(Equal(db.ErrWorkerNotPresent))
+			})
+		})
+	})
+
+	Describe("ExpireWorker", func() {
+		Context("when the worker exists", func() {
+			BeforeEach(func() {
+				atcWorker.Ephemeral = false
+				_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("makes the worker eligible for stalling", func() {
+				err := workerLifecycle.ExpireWorker(ctx, atcWorker.Name)
+				Expect(err).ToNot(HaveOccurred())
+
+				stalledWorkers, err := workerLifecycle.StallUnresponsiveWorkers(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(stalledWorkers).To(ConsistOf(atcWorker.Name))
+			})
+		})
+
+		Context("when the worker does not exist", func() {
+			It("returns ErrWorkerNotPresent", func() {
+				err := workerLifecycle.ExpireWorker(ctx, "bogus-worker")
+				Expect(err).To(Equal(db.ErrWorkerNotPresent))
+			})
+		})
+	})
+
+	Describe("HeartbeatWorker", func() {
+		BeforeEach(func() {
+			atcWorker.Ephemeral = false
+			_, err := workerFactory.SaveWorker(atcWorker, -1*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("pushes back the expiry of a running worker", func() {
+			heartbeated, err := workerLifecycle.HeartbeatWorker(ctx, atcWorker.Name, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(heartbeated).To(BeTrue())
+
+			heartbeatAges, err := workerLifecycle.GetWorkerHeartbeatAges(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(heartbeatAges).To(HaveKeyWithValue(atcWorker.Name, BeNumerically("~", 5*time.Minute, 10*time.Second)))
+
+			stalledWorkers, err := workerLifecycle.StallUnresponsiveWorkers(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stalledWorkers).To(BeEmpty())
+		})
+
+		It("returns false when the worker is not running", func() {
+			_, err := workerLifecycle.StallUnresponsiveWorkers(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			heartbeated, err := workerLifecycle.HeartbeatWorker(ctx, atcWorker.Name, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(heartbeated).To(BeFalse())
+		})
+
+		It("returns false when the worker does not exist", func() {
+			heartbeated, err := workerLifecycle.HeartbeatWorker(ctx, "bogus-worker", 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(heartbeated).To(BeFalse())
+		})
+	})
+
+	Describe("ExtendEphemeralExpiry", func() {
+		BeforeEach(func() {
+			_, err := workerFactory.SaveWorker(atcWorker, time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("pushes back the expiry of a running ephemeral worker", func() {
+			extended, err := workerLifecycle.ExtendEphemeralExpiry(ctx, atcWorker.Name, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(extended).To(BeTrue())
+
+			heartbeatAges, err := workerLifecycle.GetWorkerHeartbeatAges(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(heartbeatAges).To(HaveKeyWithValue(atcWorker.Name, BeNumerically("~", 5*time.Minute, 10*time.Second)))
+		})
+
+		It("never brings the expiry forward", func() {
+			extended, err := workerLifecycle.ExtendEphemeralExpiry(ctx, atcWorker.Name, 30*time.Second)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(extended).To(BeFalse())
+
+			heartbeatAges, err := workerLifecycle.GetWorkerHeartbeatAges(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(heartbeatAges).To(HaveKeyWithValue(atcWorker.Name, BeNumerically("~", time.Minute, 10*time.Second)))
+		})
+
+		It("leaves the persistent workers alone", func() {
+			extended, err := workerLifecycle.ExtendEphemeralExpiry(ctx, "default-worker", 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(extended).To(BeFalse())
+		})
+
+		It("leaves the workers which are not running alone", func() {
+			err := workerLifecycle.DrainWorker(ctx, atcWorker.Name)
+			Expect(err).ToNot(HaveOccurred())
+
+			extended, err := workerLifecycle.ExtendEphemeralExpiry(ctx, atcWorker.Name, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(extended).To(BeFalse())
+		})
+
+		It("leaves the workers whose heartbeat never expires alone", func() {
+			_, err := workerFactory.SaveWorker(atcWorker, 0)
+			Expect(err).ToNot(HaveOccurred())
+
+			extended, err := workerLifecycle.ExtendEphemeralExpiry(ctx, atcWorker.Name, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(extended).To(BeFalse())
+		})
+	})
+
+	Describe("HeartbeatWorkers", func() {
+		BeforeEach(func() {
+			atcWorker.Ephemeral = false
+			_, err := workerFactory.SaveWorker(atcWorker, -1*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			stalledWorker := atcWorker
+			stalledWorker.Name = "stalled-worker"
+			stalledWorker.State = string(db.WorkerStateStalled)
+			_, err = workerFactory.SaveWorker(stalledWorker, 0)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("pushes back the expiry of the named running workers", func() {
+			heartbeatWorkers, err := workerLifecycle.HeartbeatWorkers(ctx, []string{atcWorker.Name, "default-worker"}, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(heartbeatWorkers).To(ConsistOf(atcWorker.Name, "default-worker"))
+
+			heartbeatAges, err := workerLifecycle.GetWorkerHeartbeatAges(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(heartbeatAges).To(HaveKeyWithValue(atcWorker.Name, BeNumerically("~", 5*time.Minute, 10*time.Second)))
+			Expect(heartbeatAges).To(HaveKeyWithValue("default-worker", BeNumerically("~", 5*time.Minute, 10*time.Second)))
+			Expect(heartbeatAges).ToNot(HaveKey("other-worker"))
+		})
+
+		It("leaves out the workers which are not running or do not exist", func() {
+			heartbeatWorkers, err := workerLifecycle.HeartbeatWorkers(ctx, []string{atcWorker.Name, "stalled-worker", "bogus-worker"}, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(heartbeatWorkers).To(Equal([]string{atcWorker.Name}))
+
+			stateByName, err := workerLifecycle.GetWorkerStateByName(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stateByName).To(HaveKeyWithValue("stalled-worker", db.WorkerStateStalled))
+		})
+	})
+
+	Describe("TransitionWorker", func() {
+		BeforeEach(func() {
+			_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("when the worker is still in the expected state", func() {
+			It("moves the worker and reports it", func() {
+				count, err := workerLifecycle.TransitionWorker(ctx, atcWorker.Name, db.WorkerStateRunning, db.WorkerStateLanding)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(count).To(Equal(1))
+
+				stateByName, err := workerLifecycle.GetWorkerStateByName(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(stateByName).To(HaveKeyWithValue(atcWorker.Name, db.WorkerStateLanding))
+			})
+		})
+
+		Context("when the worker has already been moved", func() {
+			BeforeEach(func() {
+				count, err := workerLifecycle.TransitionWorker(ctx, atcWorker.Name, db.WorkerStateRunning, db.WorkerStateRetiring)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(count).To(Equal(1))
+			})
+
+			It("leaves the worker alone and reports that nothing changed", func() {
+				count, err := workerLifecycle.TransitionWorker(ctx, atcWorker.Name, db.WorkerStateRunning, db.WorkerStateLanding)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(count).To(BeZero())
+
+				stateByName, err := workerLifecycle.GetWorkerStateByName(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(stateByName).To(HaveKeyWithValue(atcWorker.Name, db.WorkerStateRetiring))
+			})
+		})
+
+		Context("when the worker does not exist", func() {
+			It("reports that nothing changed", func() {
+				count, err := workerLifecycle.TransitionWorker(ctx, "bogus-worker", db.WorkerStateRunning, db.WorkerStateLanding)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(count).To(BeZero())
+			})
+		})
+
+		Context("when the transition is not allowed", func() {
+			It("returns ErrInvalidWorkerTransition", func() {
+				_, err := workerLifecycle.TransitionWorker(ctx, atcWorker.Name, db.WorkerStateRetiring, db.WorkerStateRunning)
+				Expect(err).To(MatchError(db.ErrInvalidWorkerTransition))
+			})
+		})
+	})
+
+	Describe("SetWorkerStates", func() {
+		BeforeEach(func() {
+			_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			landedWorker := atcWorker
+			landedWorker.Name = "landed-worker"
+			landedWorker.State = string(db.WorkerStateLanded)
+			_, err = workerFactory.SaveWorker(landedWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("moves the named workers and returns the ones it moved", func() {
+			retiredWorkers, err := workerLifecycle.SetWorkerStates(ctx, []string{atcWorker.Name, "default-worker", "landed-worker", "bogus-worker"}, db.WorkerStateRetiring)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(retiredWorkers).To(ConsistOf(atcWorker.Name, "default-worker"))
+
+			stateByName, err := workerLifecycle.GetWorkerStateByName(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stateByName).To(Equal(map[string]db.WorkerState{
+				"default-worker": db.WorkerStateRetiring,
+				"other-worker":   db.WorkerStateRunning,
+				"some-name":      db.WorkerStateRetiring,
+				"landed-worker":  db.WorkerStateLanded,
+			}))
+		})
+
+		It("does nothing without any names", func() {
+			retiredWorkers, err := workerLifecycle.SetWorkerStates(ctx, nil, db.WorkerStateRetiring)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(retiredWorkers).To(BeEmpty())
+		})
+
+		It("does not return the workers already in the state", func() {
+			_, err := workerLifecycle.SetWorkerStates(ctx, []string{atcWorker.Name}, db.WorkerStateLanding)
+			Expect(err).ToNot(HaveOccurred())
+
+			landingWorkers, err := workerLifecycle.SetWorkerStates(ctx, []string{atcWorker.Name}, db.WorkerStateLanding)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(landingWorkers).To(BeEmpty())
+		})
+
+		It("does not move landed workers without an address back to running", func() {
+			_, err := workerLifecycle.SetWorkerStates(ctx, []string{atcWorker.Name}, db.WorkerStateLanding)
+			Expect(err).ToNot(HaveOccurred())
+
+			landedWorkers, err := workerLifecycle.SetWorkerStates(ctx, []string{atcWorker.Name}, db.WorkerStateLanded)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(landedWorkers).To(ConsistOf(atcWorker.Name))
+
+			runningWorkers, err := workerLifecycle.SetWorkerStates(ctx, []string{atcWorker.Name, "landed-worker"}, db.WorkerStateRunning)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(runningWorkers).To(ConsistOf("landed-worker"))
+
+			stateByName, err := workerLifecycle.GetWorkerStateByName(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stateByName).To(HaveKeyWithValue(atcWorker.Name, db.WorkerStateLanded))
+			Expect(stateByName).To(HaveKeyWithValue("landed-worker", db.WorkerStateRunning))
+		})
+	})
+
+	Describe("FindInconsistentWorkers", func() {
+		BeforeEach(func() {
+			landedWorker := atcWorker
+			landedWorker.Name = "landed-worker"
+			landedWorker.State = string(db.WorkerStateLanded)
+			_, err := workerFactory.SaveWorker(landedWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns every worker violating an invariant", func() {
+			inconsistencies, err := workerLifecycle.FindInconsistentWorkers(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(inconsistencies).To(ConsistOf(
+				db.WorkerInconsistency{
+					Name:      "default-worker",
+					State:     db.WorkerStateRunning,
+					Violation: "running worker has no heartbeat expiry",
+				},
+				db.WorkerInconsistency{
+					Name:      "other-worker",
+					State:     db.WorkerStateRunning,
+					Violation: "running worker has no heartbeat expiry",
+				},
+				db.WorkerInconsistency{
+					Name:      "landed-worker",
+					State:     db.WorkerStateLanded,
+					Violation: "landed worker still has an address",
+				},
+			))
+		})
+
+		It("does not report workers which have been landed", func() {
+			atcWorker.State = string(db.WorkerStateLanding)
+			_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = workerLifecycle.LandFinishedLandingWorkers(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			inconsistencies, err := workerLifecycle.FindInconsistentWorkers(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(inconsistencies).ToNot(ContainElement(HaveField("Name", atcWorker.Name)))
+		})
+	})
+
+	Describe("GetWorkersState", func() {
+
+		JustBeforeEach(func() {
+			atcWorker.State = string(db.WorkerStateStalled)
+			_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("gets the workers' state", func() {
+			countByState, err := workerLifecycle.GetWorkerStateByName(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			expectedState := map[string]db.WorkerState{
+				"default-worker": db.WorkerStateRunning,
+				"other-worker":   db.WorkerStateRunning,
+				"some-name":      db.WorkerStateStalled,
+			}
+			Expect(countByState).To(Equal(expectedState))
+		})
+
+	})
+
+	Describe("StreamWorkerStates", func() {
+		BeforeEach(func() {
+			atcWorker.State = string(db.WorkerStateStalled)
+			_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("calls back with the state of every worker", func() {
+			stateByName := map[string]db.WorkerState{}
+			err := workerLifecycle.StreamWorkerStates(ctx, func(name string, state db.WorkerState) error {
+				stateByName[name] = state
+				return nil
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stateByName).To(Equal(map[string]db.WorkerState{
+				"default-worker": db.WorkerStateRunning,
+				"other-worker":   db.WorkerStateRunning,
+				"some-name":      db.WorkerStateStalled,
+			}))
+		})
+
+		It("stops at the first error returned by the callback", func() {
+			disaster := errors.New("disaster")
+
+			calls := 0
+			err := workerLifecycle.StreamWorkerStates(ctx, func(string, db.WorkerState) error {
+				calls++
+				return disaster
+			})
+			Expect(err).To(MatchError(disaster))
+			Expect(calls).To(Equal(1))
+		})
+	})
+
+	Describe("GetWorkerStatesWithTeam", func() {
+		BeforeEach(func() {
+			_, err := defaultTeam.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("gets the state and team of every worker", func() {
+			stateInfoByName, err := workerLifecycle.GetWorkerStatesWithTeam(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			teamName := defaultTeam.Name()
+			Expect(stateInfoByName).To(Equal(map[string]db.WorkerStateInfo{
+				"default-worker": {State: db.WorkerStateRunning},
+				"other-worker":   {State: db.WorkerStateRunning},
+				"some-name":      {State: db.WorkerStateRunning, TeamName: &teamName},
+			}))
+		})
+	})
+
+	Describe("GetWorkerStatesWithTags", func() {
+		BeforeEach(func() {
+			_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("gets the state and tags of every worker", func() {
+			stateTagsByName, err := workerLifecycle.GetWorkerStatesWithTags(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stateTagsByName).To(Equal(map[string]db.WorkerStateTags{
+				"default-worker": {State: db.WorkerStateRunning, Tags: []string{}},
+				"other-worker":   {State: db.WorkerStateRunning, Tags: []string{}},
+				"some-name":      {State: db.WorkerStateRunning, Tags: []string{"some", "tags"}},
+			}))
+		})
+	})
+
+	Describe("TotalActiveContainers", func() {
+		BeforeEach(func() {
+			_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			landedWorker := atcWorker
+			landedWorker.Name = "landed-worker"
+			landedWorker.State = string(db.WorkerStateLanded)
+			landedWorker.ActiveContainers = 10
+			_, err = workerFactory.SaveWorker(landedWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("sums the active containers of the running workers", func() {
+			total, err := workerLifecycle.TotalActiveContainers(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(total).To(Equal(140))
+		})
+
+		It("returns zero when no worker is running", func() {
+			_, err := workerLifecycle.LandAllWorkers(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			total, err := workerLifecycle.TotalActiveContainers(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(total).To(BeZero())
+		})
+	})
+
+	Describe("OldestExpiredWorkerAge", func() {
+		It("returns false when no worker has expired", func() {
+			_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, found, err := workerLifecycle.OldestExpiredWorkerAge(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("returns how long ago the most overdue worker expired", func() {
+			_, err := workerFactory.SaveWorker(atcWorker, -1*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			overdueWorker := atcWorker
+			overdueWorker.Name = "overdue-worker"
+			_, err = workerFactory.SaveWorker(overdueWorker, -5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			age, found, err := workerLifecycle.OldestExpiredWorkerAge(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(age).To(BeNumerically("~", 5*time.Minute, 10*time.Second))
+		})
+	})
+
+	Describe("EphemeralExpiryHistogram", func() {
+		BeforeEach(func() {
+			for name, ttl := range map[string]time.Duration{
+				"expired-worker":    -1 * time.Minute,
+				"soon-worker":       30 * time.Second,
+				"later-worker":      3 * time.Minute,
+				"much-later-worker": time.Hour,
+			} {
+				ephemeralWorker := atcWorker
+				ephemeralWorker.Name = name
+				_, err := workerFactory.SaveWorker(ephemeralWorker, ttl)
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			persistentWorker := atcWorker
+			persistentWorker.Name = "persistent-worker"
+			persistentWorker.Ephemeral = false
+			_, err := workerFactory.SaveWorker(persistentWorker, 30*time.Second)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("counts the ephemeral workers by the smallest bucket they expire within", func() {
+			histogram, err := workerLifecycle.EphemeralExpiryHistogram(ctx, []time.Duration{15 * time.Minute, time.Minute, 5 * time.Minute})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(histogram).To(Equal(map[time.Duration]int{
+				0:                1,
+				time.Minute:      1,
+				5 * time.Minute:  1,
+				15 * time.Minute: 0,
+			}))
+		})
+	})
+
+	Describe("GetWorkerStatesWithCapacity", func() {
+		BeforeEach(func() {
+			atcWorker.ActiveContainers = 3
+			atcWorker.ActiveVolumes = 7
+			_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = dbConn.Exec(`UPDATE workers SET active_containers = NULL, active_volumes = NULL WHERE name = 'other-worker'`)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("gets the state and active containers and volumes of every worker", func() {
+			capacityByName, err := workerLifecycle.GetWorkerStatesWithCapacity(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(capacityByName).To(HaveKeyWithValue("some-name", db.WorkerCapacity{
+				State:            db.WorkerStateRunning,
+				ActiveContainers: 3,
+				ActiveVolumes:    7,
+			}))
+		})
+
+		It("treats missing counts as zero", func() {
+			capacityByName, err := workerLifecycle.GetWorkerStatesWithCapacity(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(capacityByName).To(HaveKeyWithValue("other-worker", db.WorkerCapacity{
+				State: db.WorkerStateRunning,
+			}))
+		})
+	})
+
+	Describe("GetWorkerProcessors", func() {
+		var atcLifecycle db.WorkerLifecycle
+
+		BeforeEach(func() {
+			atcLifecycle = db.NewWorkerLifecycleWithOptions(dbConn, db.WorkerLifecycleOptions{
+				ATCID: "some-atc",
+			})
+
+			atcWorker.Ephemeral = false
+			_, err := workerFactory.SaveWorker(atcWorker, -1*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns the ATC which last changed each worker", func() {
+			_, err := atcLifecycle.StallUnresponsiveWorkers(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			otherATCLifecycle := db.NewWorkerLifecycleWithOptions(dbConn, db.WorkerLifecycleOptions{
+				ATCID: "other-atc",
+			})
+
+			_, err = otherATCLifecycle.SetWorkerStates(ctx, []string{atcWorker.Name}, db.WorkerStateRunning)
+			Expect(err).ToNot(HaveOccurred())
+
+			processorByName, err := atcLifecycle.GetWorkerProcessors(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(processorByName).To(Equal(map[string]string{
+				atcWorker.Name: "other-atc",
+			}))
+		})
+
+		It("leaves out the workers changed without an ATCID", func() {
+			_, err := workerLifecycle.StallUnresponsiveWorkers(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			processorByName, err := atcLifecycle.GetWorkerProcessors(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(processorByName).To(BeEmpty())
+		})
+	})
+
+	Describe("GetRunningWorkerAddresses", func() {
+		BeforeEach(func() {
+			_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			landedWorker := atcWorker
+			landedWorker.Name = "landed-worker"
+			landedWorker.State = string(db.WorkerStateLanded)
+			_, err = workerFactory.SaveWorker(landedWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = workerLifecycle.SetWorkerStates(ctx, []string{"default-worker"}, db.WorkerStateStalled)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns the address of every running worker", func() {
+			addrByName, err := workerLifecycle.GetRunningWorkerAddresses(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(addrByName).To(HaveKeyWithValue(atcWorker.Name, "some-garden-addr"))
+			Expect(addrByName).To(HaveKeyWithValue("other-worker", "2.3.4.5:7777"))
+			Expect(addrByName).ToNot(HaveKey("landed-worker"))
+			Expect(addrByName).ToNot(HaveKey("default-worker"))
+		})
+
+		It("leaves out the running workers without an address", func() {
+			_, err := dbConn.Exec(`UPDATE workers SET addr = NULL WHERE name = $1`, atcWorker.Name)
+			Expect(err).ToNot(HaveOccurred())
+
+			addrByName, err := workerLifecycle.GetRunningWorkerAddresses(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(addrByName).ToNot(HaveKey(atcWorker.Name))
+		})
+	})
+
+	Describe("GetWorkerStatesPaged", func() {
+		BeforeEach(func() {
+			_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("pages through the workers ordered by name", func() {
+			firstPage, err := workerLifecycle.GetWorkerStatesPaged(ctx, 2, 0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(firstPage).To(Equal(map[string]db.WorkerState{
+				"default-worker": db.WorkerStateRunning,
+				"other-worker":   db.WorkerStateRunning,
+			}))
+
+			secondPage, err := workerLifecycle.GetWorkerStatesPaged(ctx, 2, 2)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secondPage).To(Equal(map[string]db.WorkerState{
+				"some-name": db.WorkerStateRunning,
+			}))
+		})
+
+		It("rejects a negative limit or offset", func() {
+			_, err := workerLifecycle.GetWorkerStatesPaged(ctx, -1, 0)
+			Expect(err).To(HaveOccurred())
+
+			_, err = workerLifecycle.GetWorkerStatesPaged(ctx, 1, -1)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("FindWorkersWithHeadroom", func() {
+		BeforeEach(func() {
+			atcWorker.ActiveContainers = 2
+			_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = dbConn.Exec(`UPDATE workers SET max_containers = 10 WHERE name = $1`, atcWorker.Name)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = dbConn.Exec(`UPDATE workers SET max_containers = 5, active_containers = 4 WHERE name = 'default-worker'`)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns the running workers with enough room, the most room first", func() {
+			headrooms, err := workerLifecycle.FindWorkersWithHeadroom(ctx, 1, 0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(headrooms).To(Equal([]db.WorkerHeadroom{
+				{Name: "other-worker", Free: db.UnboundedWorkerContainers, Total: db.UnboundedWorkerContainers},
+				{Name: atcWorker.Name, Free: 8, Total: 10},
+				{Name: "default-worker", Free: 1, Total: 5},
+			}))
+		})
+
+		It("leaves out the workers with less room than minFree", func() {
+			headrooms, err := workerLifecycle.FindWorkersWithHeadroom(ctx, 2, 0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(headrooms).To(HaveLen(2))
+			Expect(headrooms[1].Name).To(Equal(atcWorker.Name))
+		})
+
+		It("returns at most limit workers", func() {
+			headrooms, err := workerLifecycle.FindWorkersWithHeadroom(ctx, 1, 1)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(headrooms).To(HaveLen(1))
+			Expect(headrooms[0].Name).To(Equal("other-worker"))
+		})
+
+		It("leaves out the workers which are not running", func() {
+			_, err := workerLifecycle.SetWorkerStates(ctx, []string{"other-worker"}, db.WorkerStateLanding)
+			Expect(err).ToNot(HaveOccurred())
+
+			headrooms, err := workerLifecycle.FindWorkersWithHeadroom(ctx, 1, 0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(headrooms).To(HaveLen(2))
+			Expect(headrooms[0].Name).To(Equal(atcWorker.Name))
+		})
+	})
+
+	Describe("GetWorkerHeartbeatAges", func() {
+		BeforeEach(func() {
+			_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			expiredWorker := atcWorker
+			expiredWorker.Name = "expired-worker"
+			_, err = workerFactory.SaveWorker(expiredWorker, -1*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			stalledWorker := atcWorker
+			stalledWorker.Name = "stalled-worker"
+			stalledWorker.State = string(db.WorkerStateStalled)
+			_, err = workerFactory.SaveWorker(stalledWorker, 0)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns how long until each worker's heartbeat expires", func() {
+			heartbeatAges, err := workerLifecycle.GetWorkerHeartbeatAges(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(heartbeatAges).To(HaveKeyWithValue("some-name", BeNumerically("~", 5*time.Minute, 10*time.Second)))
+			Expect(heartbeatAges).To(HaveKeyWithValue("expired-worker", BeNumerically("~", -1*time.Minute, 10*time.Second)))
+		})
+
+		It("leaves out the workers without a heartbeat", func() {
+			heartbeatAges, err := workerLifecycle.GetWorkerHeartbeatAges(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(heartbeatAges).ToNot(HaveKey("stalled-worker"))
+		})
+	})
+
+	Describe("GetWorkerUptimes", func() {
+		BeforeEach(func() {
+			atcWorker.StartTime = time.Now().Add(-time.Hour).Unix()
+			_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = dbConn.Exec(`UPDATE workers SET start_time = NULL WHERE name = 'other-worker'`)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns how long ago each worker registered", func() {
+			uptimes, err := workerLifecycle.GetWorkerUptimes(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(uptimes).To(HaveKeyWithValue(atcWorker.Name, BeNumerically("~", time.Hour, 10*time.Second)))
+			Expect(uptimes).To(HaveKey("default-worker"))
+		})
+
+		It("leaves out the workers without a start time", func() {
+			uptimes, err := workerLifecycle.GetWorkerUptimes(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(uptimes).ToNot(HaveKey("other-worker"))
+		})
+
+		It("leaves out the deleted workers", func() {
+			_, err := dbConn.Exec(`UPDATE workers SET state = 'deleted' WHERE name = $1`, atcWorker.Name)
+			Expect(err).ToNot(HaveOccurred())
+
+			uptimes, err := workerLifecycle.GetWorkerUptimes(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(uptimes).ToNot(HaveKey(atcWorker.Name))
+		})
+	})
+
+	Describe("CountWorkersByState", func() {
+		JustBeforeEach(func() {
+			atcWorker.State = string(db.WorkerStateStalled)
+			_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("counts the workers in every state, including empty ones", func() {
+			countByState, err := workerLifecycle.CountWorkersByState(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(countByState).To(Equal(map[db.WorkerState]int{
+				db.WorkerStateRunning:     2,
+				db.WorkerStateStalled:     1,
+				db.WorkerStateLanding:     0,
+				db.WorkerStateLanded:      0,
+				db.WorkerStateDraining:    0,
+				db.WorkerStateRetiring:    0,
+				db.WorkerStateDeleted:     0,
+				db.WorkerStateQuarantined: 0,
+			}))
+		})
+	})
+
+	Describe("CountWorkersByTeamAndState", func() {
+		BeforeEach(func() {
+			atcWorker.State = string(db.WorkerStateStalled)
+			_, err := defaultTeam.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("counts the workers in every state by team, with the global workers under their own key", func() {
+			countByTeamAndState, err := workerLifecycle.CountWorkersByTeamAndState(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(countByTeamAndState).To(Equal(map[string]map[db.WorkerState]int{
+				db.GlobalWorkersTeamName: {db.WorkerStateRunning: 2},
+				defaultTeam.Name():       {db.WorkerStateStalled: 1},
+			}))
+		})
+	})
+
+	Describe("WorkerAgeStatsByState", func() {
+		JustBeforeEach(func() {
+			atcWorker.State = string(db.WorkerStateStalled)
+			_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = dbConn.Exec(`UPDATE workers SET state_changed_at = NOW() - '10 minutes'::interval WHERE name = 'default-worker'`)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = dbConn.Exec(`UPDATE workers SET state_changed_at = NOW() - '30 minutes'::interval WHERE name = 'other-worker'`)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns the min, average and max time spent in each populated state", func() {
+			statsByState, err := workerLifecycle.WorkerAgeStatsByState(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(statsByState).To(HaveLen(2))
+			Expect(statsByState).ToNot(HaveKey(db.WorkerStateLanding))
+
+			running := statsByState[db.WorkerStateRunning]
+			Expect(running.Min).To(BeNumerically("~", 10*time.Minute, time.Minute))
+			Expect(running.Avg).To(BeNumerically("~", 20*time.Minute, time.Minute))
+			Expect(running.Max).To(BeNumerically("~", 30*time.Minute, time.Minute))
+
+			stalled := statsByState[db.WorkerStateStalled]
+			Expect(stalled.Min).To(BeNumerically("<", time.Minute))
+			Expect(stalled.Max).To(Equal(stalled.Min))
+		})
+	})
+
+	Describe("recording the state history", func() {
+		BeforeEach(func() {
+			_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns every transition of the worker, oldest first", func() {
+			attributedLifecycle := db.NewWorkerLifecycleWithOptions(dbConn, db.WorkerLifecycleOptions{ATCID: "some-atc"})
+
+			err := attributedLifecycle.DrainWorker(ctx, atcWorker.Name)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = attributedLifecycle.LandFinishedLandingWorkers(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = attributedLifecycle.SetWorkerStates(ctx, []string{atcWorker.Name}, db.WorkerStateRunning)
+			Expect(err).ToNot(HaveOccurred())
+
+			history, err := workerLifecycle.GetWorkerStateHistory(ctx, atcWorker.Name)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(history).To(HaveLen(4))
+
+			Expect(history[0].From).To(BeEmpty())
+			Expect(history[0].To).To(Equal(db.WorkerStateRunning))
+			Expect(history[0].ProcessedBy).To(BeEmpty())
+
+			Expect(history[1].From).To(Equal(db.WorkerStateRunning))
+			Expect(history[1].To).To(Equal(db.WorkerStateDraining))
+			Expect(history[1].ProcessedBy).To(Equal("some-atc"))
+
+			Expect(history[2].From).To(Equal(db.WorkerStateDraining))
+			Expect(history[2].To).To(Equal(db.WorkerStateLanded))
+
+			Expect(history[3].From).To(Equal(db.WorkerStateLanded))
+			Expect(history[3].To).To(Equal(db.WorkerStateRunning))
+
+			for i := 1; i < len(history); i++ {
+				Expect(history[i].At).ToNot(BeTemporally("<", history[i-1].At))
+			}
+		})
+
+		It("does not record updates which leave the state alone", func() {
+			_, err := workerLifecycle.HeartbeatWorker(ctx, atcWorker.Name, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			history, err := workerLifecycle.GetWorkerStateHistory(ctx, atcWorker.Name)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(history).To(HaveLen(1))
+		})
+
+		It("keeps the history of a deleted worker", func() {
+			_, err := workerFactory.SaveWorker(atcWorker, -1*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = workerLifecycle.DeleteUnresponsiveEphemeralWorkers(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			history, err := workerLifecycle.GetWorkerStateHistory(ctx, atcWorker.Name)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(history).To(HaveLen(2))
+			Expect(history[1].From).To(Equal(db.WorkerStateRunning))
+			Expect(history[1].To).To(BeEmpty())
+		})
+
+		It("returns an empty history for an unknown worker", func() {
+			history, err := workerLifecycle.GetWorkerStateHistory(ctx, "bogus-worker")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(history).ToNot(BeNil())
+			Expect(history).To(BeEmpty())
+		})
+
+		Describe("PruneWorkerStateHistory", func() {
+			BeforeEach(func() {
+				err := workerLifecycle.DrainWorker(ctx, atcWorker.Name)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = dbConn.Exec(`UPDATE worker_state_transitions SET transitioned_at = NOW() - '2 days'::interval WHERE worker_name = $1 AND from_state IS NULL`, atcWorker.Name)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("deletes the transitions older than the retention", func() {
+				pruned, err := workerLifecycle.PruneWorkerStateHistory(ctx, 24*time.Hour)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pruned).To(Equal(1))
+
+				history, err := workerLifecycle.GetWorkerStateHistory(ctx, atcWorker.Name)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(history).To(HaveLen(1))
+				Expect(history[0].To).To(Equal(db.WorkerStateDraining))
+			})
+
+			It("deletes more transitions than fit in one batch", func() {
+				_, err := dbConn.Exec(`INSERT INTO worker_state_transitions (worker_name, to_state, transitioned_at) SELECT 'old-worker', 'running', NOW() - '2 days'::interval FROM generate_series(1, 2500)`)
+				Expect(err).ToNot(HaveOccurred())
+
+				pruned, err := workerLifecycle.PruneWorkerStateHistory(ctx, 24*time.Hour)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pruned).To(Equal(2501))
+
+				history, err := workerLifecycle.GetWorkerStateHistory(ctx, "old-worker")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(history).To(BeEmpty())
+			})
+
+			It("only counts the transitions in dry-run mode", func() {
+				dryRunLifecycle := db.NewWorkerLifecycleWithOptions(dbConn, db.WorkerLifecycleOptions{DryRun: true})
+
+				pruned, err := dryRunLifecycle.PruneWorkerStateHistory(ctx, 24*time.Hour)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pruned).To(Equal(1))
+
+				history, err := workerLifecycle.GetWorkerStateHistory(ctx, atcWorker.Name)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(history).To(HaveLen(2))
+			})
+		})
+	})
+
+	Describe("notifying state changes", func() {
+		BeforeEach(func() {
+			_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("sends a NOTIFY for every transition when enabled", func() {
+			pool, err := pgxpool.New(ctx, postgresRunner.DataSourceName())
+			Expect(err).ToNot(HaveOccurred())
+
+			listener := db.NewPgxListener(pool, logger)
+			defer listener.Close()
+
+			err = listener.Listen(db.WorkerLifecycleNotifyChannel)
+			Expect(err).ToNot(HaveOccurred())
+
+			notifyingLifecycle := db.NewWorkerLifecycleWithOptions(dbConn, db.WorkerLifecycleOptions{
+				NotifyStateChanges: true,
+			})
+
+			err = notifyingLifecycle.DrainWorker(ctx, atcWorker.Name)
+			Expect(err).ToNot(HaveOccurred())
+
+			var notification *pgconn.Notification
+			Eventually(listener.NotificationChannel()).WithTimeout(time.Second).Should(Receive(&notification))
+			Expect(notification.Channel).To(Equal(db.WorkerLifecycleNotifyChannel))
+
+			var payload db.WorkerStateChangeNotification
+			err = json.Unmarshal([]byte(notification.Payload), &payload)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(payload).To(Equal(db.WorkerStateChangeNotification{
+				Name:   atcWorker.Name,
+				State:  db.WorkerStateDraining,
+				Reason: db.WorkerTransitionReasonDrain,
+			}))
+		})
+
+		It("reports a worker deleted outright as deleted", func() {
+			atcWorker.State = string(db.WorkerStateRetiring)
+			_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			pool, err := pgxpool.New(ctx, postgresRunner.DataSourceName())
+			Expect(err).ToNot(HaveOccurred())
+
+			listener := db.NewPgxListener(pool, logger)
+			defer listener.Close()
+
+			err = listener.Listen(db.WorkerLifecycleNotifyChannel)
+			Expect(err).ToNot(HaveOccurred())
+
+			notifyingLifecycle := db.NewWorkerLifecycleWithOptions(dbConn, db.WorkerLifecycleOptions{
+				NotifyStateChanges: true,
+			})
+
+			deletedWorkers, err := notifyingLifecycle.DeleteFinishedRetiringWorkers(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deletedWorkers).To(ConsistOf(atcWorker.Name))
+
+			var notification *pgconn.Notification
+			Eventually(listener.NotificationChannel()).WithTimeout(time.Second).Should(Receive(&notification))
+
+			var payload db.WorkerStateChangeNotification
+			err = json.Unmarshal([]byte(notification.Payload), &payload)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(payload).To(Equal(db.WorkerStateChangeNotification{
+				Name:   atcWorker.Name,
+				State:  db.WorkerStateDeleted,
+				Reason: db.WorkerTransitionReasonRetireComplete,
+			}))
+		})
+
+		It("still tells the observer about the transitions", func() {
+			fakeObserver := new(dbfakes.FakeLifecycleObserver)
+			notifyingLifecycle := db.NewWorkerLifecycleWithOptions(dbConn, db.WorkerLifecycleOptions{
+				Observer:           fakeObserver,
+				NotifyStateChanges: true,
+			})
+
+			err := notifyingLifecycle.DrainWorker(ctx, atcWorker.Name)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeObserver.WorkerStateChangedCallCount()).To(Equal(1))
+		})
+
+		It("sends no NOTIFY by default", func() {
+			fakeConn := new(dbfakes.FakeDbConn)
+			fakeConn.ExecContextReturns(driver.RowsAffected(1), nil)
+
+			err := db.NewWorkerLifecycle(fakeConn, nil).DrainWorker(ctx, atcWorker.Name)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeConn.ExecContextCallCount()).To(Equal(1))
+		})
+	})
+
+	Describe("observing state changes", func() {
+		var (
+			fakeObserver      *dbfakes.FakeLifecycleObserver
+			observedLifecycle db.WorkerLifecycle
+		)
+
+		BeforeEach(func() {
+			fakeObserver = new(dbfakes.FakeLifecycleObserver)
+			observedLifecycle = db.NewWorkerLifecycle(dbConn, fakeObserver)
+		})
+
+		Context("when a worker is stalled", func() {
+			BeforeEach(func() {
+				atcWorker.Ephemeral = false
+				_, err := workerFactory.SaveWorker(atcWorker, -1*time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("notifies the observer", func() {
+				_, err := observedLifecycle.StallUnresponsiveWorkers(ctx)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeObserver.WorkerStateChangedCallCount()).To(Equal(1))
+				name, from, to, reason := fakeObserver.WorkerStateChangedArgsForCall(0)
+				Expect(name).To(Equal("some-name"))
+				Expect(from).To(Equal(db.WorkerStateRunning))
+				Expect(to).To(Equal(db.WorkerStateStalled))
+				Expect(reason).To(Equal("heartbeat-expired"))
+			})
+		})
+
+		Context("when a worker is landed", func() {
+			BeforeEach(func() {
+				atcWorker.State = string(db.WorkerStateLanding)
+				_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("notifies the observer", func() {
+				_, err := observedLifecycle.LandFinishedLandingWorkers(ctx)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeObserver.WorkerStateChangedCallCount()).To(Equal(1))
+				name, from, to, reason := fakeObserver.WorkerStateChangedArgsForCall(0)
+				Expect(name).To(Equal("some-name"))
+				Expect(from).To(Equal(db.WorkerStateLanding))
+				Expect(to).To(Equal(db.WorkerStateLanded))
+				Expect(reason).To(Equal("landing-complete"))
+			})
+		})
+
+		Context("when every worker is landed twice", func() {
+			It("only notifies the observer the first time", func() {
+				_, err := observedLifecycle.LandAllWorkers(ctx)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeObserver.WorkerStateChangedCallCount()).To(Equal(2))
+
+				_, err = observedLifecycle.LandAllWorkers(ctx)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeObserver.WorkerStateChangedCallCount()).To(Equal(2))
+			})
+		})
+
+		Context("when nothing changes", func() {
+			It("does not notify the observer", func() {
+				_, err := observedLifecycle.DeleteFinishedRetiringWorkers(ctx)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeObserver.WorkerStateChangedCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("dry run", func() {
+		var dryRunLifecycle db.WorkerLifecycle
+
+		BeforeEach(func() {
+			dryRunLifecycle = db.NewWorkerLifecycleWithOptions(dbConn, db.WorkerLifecycleOptions{
+				DryRun: true,
+			})
+		})
+
+		Context("when an ephemeral worker has not heartbeated recently", func() {
+			BeforeEach(func() {
+				_, err := workerFactory.SaveWorker(atcWorker, -1*time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns the workers that would be deleted without deleting them", func() {
+				deletedWorkers, err := dryRunLifecycle.DeleteUnresponsiveEphemeralWorkers(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deletedWorkers).To(ConsistOf("some-name"))
+
+				_, found, err := workerFactory.GetWorker("some-name")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			It("returns the workers that would be stalled without stalling them", func() {
+				stalledWorkers, err := dryRunLifecycle.StallUnresponsiveWorkers(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(stalledWorkers).To(ConsistOf("some-name"))
+
+				foundWorker, found, err := workerFactory.GetWorker("some-name")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(foundWorker.State()).To(Equal(db.WorkerStateRunning))
+			})
+		})
+
+		Context("when a worker has finished landing", func() {
+			BeforeEach(func() {
+				atcWorker.State = string(db.WorkerStateLanding)
+				_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns the workers that would be landed without landing them", func() {
+				landedWorkers, err := dryRunLifecycle.LandFinishedLandingWorkers(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(landedWorkers).To(ConsistOf("some-name"))
+
+				foundWorker, found, err := workerFactory.GetWorker("some-name")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(foundWorker.State()).To(Equal(db.WorkerStateLanding))
+			})
+		})
+
+		Context("when a worker has finished retiring", func() {
+			BeforeEach(func() {
+				atcWorker.State = string(db.WorkerStateRetiring)
+				_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns the workers that would be deleted without deleting them", func() {
+				retiredWorkers, err := dryRunLifecycle.DeleteFinishedRetiringWorkers(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(retiredWorkers).To(ConsistOf("some-name"))
+
+				_, found, err := workerFactory.GetWorker("some-name")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("GetWorkersInState", func() {
+		BeforeEach(func() {
+			for _, name := range []string{"landing-b", "landing-a"} {
+				landingWorker := atcWorker
+				landingWorker.Name = name
+				landingWorker.State = string(db.WorkerStateLanding)
+				_, err := workerFactory.SaveWorker(landingWorker, 5*time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+			}
+		})
+
+		It("returns the workers in the state ordered by name", func() {
+			workerNames, err := workerLifecycle.GetWorkersInState(ctx, db.WorkerStateLanding)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(workerNames).To(Equal([]string{"landing-a", "landing-b"}))
+		})
+
+		It("returns no workers when none are in the state", func() {
+			workerNames, err := workerLifecycle.GetWorkersInState(ctx, db.WorkerStateRetiring)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(workerNames).To(BeEmpty())
+		})
+	})
+
+	Describe("FindUnhealthyBaggageclaimWorkers", func() {
+		BeforeEach(func() {
+			_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = workerLifecycle.MarkBaggageclaimHealth(ctx, atcWorker.Name, false)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = workerLifecycle.MarkBaggageclaimHealth(ctx, "default-worker", true)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns the running workers marked unhealthy", func() {
+			workerNames, err := workerLifecycle.FindUnhealthyBaggageclaimWorkers(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(workerNames).To(Equal([]string{atcWorker.Name}))
+		})
+
+		It("stops returning a worker once it is marked healthy again", func() {
+			err := workerLifecycle.MarkBaggageclaimHealth(ctx, atcWorker.Name, true)
+			Expect(err).ToNot(HaveOccurred())
+
+			workerNames, err := workerLifecycle.FindUnhealthyBaggageclaimWorkers(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(workerNames).To(BeEmpty())
+		})
+
+		It("ignores unhealthy workers which are not running", func() {
+			_, err := workerLifecycle.SetWorkerStates(ctx, []string{atcWorker.Name}, db.WorkerStateLanding)
+			Expect(err).ToNot(HaveOccurred())
+
+			workerNames, err := workerLifecycle.FindUnhealthyBaggageclaimWorkers(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(workerNames).To(BeEmpty())
+		})
+
+		It("returns ErrWorkerNotPresent when marking a worker which does not exist", func() {
+			err := workerLifecycle.MarkBaggageclaimHealth(ctx, "bogus-worker", false)
+			Expect(err).To(Equal(db.ErrWorkerNotPresent))
+		})
+	})
+
+	Describe("GetWorkerStateByNameForTeam", func() {
+		var otherTeam db.Team
+
+		BeforeEach(func() {
+			var err error
+			otherTeam, err = teamFactory.CreateTeam(atc.Team{Name: "other-team"})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = defaultTeam.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			otherTeamWorker := atcWorker
+			otherTeamWorker.Name = "other-team-worker"
+			otherTeamWorker.State = string(db.WorkerStateStalled)
+			_, err = otherTeam.SaveWorker(otherTeamWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("gets the state of the team's workers and the global workers", func() {
+			stateByName, err := workerLifecycle.GetWorkerStateByNameForTeam(ctx, defaultTeam.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stateByName).To(Equal(map[string]db.WorkerState{
+				"default-worker": db.WorkerStateRunning,
+				"other-worker":   db.WorkerStateRunning,
+				"some-name":      db.WorkerStateRunning,
+			}))
+		})
+
+		It("does not include the workers of other teams", func() {
+			stateByName, err := workerLifecycle.GetWorkerStateByNameForTeam(ctx, otherTeam.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stateByName).To(Equal(map[string]db.WorkerState{
+				"default-worker":    db.WorkerStateRunning,
+				"other-worker":      db.WorkerStateRunning,
+				"other-team-worker": db.WorkerStateStalled,
+			}))
+		})
+	})
+
+	Describe("counting affected workers", func() {
+		Context("when there are unresponsive ephemeral workers", func() {
+			BeforeEach(func() {
+				_, err := workerFactory.SaveWorker(atcWorker, -1*time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("deletes them and returns how many were deleted", func() {
+				count, err := workerLifecycle.DeleteUnresponsiveEphemeralWorkersCount(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(count).To(Equal(1))
+
+				_, found, err := workerFactory.GetWorker(atcWorker.Name)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when there are unresponsive workers", func() {
+			BeforeEach(func() {
+				atcWorker.Ephemeral = false
+				_, err := workerFactory.SaveWorker(atcWorker, -1*time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("stalls them and returns how many were stalled", func() {
+				count, err := workerLifecycle.StallUnresponsiveWorkersCount(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(count).To(Equal(1))
+
+				count, err = workerLifecycle.StallUnresponsiveWorkersCount(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(count).To(BeZero())
+			})
+
+			It("deletes them once stalled for longer than the timeout", func() {
+				_, err := workerLifecycle.StallUnresponsiveWorkers(ctx)
+				Expect(err).ToNot(HaveOccurred())
+
+				count, err := workerLifecycle.DeleteStalledWorkersCount(ctx, -1*time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(count).To(Equal(1))
+			})
+		})
+
+		Context("when there are finished landing workers", func() {
+			BeforeEach(func() {
+				atcWorker.State = string(db.WorkerStateLanding)
+				_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("lands them and returns how many were landed", func() {
+				count, err := workerLifecycle.LandFinishedLandingWorkersCount(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(count).To(Equal(1))
+			})
+		})
+
+		Context("when there are finished retiring workers", func() {
+			BeforeEach(func() {
+				atcWorker.State = string(db.WorkerStateRetiring)
+				_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("deletes them and returns how many were deleted", func() {
+				count, err := workerLifecycle.DeleteFinishedRetiringWorkersCount(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(count).To(Equal(1))
+			})
+		})
+	})
+
+	Describe("excluding workers with uninterruptible builds", func() {
+		var (
+			fakeConn      *dbfakes.FakeDbConn
+			fakeLifecycle db.WorkerLifecycle
+			disaster      error
+		)
+
+		BeforeEach(func() {
+			disaster = errors.New("disaster")
+
+			fakeConn = new(dbfakes.FakeDbConn)
+			fakeConn.QueryContextReturns(nil, disaster)
+
+			fakeLifecycle = db.NewWorkerLifecycle(fakeConn, nil)
+		})
+
+		DescribeTable("the subquery used by",
+			func(mutate func() error) {
+				Expect(mutate()).To(MatchError(disaster))
+				Expect(fakeConn.QueryContextCallCount()).To(Equal(1))
+
+				_, query, args := fakeConn.QueryContextArgsForCall(0)
+				Expect(query).To(ContainSubstring("NOT IN (SELECT DISTINCT w.name FROM builds b JOIN containers c ON b.id = c.build_id JOIN workers w ON w.name = c.worker_name LEFT JOIN jobs j ON j.id = b.job_id"))
+				Expect(query).To(MatchRegexp(`WHERE b\.completed = \$\d+ AND \(j\.interruptible = \$\d+ OR b\.job_id IS NULL\)\)`))
+				Expect(query).ToNot(ContainSubstring("?"))
+				Expect(args).To(ContainElements(false, false))
+			},
+			Entry("LandFinishedLandingWorkers", func() error {
+				_, err := fakeLifecycle.LandFinishedLandingWorkers(ctx)
+				return err
+			}),
+			Entry("DeleteFinishedRetiringWorkers", func() error {
+				_, err := fakeLifecycle.DeleteFinishedRetiringWorkers(ctx)
+				return err
+			}),
+		)
+	})
+
+	Describe("FindWorkersBlockedByDeletedJobs", func() {
+		var pipeline db.Pipeline
+
+		BeforeEach(func() {
+			atcWorker.State = string(db.WorkerStateLanding)
+			dbWorker, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			var created bool
+			pipeline, created, err = defaultTeam.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, atc.Config{
+				Jobs: atc.JobConfigs{
+					{
+						Name:          "some-job",
+						Interruptible: false,
+					},
+				},
+			}, db.ConfigVersion(0), false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(created).To(BeTrue())
+
+			job, found, err := pipeline.Job("some-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			dbBuild, err := job.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = dbWorker.CreateContainer(db.NewBuildStepContainerOwner(dbBuild.ID(), atc.PlanID("4"), defaultTeam.ID()), db.ContainerMetadata{})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("does not find the worker while the job is in its pipeline", func() {
+			workerNames, err := workerLifecycle.FindWorkersBlockedByDeletedJobs(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(workerNames).To(BeEmpty())
+
+			landedWorkers, err := workerLifecycle.LandFinishedLandingWorkers(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(landedWorkers).To(BeEmpty())
+		})
+
+		Context("when the job has been removed from its pipeline", func() {
+			BeforeEach(func() {
+				_, _, err := defaultTeam.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, atc.Config{
+					Jobs: atc.JobConfigs{
+						{
+							Name: "other-job",
+						},
+					},
+				}, pipeline.ConfigVersion(), false)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("finds the worker", func() {
+				workerNames, err := workerLifecycle.FindWorkersBlockedByDeletedJobs(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(workerNames).To(Equal([]string{atcWorker.Name}))
+			})
+
+			It("is landed by LandFinishedLandingWorkers", func() {
+				landedWorkers, err := workerLifecycle.LandFinishedLandingWorkers(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(landedWorkers).To(ConsistOf(atcWorker.Name))
+
+				workerNames, err := workerLifecycle.FindWorkersBlockedByDeletedJobs(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(workerNames).To(BeEmpty())
+			})
+
+			It("no longer reports the build as blocking the worker", func() {
+				blockingBuilds, err := workerLifecycle.GetBuildsBlockingWorkerLanding(ctx, atcWorker.Name)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(blockingBuilds).To(BeEmpty())
+			})
+
+			Context("when the worker is retiring", func() {
+				BeforeEach(func() {
+					atcWorker.State = string(db.WorkerStateRetiring)
+					_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("is still kept from retiring by the build", func() {
+					deletedWorkers, err := workerLifecycle.DeleteFinishedRetiringWorkers(ctx)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(deletedWorkers).To(BeEmpty())
+
+					count, err := workerLifecycle.DeleteFinishedRetiringWorkersCount(ctx)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(count).To(BeZero())
+
+					_, retired, err := workerLifecycle.ProcessFinishedWorkers(ctx)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(retired).To(BeEmpty())
+
+					_, found, err := workerFactory.GetWorker(atcWorker.Name)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+				})
+			})
+
+			It("is landed by ProcessFinishedWorkers", func() {
+				landed, _, err := workerLifecycle.ProcessFinishedWorkers(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(landed).To(ConsistOf(atcWorker.Name))
+			})
+		})
+	})
+
+	Describe("FindNeverLandedWorkers", func() {
+		var softDeletingLifecycle db.WorkerLifecycle
+
+		BeforeEach(func() {
+			softDeletingLifecycle = db.NewWorkerLifecycleWithOptions(dbConn, db.WorkerLifecycleOptions{
+				SoftDeleteEphemeralWorkers: true,
+			})
+
+			_, err := workerFactory.SaveWorker(atcWorker, -1*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			landingWorker := atcWorker
+			landingWorker.Name = "landing-worker"
+			landingWorker.State = string(db.WorkerStateLanding)
+			_, err = workerFactory.SaveWorker(landingWorker, -1*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			landedWorkers, err := softDeletingLifecycle.LandFinishedLandingWorkers(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(landedWorkers).To(ConsistOf("landing-worker"))
+
+			deletedWorkers, err := softDeletingLifecycle.DeleteUnresponsiveEphemeralWorkers(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deletedWorkers).To(ConsistOf("some-name", "landing-worker"))
+		})
+
+		It("returns the deleted workers which never finished landing", func() {
+			workerNames, err := softDeletingLifecycle.FindNeverLandedWorkers(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(workerNames).To(Equal([]string{"some-name"}))
+		})
+
+		It("leaves out the workers which are not deleted", func() {
+			Expect(softDeletingLifecycle.FindNeverLandedWorkers(ctx)).ToNot(ContainElement("default-worker"))
+		})
+
+		It("forgets that a worker landed once it registers again", func() {
+			landingWorker := atcWorker
+			landingWorker.Name = "landing-worker"
+			_, err := workerFactory.SaveWorker(landingWorker, -1*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = softDeletingLifecycle.DeleteUnresponsiveEphemeralWorkers(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			workerNames, err := softDeletingLifecycle.FindNeverLandedWorkers(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(workerNames).To(Equal([]string{"landing-worker", "some-name"}))
+		})
+	})
+
+	Describe("FindWorkersWithOutdatedResourceTypes", func() {
+		BeforeEach(func() {
+			_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns the workers advertising another version of an expected type", func() {
+			workerNames, err := workerLifecycle.FindWorkersWithOutdatedResourceTypes(ctx, map[string]string{
+				"some-resource-type": "newer-version",
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(workerNames).To(Equal([]string{atcWorker.Name}))
+		})
+
+		It("leaves out the workers advertising the expected versions", func() {
+			workerNames, err := workerLifecycle.FindWorkersWithOutdatedResourceTypes(ctx, map[string]string{
+				"some-resource-type":  "some-version",
+				"other-resource-type": "other-version",
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(workerNames).To(BeEmpty())
+		})
+
+		It("ignores the types which are not expected and the expected types which are not advertised", func() {
+			workerNames, err := workerLifecycle.FindWorkersWithOutdatedResourceTypes(ctx, map[string]string{
+				"some-resource-type":   "some-version",
+				"absent-resource-type": "some-version",
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(workerNames).To(BeEmpty())
+		})
+	})
+
+	Describe("FindDuplicateWorkerAddresses", func() {
+		BeforeEach(func() {
+			_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			renamedWorker := atcWorker
+			renamedWorker.Name = "renamed-worker"
+			_, err = workerFactory.SaveWorker(renamedWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			landedWorker := atcWorker
+			landedWorker.Name = "landed-worker"
+			landedWorker.State = string(db.WorkerStateLanded)
+			_, err = workerFactory.SaveWorker(landedWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns the workers sharing an address, leaving out landed workers", func() {
+			namesByAddr, err := workerLifecycle.FindDuplicateWorkerAddresses(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(namesByAddr).To(Equal(map[string][]string{
+				"some-garden-addr": {"renamed-worker", "some-name"},
+			}))
+		})
+
+		It("returns nothing once the duplicate is gone", func() {
+			_, err := workerLifecycle.SetWorkerStates(ctx, []string{"renamed-worker"}, db.WorkerStateLanding)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = workerLifecycle.LandFinishedLandingWorkers(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			namesByAddr, err := workerLifecycle.FindDuplicateWorkerAddresses(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(namesByAddr).To(BeEmpty())
+		})
+	})
+
+	Describe("FindStuckRetiringWorkers", func() {
+		var dbWorker db.Worker
+
+		BeforeEach(func() {
+			var err error
+			atcWorker.State = string(db.WorkerStateRetiring)
+			dbWorker, err = workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = dbConn.Exec(`UPDATE workers SET state_changed_at = NOW() - '2 hours'::INTERVAL WHERE name = $1`, atcWorker.Name)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("leaves out retiring workers without uninterruptible builds", func() {
+			workerNames, err := workerLifecycle.FindStuckRetiringWorkers(ctx, time.Hour)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(workerNames).To(BeEmpty())
+		})
+
+		Context("when the worker has a running one-off build", func() {
+			BeforeEach(func() {
+				build, err := defaultTeam.CreateOneOffBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = dbWorker.CreateContainer(db.NewBuildStepContainerOwner(build.ID(), atc.PlanID("4"), defaultTeam.ID()), db.ContainerMetadata{})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns the worker once it has been retiring for longer than stuckFor", func() {
+				workerNames, err := workerLifecycle.FindStuckRetiringWorkers(ctx, time.Hour)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(workerNames).To(Equal([]string{atcWorker.Name}))
+			})
+
+			It("leaves out the worker while it has been retiring for less than stuckFor", func() {
+				workerNames, err := workerLifecycle.FindStuckRetiringWorkers(ctx, 3*time.Hour)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(workerNames).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("GetChronicallyStalledWorkers", func() {
+		BeforeEach(func() {
+			atcWorker.Ephemeral = false
+
+			for i := 0; i < 2; i++ {
+				_, err := workerFactory.SaveWorker(atcWorker, -1*time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+
+				stalled, err := workerLifecycle.StallUnresponsiveWorkers(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(stalled).To(ContainElement(atcWorker.Name))
+			}
+		})
+
+		It("returns the workers stalled more times than the threshold", func() {
+			workerNames, err := workerLifecycle.GetChronicallyStalledWorkers(ctx, 1)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(workerNames).To(ConsistOf(atcWorker.Name))
+		})
+
+		It("leaves out the workers stalled no more than the threshold", func() {
+			workerNames, err := workerLifecycle.GetChronicallyStalledWorkers(ctx, 2)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(workerNames).To(BeEmpty())
+		})
+	})
+
+	Describe("FindWorkersMissingHeartbeats", func() {
+		BeforeEach(func() {
+			atcWorker.Ephemeral = false
+			_, err := workerFactory.SaveWorker(atcWorker, -1*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			for i := 0; i < 3; i++ {
+				_, err := workerLifecycle.StallUnresponsiveWorkers(ctx)
+				Expect(err).ToNot(HaveOccurred())
+			}
+		})
+
+		It("returns the workers which missed more than n heartbeats in a row", func() {
+			workerNames, err := workerLifecycle.FindWorkersMissingHeartbeats(ctx, 2)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(workerNames).To(ConsistOf(atcWorker.Name))
+		})
+
+		It("leaves out the workers which missed no more than n heartbeats in a row", func() {
+			workerNames, err := workerLifecycle.FindWorkersMissingHeartbeats(ctx, 3)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(workerNames).To(BeEmpty())
+		})
+
+		It("starts counting again once the worker heartbeats", func() {
+			_, err := workerFactory.HeartbeatWorker(atcWorker, -1*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			workerNames, err := workerLifecycle.FindWorkersMissingHeartbeats(ctx, 0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(workerNames).To(BeEmpty())
+
+			stalled, err := workerLifecycle.StallUnresponsiveWorkers(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stalled).To(ConsistOf(atcWorker.Name))
+
+			workerNames, err = workerLifecycle.FindWorkersMissingHeartbeats(ctx, 0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(workerNames).To(ConsistOf(atcWorker.Name))
+
+			workerNames, err = workerLifecycle.FindWorkersMissingHeartbeats(ctx, 1)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(workerNames).To(BeEmpty())
+		})
+
+		It("starts counting again once the worker registers again", func() {
+			_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			workerNames, err := workerLifecycle.FindWorkersMissingHeartbeats(ctx, 0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(workerNames).To(BeEmpty())
+		})
+
+		It("does not count the workers of another kind", func() {
+			_, err := workerLifecycle.StallUnresponsiveWorkersOfKind(ctx, db.WorkerKindEphemeral)
+			Expect(err).ToNot(HaveOccurred())
+
+			workerNames, err := workerLifecycle.FindWorkersMissingHeartbeats(ctx, 3)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(workerNames).To(BeEmpty())
+		})
+	})
+
+	Describe("FindIdleWorkers", func() {
+		var dbWorker db.Worker
+
+		BeforeEach(func() {
+			var err error
+			dbWorker, err = workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		createBuildContainer := func(build db.Build) db.CreatedContainer {
+			creatingContainer, err := dbWorker.CreateContainer(db.NewBuildStepContainerOwner(build.ID(), atc.PlanID("4"), defaultTeam.ID()), db.ContainerMetadata{})
+			Expect(err).ToNot(HaveOccurred())
+
+			createdContainer, err := creatingContainer.Created()
+			Expect(err).ToNot(HaveOccurred())
+
+			return createdContainer
+		}
+
+		It("returns the running workers without containers", func() {
+			workerNames, err := workerLifecycle.FindIdleWorkers(ctx, time.Hour)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(workerNames).To(ContainElement(atcWorker.Name))
+		})
+
+		It("leaves out workers which are not running", func() {
+			atcWorker.State = string(db.WorkerStateLanding)
+			_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			workerNames, err := workerLifecycle.FindIdleWorkers(ctx, time.Hour)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(workerNames).ToNot(ContainElement(atcWorker.Name))
+		})
+
+		Context("when the worker has a container for a running build", func() {
+			BeforeEach(func() {
+				build, err := defaultTeam.CreateOneOffBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				createBuildContainer(build)
+			})
+
+			It("leaves out the worker", func() {
+				workerNames, err := workerLifecycle.FindIdleWorkers(ctx, time.Hour)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(workerNames).ToNot(ContainElement(atcWorker.Name))
+			})
+		})
+
+		Context("when the worker has a container for a build which finished recently", func() {
+			BeforeEach(func() {
+				build, err := defaultTeam.CreateOneOffBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				createBuildContainer(build)
+
+				err = build.Finish(db.BuildStatusSucceeded)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("leaves out the worker", func() {
+				workerNames, err := workerLifecycle.FindIdleWorkers(ctx, time.Hour)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(workerNames).ToNot(ContainElement(atcWorker.Name))
+			})
+
+			It("returns the worker once the build finished longer ago than idleFor", func() {
+				_, err := dbConn.Exec(`UPDATE builds SET end_time = NOW() - '2 hours'::INTERVAL`)
+				Expect(err).ToNot(HaveOccurred())
+
+				workerNames, err := workerLifecycle.FindIdleWorkers(ctx, time.Hour)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(workerNames).To(ContainElement(atcWorker.Name))
+			})
+		})
+
+		Context("when the worker has a hijacked container for a finished build", func() {
+			BeforeEach(func() {
+				build, err := defaultTeam.CreateOneOffBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				hijackedContainer := createBuildContainer(build)
+
+				err = build.Finish(db.BuildStatusSucceeded)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = dbConn.Exec(`UPDATE builds SET end_time = NOW() - '2 hours'::INTERVAL`)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = hijackedContainer.UpdateLastHijack()
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns the worker", func() {
+				workerNames, err := workerLifecycle.FindIdleWorkers(ctx, time.Hour)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(workerNames).To(ContainElement(atcWorker.Name))
+			})
+
+			It("leaves out the worker if it also has a container for a running build", func() {
+				build, err := defaultTeam.CreateOneOffBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				createBuildContainer(build)
+
+				workerNames, err := workerLifecycle.FindIdleWorkers(ctx, time.Hour)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(workerNames).ToNot(ContainElement(atcWorker.Name))
+			})
+		})
+	})
+
+	Describe("FindScaleDownCandidates", func() {
+		BeforeEach(func() {
+			_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = dbConn.Exec(`UPDATE workers SET state_changed_at = NOW() - '2 hours'::interval`)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns the idle workers which have been running for at least idleFor", func() {
+			workerNames, err := workerLifecycle.FindScaleDownCandidates(ctx, time.Hour, 0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(workerNames).To(Equal([]string{"default-worker", "other-worker", atcWorker.Name}))
+		})
+
+		It("returns at most limit workers", func() {
+			workerNames, err := workerLifecycle.FindScaleDownCandidates(ctx, time.Hour, 2)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(workerNames).To(Equal([]string{"default-worker", "other-worker"}))
+		})
+
+		It("leaves out workers which have only just started running", func() {
+			_, err := dbConn.Exec(`UPDATE workers SET state_changed_at = NOW() WHERE name = $1`, atcWorker.Name)
+			Expect(err).ToNot(HaveOccurred())
+
+			workerNames, err := workerLifecycle.FindScaleDownCandidates(ctx, time.Hour, 0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(workerNames).ToNot(ContainElement(atcWorker.Name))
+		})
+
+		It("leaves out workers which are landing", func() {
+			_, err := workerLifecycle.SetWorkerStates(ctx, []string{atcWorker.Name}, db.WorkerStateLanding)
+			Expect(err).ToNot(HaveOccurred())
+
+			workerNames, err := workerLifecycle.FindScaleDownCandidates(ctx, 0, 0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(workerNames).ToNot(ContainElement(atcWorker.Name))
+		})
+
+		It("leaves out workers with a container for a running build", func() {
+			dbWorker, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			build, err := defaultTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			creatingContainer, err := dbWorker.CreateContainer(db.NewBuildStepContainerOwner(build.ID(), atc.PlanID("4"), defaultTeam.ID()), db.ContainerMetadata{})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = creatingContainer.Created()
+			Expect(err).ToNot(HaveOccurred())
+
+			workerNames, err := workerLifecycle.FindScaleDownCandidates(ctx, time.Hour, 0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(workerNames).ToNot(ContainElement(atcWorker.Name))
+		})
+	})
+
+	Describe("emitting query metrics", func() {
+		var (
+			fakeEmitter       *dbfakes.FakeLifecycleMetricsEmitter
+			measuredLifecycle db.WorkerLifecycle
+		)
+
+		BeforeEach(func() {
+			fakeEmitter = new(dbfakes.FakeLifecycleMetricsEmitter)
+			measuredLifecycle = db.NewWorkerLifecycleWithOptions(dbConn, db.WorkerLifecycleOptions{
+				Emitter: fakeEmitter,
+			})
+
+			atcWorker.Ephemeral = false
+			_, err := workerFactory.SaveWorker(atcWorker, -1*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("reports the operation, its duration and the rows it affected", func() {
+			_, err := measuredLifecycle.StallUnresponsiveWorkers(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeEmitter.LifecycleQueryCompletedCallCount()).To(Equal(1))
+			operation, duration, rowsAffected := fakeEmitter.LifecycleQueryCompletedArgsForCall(0)
+			Expect(operation).To(Equal("stall-unresponsive-workers"))
+			Expect(duration).To(BeNumerically(">", 0))
+			Expect(rowsAffected).To(Equal(1))
+		})
+
+		It("does not report queries which fail", func() {
+			fakeConn := new(dbfakes.FakeDbConn)
+			fakeConn.QueryContextReturns(nil, errors.New("disaster"))
+
+			failingLifecycle := db.NewWorkerLifecycleWithOptions(fakeConn, db.WorkerLifecycleOptions{
+				Emitter: fakeEmitter,
+			})
+
+			_, err := failingLifecycle.StallUnresponsiveWorkers(ctx)
+			Expect(err).To(HaveOccurred())
+			Expect(fakeEmitter.LifecycleQueryCompletedCallCount()).To(BeZero())
+		})
+	})
+
+	Describe("LifecycleStats", func() {
+		var countingLifecycle db.WorkerLifecycle
+
+		BeforeEach(func() {
+			countingLifecycle = db.NewWorkerLifecycle(dbConn, nil)
+
+			atcWorker.Ephemeral = false
+			_, err := workerFactory.SaveWorker(atcWorker, -1*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("counts the rows affected by each operation", func() {
+			before := time.Now()
+
+			_, err := countingLifecycle.StallUnresponsiveWorkers(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = countingLifecycle.StallUnresponsiveWorkers(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = countingLifecycle.LandAllWorkers(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			counters := countingLifecycle.LifecycleStats()
+			Expect(counters.Since).To(BeTemporally("<=", before))
+			Expect(counters.RowsAffected).To(Equal(map[string]int{
+				"stall-unresponsive-workers": 1,
+				"land-all-workers":           2,
+			}))
+		})
+
+		It("starts counting again once read", func() {
+			_, err := countingLifecycle.StallUnresponsiveWorkers(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			first := countingLifecycle.LifecycleStats()
+
+			counters := countingLifecycle.LifecycleStats()
+			Expect(counters.Since).To(BeTemporally(">=", first.Since))
+			Expect(counters.RowsAffected).To(BeEmpty())
+		})
+	})
+
+	Describe("WithLifecycleLock", func() {
+		var lockingLifecycle db.WorkerLifecycle
+
+		BeforeEach(func() {
+			lockingLifecycle = db.NewWorkerLifecycleWithOptions(dbConn, db.WorkerLifecycleOptions{
+				LockFactory: lockFactory,
+			})
+		})
+
+		It("runs fn while holding the lock", func() {
+			ran := false
+			err := lockingLifecycle.WithLifecycleLock(ctx, func() error {
+				ran = true
+
+				_, acquired, err := lockFactory.Acquire(logger, lock.NewWorkerLifecycleLockID())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(acquired).To(BeFalse())
+
+				return nil
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ran).To(BeTrue())
+		})
+
+		It("releases the lock and returns the error of fn", func() {
+			disaster := errors.New("disaster")
+			err := lockingLifecycle.WithLifecycleLock(ctx, func() error {
+				return disaster
+			})
+			Expect(err).To(Equal(disaster))
+
+			lifecycleLock, acquired, err := lockFactory.Acquire(logger, lock.NewWorkerLifecycleLockID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(acquired).To(BeTrue())
+			Expect(lifecycleLock.Release()).To(Succeed())
+		})
+
+		Context("when another ATC holds the lock", func() {
+			var lifecycleLock lock.Lock
+
+			BeforeEach(func() {
+				var (
+					acquired bool
+					err      error
+				)
+				lifecycleLock, acquired, err = lockFactory.Acquire(logger, lock.NewWorkerLifecycleLockID())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(acquired).To(BeTrue())
+			})
+
+			AfterEach(func() {
+				Expect(lifecycleLock.Release()).To(Succeed())
+			})
+
+			It("returns without running fn", func() {
+				err := lockingLifecycle.WithLifecycleLock(ctx, func() error {
+					Fail("fn should not run")
+					return nil
+				})
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		It("returns ErrNoLifecycleLockFactory without a lock factory", func() {
+			err := workerLifecycle.WithLifecycleLock(ctx, func() error {
+				Fail("fn should not run")
+				return nil
+			})
+			Expect(err).To(Equal(db.ErrNoLifecycleLockFactory))
+		})
+	})
+
+	Describe("cancelling the base context", func() {
+		var (
+			baseCtx    context.Context
+			cancelBase context.CancelFunc
+			fakeConn   *dbfakes.FakeDbConn
+			lifecycle  db.WorkerLifecycle
+		)
+
+		BeforeEach(func() {
+			baseCtx, cancelBase = context.WithCancel(context.Background())
+
+			fakeConn = new(dbfakes.FakeDbConn)
+			fakeConn.QueryContextStub = func(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+				cancelBase()
+				<-ctx.Done()
+				return nil, ctx.Err()
+			}
+
+			lifecycle = db.NewWorkerLifecycleWithOptions(fakeConn, db.WorkerLifecycleOptions{
+				Context: baseCtx,
+			})
+		})
+
+		AfterEach(func() {
+			cancelBase()
+		})
+
+		It("aborts the query in progress", func() {
+			_, err := lifecycle.StallUnresponsiveWorkers(context.Background())
+			Expect(err).To(MatchError(context.Canceled))
+		})
+
+		It("leaves the context passed to the operation alone", func() {
+			_, err := lifecycle.GetWorkerStateByName(ctx)
+			Expect(err).To(MatchError(context.Canceled))
+			Expect(ctx.Err()).ToNot(HaveOccurred())
+		})
+	})
+
+	Describe("retrying conflicting queries", func() {
+		var (
+			fakeConn *dbfakes.FakeDbConn
+			options  db.WorkerLifecycleOptions
+			deadlock error
+		)
+
+		BeforeEach(func() {
+			fakeConn = new(dbfakes.FakeDbConn)
+			options = db.WorkerLifecycleOptions{}
+			deadlock = &pgconn.PgError{Code: pgerrcode.DeadlockDetected}
+		})
+
+		expireWorker := func() error {
+			return db.NewWorkerLifecycleWithOptions(fakeConn, options).ExpireWorker(ctx, "some-name")
+		}
+
+		Context("when the query deadlocks once", func() {
+			BeforeEach(func() {
+				fakeConn.ExecContextReturnsOnCall(0, nil, deadlock)
+				fakeConn.ExecContextReturnsOnCall(1, driver.RowsAffected(1), nil)
+			})
+
+			It("retries it", func() {
+				Expect(expireWorker()).To(Succeed())
+				Expect(fakeConn.ExecContextCallCount()).To(Equal(2))
+			})
+		})
+
+		Context("when the query keeps failing to serialize", func() {
+			BeforeEach(func() {
+				fakeConn.ExecContextReturns(nil, &pgconn.PgError{Code: pgerrcode.SerializationFailure})
+			})
+
+			It("gives up after 3 retries by default", func() {
+				Expect(expireWorker()).To(HaveOccurred())
+				Expect(fakeConn.ExecContextCallCount()).To(Equal(4))
+			})
+
+			It("gives up after the configured number of retries", func() {
+				options.Retries = 1
+				Expect(expireWorker()).To(HaveOccurred())
+				Expect(fakeConn.ExecContextCallCount()).To(Equal(2))
+			})
+
+			It("keeps backing off without overflowing when retried many times", func() {
+				options.Retries = 100
+				options.MaxRetryBackoff = time.Millisecond
+				Expect(expireWorker()).To(HaveOccurred())
+				Expect(fakeConn.ExecContextCallCount()).To(Equal(101))
+			})
+
+			It("does not retry when retrying is disabled", func() {
+				options.Retries = -1
+				Expect(expireWorker()).To(HaveOccurred())
+				Expect(fakeConn.ExecContextCallCount()).To(Equal(1))
+			})
+
+			It("returns an error naming the operation which unwraps to the statement's error", func() {
+				err := expireWorker()
+
+				var queryErr db.LifecycleQueryError
+				Expect(errors.As(err, &queryErr)).To(BeTrue())
+				Expect(queryErr.Operation).To(Equal("expire-worker"))
+
+				var pgErr *pgconn.PgError
+				Expect(errors.As(err, &pgErr)).To(BeTrue())
+				Expect(pgErr.Code).To(Equal(pgerrcode.SerializationFailure))
+			})
+		})
+
+		Context("when the query fails for another reason", func() {
+			BeforeEach(func() {
+				fakeConn.ExecContextReturns(nil, errors.New("disaster"))
+			})
+
+			It("returns the error immediately", func() {
+				Expect(expireWorker()).To(MatchError("expire-worker: disaster"))
+				Expect(fakeConn.ExecContextCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Describe("naming the operation of a failed query", func() {
+		var (
+			fakeConn      *dbfakes.FakeDbConn
+			fakeLifecycle db.WorkerLifecycle
+		)
+
+		BeforeEach(func() {
+			fakeConn = new(dbfakes.FakeDbConn)
+			fakeConn.QueryContextReturns(nil, errors.New("disaster"))
+
+			fakeLifecycle = db.NewWorkerLifecycle(fakeConn, nil)
+		})
+
+		DescribeTable("wraps the error of the statement",
+			func(run func(db.WorkerLifecycle) error, operation string) {
+				err := run(fakeLifecycle)
+				Expect(err).To(MatchError(operation + ": disaster"))
+
+				var queryErr db.LifecycleQueryError
+				Expect(errors.As(err, &queryErr)).To(BeTrue())
+				Expect(queryErr.Operation).To(Equal(operation))
+			},
+			Entry("StallUnresponsiveWorkersTx", func(lifecycle db.WorkerLifecycle) error {
+				fakeTx := new(dbfakes.FakeTx)
+				fakeTx.ExecContextReturns(nil, errors.New("disaster"))
+
+				_, err := lifecycle.StallUnresponsiveWorkersTx(ctx, fakeTx)
+				return err
+			}, "stall-unresponsive-workers"),
+			Entry("GetWorkerHeartbeatAges", func(lifecycle db.WorkerLifecycle) error {
+				_, err := lifecycle.GetWorkerHeartbeatAges(ctx)
+				return err
+			}, "get-worker-heartbeat-ages"),
+			Entry("GetWorkerUptimes", func(lifecycle db.WorkerLifecycle) error {
+				_, err := lifecycle.GetWorkerUptimes(ctx)
+				return err
+			}, "get-worker-uptimes"),
+			Entry("CountWorkersByState", func(lifecycle db.WorkerLifecycle) error {
+				_, err := lifecycle.CountWorkersByState(ctx)
+				return err
+			}, "count-workers-by-state"),
+			Entry("CountWorkersByTeamAndState", func(lifecycle db.WorkerLifecycle) error {
+				_, err := lifecycle.CountWorkersByTeamAndState(ctx)
+				return err
+			}, "count-workers-by-team-and-state"),
+			Entry("GetWorkersInState", func(lifecycle db.WorkerLifecycle) error {
+				_, err := lifecycle.GetWorkersInState(ctx, db.WorkerStateRunning)
+				return err
+			}, "get-workers-in-state"),
+			Entry("GetWorkerStatesWithTeam", func(lifecycle db.WorkerLifecycle) error {
+				_, err := lifecycle.GetWorkerStatesWithTeam(ctx)
+				return err
+			}, "get-worker-states-with-team"),
+			Entry("GetWorkerProcessors", func(lifecycle db.WorkerLifecycle) error {
+				_, err := lifecycle.GetWorkerProcessors(ctx)
+				return err
+			}, "get-worker-processors"),
+			Entry("GetBuildsBlockingWorkerLanding", func(lifecycle db.WorkerLifecycle) error {
+				_, err := lifecycle.GetBuildsBlockingWorkerLanding(ctx, "some-worker")
+				return err
+			}, "get-builds-blocking-worker-landing"),
+			Entry("EstimateLandingDrainTimes", func(lifecycle db.WorkerLifecycle) error {
+				_, err := lifecycle.EstimateLandingDrainTimes(ctx)
+				return err
+			}, "estimate-landing-drain-times"),
+		)
+	})
+
+	Describe("using a custom workers table", func() {
+		var (
+			fakeConn      *dbfakes.FakeDbConn
+			fakeLifecycle db.WorkerLifecycle
+		)
+
+		BeforeEach(func() {
+			fakeConn = new(dbfakes.FakeDbConn)
+			fakeConn.QueryContextReturns(nil, errors.New("disaster"))
+
+			fakeLifecycle = db.NewWorkerLifecycleWithOptions(fakeConn, db.WorkerLifecycleOptions{
+				Table: "tenant_a.workers",
+			})
+		})
+
+		It("runs the statements against the table aliased as workers", func() {
+			_, err := fakeLifecycle.StallUnresponsiveWorkers(ctx)
+			Expect(err).To(MatchError("stall-unresponsive-workers: disaster"))
+
+			_, query, _ := fakeConn.QueryContextArgsForCall(0)
+			Expect(query).To(HavePrefix(`UPDATE "tenant_a"."workers" workers SET`))
+		})
+
+		It("joins against the table in the uninterruptible-build subquery", func() {
+			_, err := fakeLifecycle.LandFinishedLandingWorkers(ctx)
+			Expect(err).To(MatchError("land-finished-landing-workers: disaster"))
+
+			_, query, _ := fakeConn.QueryContextArgsForCall(0)
+			Expect(query).To(ContainSubstring(`JOIN "tenant_a"."workers" w ON w.name = c.worker_name`))
+		})
+
+		It("reads worker states from the table", func() {
+			_, err := fakeLifecycle.GetWorkerStateByName(ctx)
+			Expect(err).To(MatchError("disaster"))
+
+			_, query, _ := fakeConn.QueryContextArgsForCall(0)
+			Expect(query).To(ContainSubstring(`FROM "tenant_a"."workers" workers`))
+		})
+	})
+
+	Describe("WithWorkerCount", func() {
+		BeforeEach(func() {
+			atcWorker.State = string(db.WorkerStateRetiring)
+			_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns the names of the affected workers along with their count", func() {
+			names, count, err := db.WithWorkerCount(workerLifecycle.DeleteFinishedRetiringWorkers(ctx))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(names).To(Equal([]string{atcWorker.Name}))
+			Expect(count).To(Equal(1))
+		})
+
+		It("counts the workers affected before a failure", func() {
+			names, count, err := db.WithWorkerCount([]string{"some-worker"}, errors.New("disaster"))
+			Expect(err).To(MatchError("disaster"))
+			Expect(names).To(Equal([]string{"some-worker"}))
+			Expect(count).To(Equal(1))
+		})
+	})
+
+	Describe("distinguishing empty results from failures", func() {
+		DescribeTable("returns an empty, non-nil slice when nothing matches",
+			func(operation func(db.WorkerLifecycle) (any, error)) {
+				result, err := operation(workerLifecycle)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).ToNot(BeNil())
+				Expect(result).To(BeEmpty())
+			},
+			Entry("DeleteUnresponsiveEphemeralWorkers", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.DeleteUnresponsiveEphemeralWorkers(ctx)
+			}),
+			Entry("DeleteUnresponsiveEphemeralWorkersDetailed", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.DeleteUnresponsiveEphemeralWorkersDetailed(ctx)
+			}),
+			Entry("GetDeletableEphemeralWorkers", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.GetDeletableEphemeralWorkers(ctx)
+			}),
+			Entry("StallUnresponsiveWorkers", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.StallUnresponsiveWorkers(ctx)
+			}),
+			Entry("StallUnresponsiveWorkersWithGrace", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.StallUnresponsiveWorkersWithGrace(ctx, time.Minute)
+			}),
+			Entry("StallUnresponsiveWorkersWithPreviousState", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.StallUnresponsiveWorkersWithPreviousState(ctx)
+			}),
+			Entry("StallUnresponsiveWorkersDetailed", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.StallUnresponsiveWorkersDetailed(ctx)
+			}),
+			Entry("DeleteStalledWorkers", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.DeleteStalledWorkers(ctx, time.Minute)
+			}),
+			Entry("DeleteExpiredStalledWorkers", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.DeleteExpiredStalledWorkers(ctx)
+			}),
+			Entry("LandFinishedLandingWorkers", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.LandFinishedLandingWorkers(ctx)
+			}),
+			Entry("LandFinishedLandingWorkersWithDuration", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.LandFinishedLandingWorkersWithDuration(ctx)
+			}),
+			Entry("LandFinishedLandingWorkersDetailed", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.LandFinishedLandingWorkersDetailed(ctx)
+			}),
+			Entry("DeleteFinishedRetiringWorkers", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.DeleteFinishedRetiringWorkers(ctx)
+			}),
+			Entry("DeleteFinishedRetiringWorkersWithPreviousState", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.DeleteFinishedRetiringWorkersWithPreviousState(ctx)
+			}),
+			Entry("DeleteFinishedRetiringWorkersDetailed", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.DeleteFinishedRetiringWorkersDetailed(ctx)
+			}),
+			Entry("DeleteFinishedRetiringWorkersBatch", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.DeleteFinishedRetiringWorkersBatch(ctx, 10)
+			}),
+			Entry("PurgeDeletedWorkers", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.PurgeDeletedWorkers(ctx, time.Minute)
+			}),
+			Entry("SetWorkerStates", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.SetWorkerStates(ctx, []string{"bogus-worker"}, db.WorkerStateRetiring)
+			}),
+			Entry("GetChronicallyStalledWorkers", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.GetChronicallyStalledWorkers(ctx, 0)
+			}),
+			Entry("FindNeverLandedWorkers", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.FindNeverLandedWorkers(ctx)
+			}),
+			Entry("FindWorkersBlockedByDeletedJobs", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.FindWorkersBlockedByDeletedJobs(ctx)
+			}),
+			Entry("FindScaleDownCandidates", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.FindScaleDownCandidates(ctx, time.Hour, 10)
+			}),
+			Entry("FindExpiredWorkersWithActiveBuilds", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.FindExpiredWorkersWithActiveBuilds(ctx)
+			}),
+			Entry("FindWorkersWithOutdatedResourceTypes", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.FindWorkersWithOutdatedResourceTypes(ctx, map[string]string{"some-resource-type": "some-version"})
+			}),
+			Entry("HeartbeatWorkers", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.HeartbeatWorkers(ctx, []string{"bogus-worker"}, time.Minute)
+			}),
+			Entry("RestoreUpgradedWorkers", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.RestoreUpgradedWorkers(ctx, []string{"bogus-worker"})
+			}),
+			Entry("FindWorkersMissingHeartbeats", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.FindWorkersMissingHeartbeats(ctx, 0)
+			}),
+			Entry("GetBuildsBlockingWorkerLanding", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.GetBuildsBlockingWorkerLanding(ctx, "default-worker")
+			}),
+		)
+
+		DescribeTable("returns a nil slice when the query fails",
+			func(operation func(db.WorkerLifecycle) (any, error)) {
+				disaster := errors.New("disaster")
+
+				fakeConn := new(dbfakes.FakeDbConn)
+				fakeConn.QueryContextReturns(nil, disaster)
+				fakeLifecycle := db.NewWorkerLifecycleWithOptions(fakeConn, db.WorkerLifecycleOptions{
+					Retries: -1,
+				})
+
+				result, err := operation(fakeLifecycle)
+				Expect(err).To(MatchError(disaster))
+				Expect(result).To(BeNil())
+			},
+			Entry("DeleteUnresponsiveEphemeralWorkers", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.DeleteUnresponsiveEphemeralWorkers(ctx)
+			}),
+			Entry("DeleteUnresponsiveEphemeralWorkersDetailed", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.DeleteUnresponsiveEphemeralWorkersDetailed(ctx)
+			}),
+			Entry("GetDeletableEphemeralWorkers", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.GetDeletableEphemeralWorkers(ctx)
+			}),
+			Entry("StallUnresponsiveWorkers", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.StallUnresponsiveWorkers(ctx)
+			}),
+			Entry("StallUnresponsiveWorkersWithGrace", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.StallUnresponsiveWorkersWithGrace(ctx, time.Minute)
+			}),
+			Entry("StallUnresponsiveWorkersWithPreviousState", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.StallUnresponsiveWorkersWithPreviousState(ctx)
+			}),
+			Entry("StallUnresponsiveWorkersDetailed", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.StallUnresponsiveWorkersDetailed(ctx)
+			}),
+			Entry("DeleteStalledWorkers", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.DeleteStalledWorkers(ctx, time.Minute)
+			}),
+			Entry("DeleteExpiredStalledWorkers", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.DeleteExpiredStalledWorkers(ctx)
+			}),
+			Entry("LandFinishedLandingWorkers", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.LandFinishedLandingWorkers(ctx)
+			}),
+			Entry("LandFinishedLandingWorkersWithDuration", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.LandFinishedLandingWorkersWithDuration(ctx)
+			}),
+			Entry("LandFinishedLandingWorkersDetailed", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.LandFinishedLandingWorkersDetailed(ctx)
+			}),
+			Entry("DeleteFinishedRetiringWorkers", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.DeleteFinishedRetiringWorkers(ctx)
+			}),
+			Entry("DeleteFinishedRetiringWorkersWithPreviousState", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.DeleteFinishedRetiringWorkersWithPreviousState(ctx)
+			}),
+			Entry("DeleteFinishedRetiringWorkersDetailed", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.DeleteFinishedRetiringWorkersDetailed(ctx)
+			}),
+			Entry("DeleteFinishedRetiringWorkersBatch", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.DeleteFinishedRetiringWorkersBatch(ctx, 10)
+			}),
+			Entry("PurgeDeletedWorkers", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.PurgeDeletedWorkers(ctx, time.Minute)
+			}),
+			Entry("SetWorkerStates", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.SetWorkerStates(ctx, []string{"bogus-worker"}, db.WorkerStateRetiring)
+			}),
+			Entry("GetChronicallyStalledWorkers", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.GetChronicallyStalledWorkers(ctx, 0)
+			}),
+			Entry("FindNeverLandedWorkers", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.FindNeverLandedWorkers(ctx)
+			}),
+			Entry("FindWorkersWithHeadroom", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.FindWorkersWithHeadroom(ctx, 1, 0)
+			}),
+			Entry("FindWorkersBlockedByDeletedJobs", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.FindWorkersBlockedByDeletedJobs(ctx)
+			}),
+			Entry("FindScaleDownCandidates", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.FindScaleDownCandidates(ctx, time.Hour, 10)
+			}),
+			Entry("FindExpiredWorkersWithActiveBuilds", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.FindExpiredWorkersWithActiveBuilds(ctx)
+			}),
+			Entry("FindWorkersWithOutdatedResourceTypes", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.FindWorkersWithOutdatedResourceTypes(ctx, map[string]string{"some-resource-type": "some-version"})
+			}),
+			Entry("HeartbeatWorkers", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.HeartbeatWorkers(ctx, []string{"bogus-worker"}, time.Minute)
+			}),
+			Entry("RestoreUpgradedWorkers", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.RestoreUpgradedWorkers(ctx, []string{"bogus-worker"})
+			}),
+			Entry("FindWorkersMissingHeartbeats", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.FindWorkersMissingHeartbeats(ctx, 0)
+			}),
+			Entry("GetBuildsBlockingWorkerLanding", func(lifecycle db.WorkerLifecycle) (any, error) {
+				return lifecycle.GetBuildsBlockingWorkerLanding(ctx, "default-worker")
+			}),
+		)
+	})
+
+	Describe("building the SQL of the operations", func() {
+		DescribeTable("returns the statement with postgres placeholders",
+			func(build func(db.WorkerLifecycle) (string, []any, error), prefix string) {
+				query, args, err := build(workerLifecycle)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(query).To(HavePrefix(prefix))
+				Expect(query).ToNot(ContainSubstring("?"))
+				Expect(query).To(ContainSubstring(fmt.Sprintf("$%d", len(args))))
+			},
+			Entry("DeleteUnresponsiveEphemeralWorkersSQL", func(lifecycle db.WorkerLifecycle) (string, []any, error) {
+				return lifecycle.DeleteUnresponsiveEphemeralWorkersSQL()
+			}, "DELETE FROM workers"),
+			Entry("StallUnresponsiveWorkersSQL", func(lifecycle db.WorkerLifecycle) (string, []any, error) {
+				return lifecycle.StallUnresponsiveWorkersSQL(time.Minute)
+			}, "UPDATE workers"),
+			Entry("DeleteStalledWorkersSQL", func(lifecycle db.WorkerLifecycle) (string, []any, error) {
+				return lifecycle.DeleteStalledWorkersSQL(time.Minute)
+			}, "DELETE FROM workers"),
+			Entry("LandFinishedLandingWorkersSQL", func(lifecycle db.WorkerLifecycle) (string, []any, error) {
+				return lifecycle.LandFinishedLandingWorkersSQL()
+			}, "UPDATE workers"),
+			Entry("DeleteFinishedRetiringWorkersSQL", func(lifecycle db.WorkerLifecycle) (string, []any, error) {
+				return lifecycle.DeleteFinishedRetiringWorkersSQL()
+			}, "DELETE FROM workers"),
+		)
+
+		DescribeTable("numbers the placeholders of the injected subqueries together with its own",
+			func(build func(db.WorkerLifecycle) (string, []any, error)) {
+				query, args, err := build(workerLifecycle)
+				Expect(err).ToNot(HaveOccurred())
+
+				for i := 1; i <= len(args); i++ {
+					Expect(query).To(ContainSubstring(fmt.Sprintf("$%d", i)))
+				}
+				Expect(query).ToNot(ContainSubstring(fmt.Sprintf("$%d", len(args)+1)))
+			},
+			Entry("LandFinishedLandingWorkersSQL", func(lifecycle db.WorkerLifecycle) (string, []any, error) {
+				return lifecycle.LandFinishedLandingWorkersSQL()
+			}),
+			Entry("DeleteFinishedRetiringWorkersSQL", func(lifecycle db.WorkerLifecycle) (string, []any, error) {
+				return lifecycle.DeleteFinishedRetiringWorkersSQL()
+			}),
+		)
+
+		It("returns the statement the operation runs", func() {
+			fakeConn := new(dbfakes.FakeDbConn)
+			fakeConn.QueryContextReturns(nil, errors.New("disaster"))
+			fakeLifecycle := db.NewWorkerLifecycle(fakeConn, nil)
+
+			_, err := fakeLifecycle.DeleteFinishedRetiringWorkers(ctx)
+			Expect(err).To(MatchError("delete-finished-retiring-workers: disaster"))
+
+			query, args, err := fakeLifecycle.DeleteFinishedRetiringWorkersSQL()
+			Expect(err).ToNot(HaveOccurred())
+
+			_, runQuery, runArgs := fakeConn.QueryContextArgsForCall(0)
+			Expect(runQuery).To(Equal(query))
+			Expect(runArgs).To(Equal(args))
+		})
+
+		It("returns the preview in dry-run mode", func() {
+			dryRunLifecycle := db.NewWorkerLifecycleWithOptions(dbConn, db.WorkerLifecycleOptions{DryRun: true})
+
+			query, _, err := dryRunLifecycle.LandFinishedLandingWorkersSQL()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(query).To(HavePrefix("SELECT workers.name, workers.state,"))
+		})
 	})
 })
@@ -1,7 +1,9 @@
 package db_test
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -69,7 +71,7 @@ var _ = Describe("Worker", func() {
 				BeforeEach(func() {
 					err := worker.Land()
 					Expect(err).NotTo(HaveOccurred())
-					_, err = workerLifecycle.LandFinishedLandingWorkers()
+					_, err = workerLifecycle.LandFinishedLandingWorkers(context.Background())
 					Expect(err).NotTo(HaveOccurred())
 				})
 
@@ -177,7 +179,7 @@ var _ = Describe("Worker", func() {
 					}, -5*time.Minute)
 					Expect(err).NotTo(HaveOccurred())
 
-					_, err = workerLifecycle.StallUnresponsiveWorkers()
+					_, err = workerLifecycle.StallUnresponsiveWorkers(context.Background())
 					Expect(err).NotTo(HaveOccurred())
 					pruneErr = worker.Prune()
 				})
@@ -474,4 +476,120 @@ var _ = Describe("Worker", func() {
 			})
 		})
 	})
+
+	Describe("ValidWorkerTransition", func() {
+		DescribeTable("transitions",
+			func(from, to WorkerState, valid bool) {
+				Expect(ValidWorkerTransition(from, to)).To(Equal(valid))
+			},
+			Entry("running to stalled", WorkerStateRunning, WorkerStateStalled, true),
+			Entry("running to landing", WorkerStateRunning, WorkerStateLanding, true),
+			Entry("running to retiring", WorkerStateRunning, WorkerStateRetiring, true),
+			Entry("running to draining", WorkerStateRunning, WorkerStateDraining, true),
+			Entry("stalled to running", WorkerStateStalled, WorkerStateRunning, true),
+			Entry("stalled to landing", WorkerStateStalled, WorkerStateLanding, false),
+			Entry("landing to landed", WorkerStateLanding, WorkerStateLanded, true),
+			Entry("landed to running", WorkerStateLanded, WorkerStateRunning, true),
+			Entry("landed to stalled", WorkerStateLanded, WorkerStateStalled, false),
+			Entry("landed to quarantined", WorkerStateLanded, WorkerStateQuarantined, false),
+			Entry("draining to landed", WorkerStateDraining, WorkerStateLanded, true),
+			Entry("draining to running", WorkerStateDraining, WorkerStateRunning, false),
+			Entry("retiring to running", WorkerStateRetiring, WorkerStateRunning, false),
+			Entry("stalled to quarantined", WorkerStateStalled, WorkerStateQuarantined, true),
+			Entry("retiring to quarantined", WorkerStateRetiring, WorkerStateQuarantined, false),
+			Entry("quarantined to running", WorkerStateQuarantined, WorkerStateRunning, true),
+			Entry("quarantined to stalled", WorkerStateQuarantined, WorkerStateStalled, false),
+			Entry("an unknown state", WorkerState("bogus"), WorkerStateRunning, false),
+		)
+
+		It("has an entry for every state", func() {
+			for _, state := range AllWorkerStates() {
+				Expect(WorkerStateTransitions).To(HaveKey(state))
+			}
+		})
+
+		It("never moves a worker to the state it is already in", func() {
+			for _, state := range AllWorkerStates() {
+				Expect(ValidWorkerTransition(state, state)).To(BeFalse())
+			}
+		})
+	})
+
+	Describe("WorkerState", func() {
+		It("scans known states", func() {
+			var state WorkerState
+			Expect(state.Scan([]byte("landing"))).To(Succeed())
+			Expect(state).To(Equal(WorkerStateLanding))
+		})
+
+		It("fails to scan unknown states", func() {
+			var state WorkerState
+			err := state.Scan("bogus")
+			Expect(err).To(MatchError(ErrUnknownWorkerState))
+			Expect(state).To(BeEmpty())
+		})
+
+		It("fails to write unknown states", func() {
+			_, err := WorkerState("bogus").Value()
+			Expect(err).To(MatchError(ErrUnknownWorkerState))
+		})
+
+		It("fails loudly when reading an unknown state from the database", func() {
+			var state WorkerState
+			err := dbConn.QueryRow(`SELECT 'bogus'::text`).Scan(&state)
+			Expect(err).To(MatchError(ContainSubstring("unknown worker state")))
+		})
+
+		It("round-trips every state through JSON as a plain string", func() {
+			for _, state := range AllWorkerStates() {
+				payload, err := json.Marshal(state)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(payload).To(MatchJSON(fmt.Sprintf("%q", string(state))))
+
+				var decoded WorkerState
+				Expect(json.Unmarshal(payload, &decoded)).To(Succeed())
+				Expect(decoded).To(Equal(state))
+			}
+		})
+
+		It("fails to unmarshal unknown states", func() {
+			var state WorkerState
+			err := json.Unmarshal([]byte(`"Running"`), &state)
+			Expect(err).To(MatchError(ErrUnknownWorkerState))
+			Expect(state).To(BeEmpty())
+		})
+
+		It("classifies every state", func() {
+			type classification struct {
+				active, terminal, acceptsWork bool
+			}
+
+			classifications := map[WorkerState]classification{
+				WorkerStateRunning:     {active: true, acceptsWork: true},
+				WorkerStateStalled:     {},
+				WorkerStateLanding:     {active: true},
+				WorkerStateLanded:      {terminal: true},
+				WorkerStateDraining:    {active: true},
+				WorkerStateRetiring:    {active: true},
+				WorkerStateDeleted:     {terminal: true},
+				WorkerStateQuarantined: {active: true},
+			}
+
+			for _, state := range AllWorkerStates() {
+				Expect(classifications).To(HaveKey(state), "state %q is not classified", state)
+
+				expected := classifications[state]
+				Expect(state.IsActive()).To(Equal(expected.active), "IsActive of %q", state)
+				Expect(state.IsTerminal()).To(Equal(expected.terminal), "IsTerminal of %q", state)
+				Expect(state.CanAcceptWork()).To(Equal(expected.acceptsWork), "CanAcceptWork of %q", state)
+			}
+		})
+
+		It("does not classify unknown states", func() {
+			state := WorkerState("bogus")
+			Expect(state.IsActive()).To(BeFalse())
+			Expect(state.IsTerminal()).To(BeFalse())
+			Expect(state.CanAcceptWork()).To(BeFalse())
+		})
+	})
 })
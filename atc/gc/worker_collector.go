@@ -35,19 +35,34 @@ func (wc *workerCollector) Run(ctx context.Context) error {
 		}.Emit(logger)
 	}()
 
-	affected, err := wc.workerLifecycle.DeleteUnresponsiveEphemeralWorkers()
+	// The lifecycle operations return the workers they affected before
+	// failing, so those are still logged.
+	deletedWorkers, err := wc.workerLifecycle.DeleteUnresponsiveEphemeralWorkersDetailed(ctx)
+
+	for _, deletedWorker := range deletedWorkers {
+		data := lager.Data{
+			"worker":  deletedWorker.Name,
+			"team":    deletedWorker.TeamName,
+			"state":   deletedWorker.State,
+			"expires": deletedWorker.Expires,
+		}
+
+		if deletedWorker.Addr != nil {
+			data["addr"] = *deletedWorker.Addr
+		}
+
+		logger.Info("ephemeral-worker-removed", data)
+	}
+
 	if err != nil {
 		logger.Error("failed-to-remove-dead-ephemeral-workers", err)
 		return err
 	}
 
-	if len(affected) > 0 {
-		logger.Info("ephemeral-workers-removed", lager.Data{"count": len(affected), "workers": affected})
-	}
-
-	affected, err = wc.workerLifecycle.StallUnresponsiveWorkers()
+	// The workers are logged along with the reason they were affected for.
+	affected, err := wc.workerLifecycle.StallUnresponsiveWorkersDetailed(ctx)
 	if err != nil {
-		logger.Error("failed-to-mark-workers-as-stalled", err)
+		logger.Error("failed-to-mark-workers-as-stalled", err, lager.Data{"workers": affected})
 		return err
 	}
 
@@ -56,20 +71,20 @@ func (wc *workerCollector) Run(ctx context.Context) error {
 	}
 
 	if wc.stallTimeout > 0 {
-		affected, err = wc.workerLifecycle.DeleteStalledWorkers(wc.stallTimeout)
+		deleted, err := wc.workerLifecycle.DeleteStalledWorkers(ctx, wc.stallTimeout)
 		if err != nil {
-			logger.Error("failed-to-delete-stalled-workers", err)
+			logger.Error("failed-to-delete-stalled-workers", err, lager.Data{"workers": deleted})
 			return err
 		}
 
-		if len(affected) > 0 {
-			logger.Info("stalled-workers-removed", lager.Data{"count": len(affected), "workers": affected})
+		if len(deleted) > 0 {
+			logger.Info("stalled-workers-removed", lager.Data{"count": len(deleted), "workers": deleted})
 		}
 	}
 
-	affected, err = wc.workerLifecycle.DeleteFinishedRetiringWorkers()
+	affected, err = wc.workerLifecycle.DeleteFinishedRetiringWorkersDetailed(ctx)
 	if err != nil {
-		logger.Error("failed-to-delete-finished-retiring-workers", err)
+		logger.Error("failed-to-delete-finished-retiring-workers", err, lager.Data{"workers": affected})
 		return err
 	}
 
@@ -77,9 +92,9 @@ func (wc *workerCollector) Run(ctx context.Context) error {
 		logger.Info("marked-workers-as-retired", lager.Data{"count": len(affected), "workers": affected})
 	}
 
-	affected, err = wc.workerLifecycle.LandFinishedLandingWorkers()
+	affected, err = wc.workerLifecycle.LandFinishedLandingWorkersDetailed(ctx)
 	if err != nil {
-		logger.Error("failed-to-land-finished-landing-workers", err)
+		logger.Error("failed-to-land-finished-landing-workers", err, lager.Data{"workers": affected})
 		return err
 	}
 
@@ -87,7 +102,19 @@ func (wc *workerCollector) Run(ctx context.Context) error {
 		logger.Info("marked-workers-as-landed", lager.Data{"count": len(affected), "workers": affected})
 	}
 
-	workerStateByName, err := wc.workerLifecycle.GetWorkerStateByName()
+	for _, landedWorker := range affected {
+		cleaned, err := wc.workerLifecycle.CleanWorkerResourceCaches(ctx, landedWorker.Name)
+		if err != nil {
+			logger.Error("failed-to-clean-landed-worker-resource-caches", err, lager.Data{"worker": landedWorker.Name})
+			return err
+		}
+
+		if cleaned > 0 {
+			logger.Info("cleaned-landed-worker-resource-caches", lager.Data{"worker": landedWorker.Name, "count": cleaned})
+		}
+	}
+
+	workerStateByName, err := wc.workerLifecycle.GetWorkerStateByName(ctx)
 
 	if err != nil {
 		logger.Error("failed-to-get-workers-states-for-metrics", err)
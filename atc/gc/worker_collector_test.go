@@ -4,10 +4,14 @@ import (
 	"context"
 	"time"
 
+	"code.cloudfoundry.org/lager/v3/lagerctx"
+	"code.cloudfoundry.org/lager/v3/lagertest"
 	"github.com/concourse/concourse/atc/gc"
 
 	"errors"
 
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -24,11 +28,11 @@ var _ = Describe("WorkerCollector", func() {
 		fakeWorkerLifecycle = new(dbfakes.FakeWorkerLifecycle)
 		stallTimeout = 0
 
-		fakeWorkerLifecycle.DeleteUnresponsiveEphemeralWorkersReturns(nil, nil)
-		fakeWorkerLifecycle.StallUnresponsiveWorkersReturns(nil, nil)
+		fakeWorkerLifecycle.DeleteUnresponsiveEphemeralWorkersDetailedReturns(nil, nil)
+		fakeWorkerLifecycle.StallUnresponsiveWorkersDetailedReturns(nil, nil)
 		fakeWorkerLifecycle.DeleteStalledWorkersReturns(nil, nil)
-		fakeWorkerLifecycle.DeleteFinishedRetiringWorkersReturns(nil, nil)
-		fakeWorkerLifecycle.LandFinishedLandingWorkersReturns(nil, nil)
+		fakeWorkerLifecycle.DeleteFinishedRetiringWorkersDetailedReturns(nil, nil)
+		fakeWorkerLifecycle.LandFinishedLandingWorkersDetailedReturns(nil, nil)
 	})
 
 	JustBeforeEach(func() {
@@ -40,41 +44,103 @@ var _ = Describe("WorkerCollector", func() {
 			err := workerCollector.Run(context.TODO())
 			Expect(err).NotTo(HaveOccurred())
 
-			Expect(fakeWorkerLifecycle.DeleteUnresponsiveEphemeralWorkersCallCount()).To(Equal(1))
+			Expect(fakeWorkerLifecycle.DeleteUnresponsiveEphemeralWorkersDetailedCallCount()).To(Equal(1))
+		})
+
+		It("returns an error if deleting unresponsive ephemeral workers fails", func() {
+			returnedErr := errors.New("some-error")
+			fakeWorkerLifecycle.DeleteUnresponsiveEphemeralWorkersDetailedReturns(nil, returnedErr)
+
+			err := workerCollector.Run(context.TODO())
+			Expect(err).To(MatchError(returnedErr))
 		})
 
 		It("tells the worker factory to expired stalled workers", func() {
 			err := workerCollector.Run(context.TODO())
 			Expect(err).NotTo(HaveOccurred())
 
-			Expect(fakeWorkerLifecycle.StallUnresponsiveWorkersCallCount()).To(Equal(1))
+			Expect(fakeWorkerLifecycle.StallUnresponsiveWorkersDetailedCallCount()).To(Equal(1))
 		})
 
 		It("tells the worker factory to delete finished retiring workers", func() {
 			err := workerCollector.Run(context.TODO())
 			Expect(err).NotTo(HaveOccurred())
 
-			Expect(fakeWorkerLifecycle.DeleteFinishedRetiringWorkersCallCount()).To(Equal(1))
+			Expect(fakeWorkerLifecycle.DeleteFinishedRetiringWorkersDetailedCallCount()).To(Equal(1))
 		})
 
 		It("tells the worker factory to land finished landing workers", func() {
 			err := workerCollector.Run(context.TODO())
 			Expect(err).NotTo(HaveOccurred())
 
-			Expect(fakeWorkerLifecycle.LandFinishedLandingWorkersCallCount()).To(Equal(1))
+			Expect(fakeWorkerLifecycle.LandFinishedLandingWorkersDetailedCallCount()).To(Equal(1))
+		})
+
+		It("propagates the context to every lifecycle call", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+
+			err := workerCollector.Run(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeWorkerLifecycle.DeleteUnresponsiveEphemeralWorkersDetailedArgsForCall(0)).To(Equal(ctx))
+			Expect(fakeWorkerLifecycle.StallUnresponsiveWorkersDetailedArgsForCall(0)).To(Equal(ctx))
+			Expect(fakeWorkerLifecycle.DeleteFinishedRetiringWorkersDetailedArgsForCall(0)).To(Equal(ctx))
+			Expect(fakeWorkerLifecycle.LandFinishedLandingWorkersDetailedArgsForCall(0)).To(Equal(ctx))
+			Expect(fakeWorkerLifecycle.GetWorkerStateByNameArgsForCall(0)).To(Equal(ctx))
 		})
 
 		It("returns an error if stalling unresponsive workers fails", func() {
 			returnedErr := errors.New("some-error")
-			fakeWorkerLifecycle.StallUnresponsiveWorkersReturns(nil, returnedErr)
+			fakeWorkerLifecycle.StallUnresponsiveWorkersDetailedReturns(nil, returnedErr)
 
 			err := workerCollector.Run(context.TODO())
 			Expect(err).To(MatchError(returnedErr))
 		})
 
+		It("logs the workers stalled before stalling unresponsive workers failed", func() {
+			testLogger := lagertest.NewTestLogger("test")
+			fakeWorkerLifecycle.StallUnresponsiveWorkersDetailedReturns([]db.WorkerAffected{{Name: "some-worker", Reason: db.WorkerTransitionReasonHeartbeatExpired}}, errors.New("some-error"))
+
+			err := workerCollector.Run(lagerctx.NewContext(context.TODO(), testLogger))
+			Expect(err).To(HaveOccurred())
+
+			Expect(testLogger.Logs()).To(ContainElement(SatisfyAll(
+				HaveField("Message", "test.worker-collector.failed-to-mark-workers-as-stalled"),
+				HaveField("Data", HaveKeyWithValue("workers", []any{
+					map[string]any{"name": "some-worker", "reason": "heartbeat-expired"},
+				})),
+			)))
+		})
+
 		It("returns an error if deleting finished retiring workers fails", func() {
 			returnedErr := errors.New("some-error")
-			fakeWorkerLifecycle.DeleteFinishedRetiringWorkersReturns(nil, returnedErr)
+			fakeWorkerLifecycle.DeleteFinishedRetiringWorkersDetailedReturns(nil, returnedErr)
+
+			err := workerCollector.Run(context.TODO())
+			Expect(err).To(MatchError(returnedErr))
+		})
+
+		It("cleans the resource caches of the workers it landed", func() {
+			fakeWorkerLifecycle.LandFinishedLandingWorkersDetailedReturns([]db.WorkerAffected{
+				{Name: "some-worker", Reason: db.WorkerTransitionReasonLandingComplete},
+				{Name: "other-worker", Reason: db.WorkerTransitionReasonLandingComplete},
+			}, nil)
+
+			err := workerCollector.Run(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeWorkerLifecycle.CleanWorkerResourceCachesCallCount()).To(Equal(2))
+			_, workerName := fakeWorkerLifecycle.CleanWorkerResourceCachesArgsForCall(0)
+			Expect(workerName).To(Equal("some-worker"))
+			_, workerName = fakeWorkerLifecycle.CleanWorkerResourceCachesArgsForCall(1)
+			Expect(workerName).To(Equal("other-worker"))
+		})
+
+		It("returns an error if cleaning the resource caches of a landed worker fails", func() {
+			returnedErr := errors.New("some-error")
+			fakeWorkerLifecycle.LandFinishedLandingWorkersDetailedReturns([]db.WorkerAffected{{Name: "some-worker"}}, nil)
+			fakeWorkerLifecycle.CleanWorkerResourceCachesReturns(0, returnedErr)
 
 			err := workerCollector.Run(context.TODO())
 			Expect(err).To(MatchError(returnedErr))
@@ -82,12 +148,54 @@ var _ = Describe("WorkerCollector", func() {
 
 		It("returns an error if landing finished landing workers fails", func() {
 			returnedErr := errors.New("some-error")
-			fakeWorkerLifecycle.LandFinishedLandingWorkersReturns(nil, returnedErr)
+			fakeWorkerLifecycle.LandFinishedLandingWorkersDetailedReturns(nil, returnedErr)
 
 			err := workerCollector.Run(context.TODO())
 			Expect(err).To(MatchError(returnedErr))
 		})
 
+		Context("when the lifecycle soft deletes ephemeral workers", func() {
+			var container db.CreatedContainer
+
+			BeforeEach(func() {
+				workerFactory := db.NewWorkerFactory(dbConn, db.NewStaticWorkerCache(logger, dbConn, 0))
+
+				worker, err := workerFactory.SaveWorker(atc.Worker{
+					Name:            "some-worker",
+					GardenAddr:      "1.2.3.4:7777",
+					BaggageclaimURL: "1.2.3.4:7788",
+					Ephemeral:       true,
+				}, 5*time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+
+				creatingContainer, err := worker.CreateContainer(db.NewBuildStepContainerOwner(defaultBuild.ID(), "some-plan", defaultTeam.ID()), db.ContainerMetadata{})
+				Expect(err).ToNot(HaveOccurred())
+
+				container, err = creatingContainer.Created()
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = dbConn.Exec(`UPDATE workers SET expires = NOW() - '1 minute'::interval WHERE name = $1`, worker.Name())
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			JustBeforeEach(func() {
+				workerLifecycle := db.NewWorkerLifecycleWithOptions(dbConn, db.WorkerLifecycleOptions{
+					SoftDeleteEphemeralWorkers: true,
+				})
+				workerCollector = gc.NewWorkerCollector(workerLifecycle, stallTimeout)
+			})
+
+			It("leaves the containers of the deleted workers to be destroyed", func() {
+				err := workerCollector.Run(context.TODO())
+				Expect(err).NotTo(HaveOccurred())
+
+				var state string
+				err = dbConn.QueryRow(`SELECT state FROM containers WHERE handle = $1`, container.Handle()).Scan(&state)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(state).To(Equal(atc.ContainerStateDestroying))
+			})
+		})
+
 		Context("when the stall timeout is disabled (zero)", func() {
 			BeforeEach(func() {
 				stallTimeout = 0
@@ -111,7 +219,8 @@ var _ = Describe("WorkerCollector", func() {
 				Expect(err).NotTo(HaveOccurred())
 
 				Expect(fakeWorkerLifecycle.DeleteStalledWorkersCallCount()).To(Equal(1))
-				Expect(fakeWorkerLifecycle.DeleteStalledWorkersArgsForCall(0)).To(Equal(time.Hour))
+				_, timeout := fakeWorkerLifecycle.DeleteStalledWorkersArgsForCall(0)
+				Expect(timeout).To(Equal(time.Hour))
 			})
 
 			It("returns an error if deleting stalled workers fails", func() {
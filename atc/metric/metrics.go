@@ -663,3 +663,48 @@ func (event WorkersState) Emit(logger lager.Logger, m *Monitor) {
 		)
 	}
 }
+
+type WorkerLifecycleQuery struct {
+	Operation    string
+	Duration     time.Duration
+	RowsAffected int
+}
+
+func (event WorkerLifecycleQuery) Emit(logger lager.Logger, m *Monitor) {
+	attributes := map[string]string{
+		"operation": event.Operation,
+	}
+
+	m.emit(
+		logger.Session("worker-lifecycle-query-duration"),
+		Event{
+			Name:       "worker lifecycle query duration (ms)",
+			Value:      ms(event.Duration),
+			Attributes: attributes,
+		},
+	)
+
+	m.emit(
+		logger.Session("worker-lifecycle-query-rows-affected"),
+		Event{
+			Name:       "worker lifecycle query rows affected",
+			Value:      float64(event.RowsAffected),
+			Attributes: attributes,
+		},
+	)
+}
+
+// WorkerLifecycleEmitter reports the queries run by a db.WorkerLifecycle as
+// WorkerLifecycleQuery events.
+type WorkerLifecycleEmitter struct {
+	Logger  lager.Logger
+	Monitor *Monitor
+}
+
+func (emitter WorkerLifecycleEmitter) LifecycleQueryCompleted(operation string, duration time.Duration, rowsAffected int) {
+	WorkerLifecycleQuery{
+		Operation:    operation,
+		Duration:     duration,
+		RowsAffected: rowsAffected,
+	}.Emit(emitter.Logger, emitter.Monitor)
+}
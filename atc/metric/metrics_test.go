@@ -1,6 +1,8 @@
 package metric_test
 
 import (
+	"time"
+
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/metric"
 	"github.com/concourse/concourse/atc/metric/metricfakes"
@@ -49,6 +51,44 @@ var _ = Describe("Metrics", func() {
 			Expect(event.Value).To(Equal(float64(1)))
 		})
 	})
+
+	Describe("worker lifecycle query metric", func() {
+		var (
+			emitter *smartFakeEmitter
+			monitor *metric.Monitor
+		)
+
+		BeforeEach(func() {
+			emitter = new(smartFakeEmitter)
+			monitor = metric.NewMonitor()
+
+			emitterFactory := new(metricfakes.FakeEmitterFactory)
+			emitterFactory.IsConfiguredReturns(true)
+			emitterFactory.NewEmitterReturns(emitter, nil)
+
+			monitor.RegisterEmitter(emitterFactory)
+			monitor.Initialize(testLogger, "test", map[string]string{}, 1000)
+		})
+
+		It("emits the duration and rows affected of the operation", func() {
+			metric.WorkerLifecycleEmitter{
+				Logger:  testLogger,
+				Monitor: monitor,
+			}.LifecycleQueryCompleted("stall-unresponsive-workers", 2*time.Second, 3)
+
+			Eventually(emitter.EmitCallCount).Should(Equal(2))
+
+			_, duration := emitter.EmitArgsForCall(0)
+			Expect(duration.Name).To(Equal("worker lifecycle query duration (ms)"))
+			Expect(duration.Value).To(Equal(float64(2000)))
+			Expect(duration.Attributes).To(HaveKeyWithValue("operation", "stall-unresponsive-workers"))
+
+			_, rowsAffected := emitter.EmitArgsForCall(1)
+			Expect(rowsAffected.Name).To(Equal("worker lifecycle query rows affected"))
+			Expect(rowsAffected.Value).To(Equal(float64(3)))
+			Expect(rowsAffected.Attributes).To(HaveKeyWithValue("operation", "stall-unresponsive-workers"))
+		})
+	})
 })
 
 type smartFakeEmitter struct {